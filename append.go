@@ -0,0 +1,88 @@
+package typedcsv
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+)
+
+// ErrHeaderMismatch is returned by CreateWriter with AppendMode when the header of the existing file is not the header of the records,
+// and by ReadHeader with WithStrictHeader when the header does not have exactly the columns of the fields.
+var ErrHeaderMismatch = errors.New("typedcsv: header mismatch")
+
+// WithoutHeader makes TypedCSVWriter.WriteHeader write nothing, for files without a header
+// or records appended to an existing file.
+func WithoutHeader() Option {
+	return func(o *options) {
+		o.noHeader = true
+	}
+}
+
+// AppendMode makes CreateWriter append the records to the file instead of truncating it.
+// If the file is not empty, its header must be the header written by WriteHeader, which then writes nothing;
+// otherwise CreateWriter returns an error wrapping ErrHeaderMismatch. With WithoutHeader, the file is not expected
+// to have a header and is not checked. A missing final line ending is added.
+// If the file does not exist or is empty, it is created and WriteHeader writes the header as usual.
+// Gzip files cannot be appended to.
+func AppendMode() Option {
+	return func(o *options) {
+		o.appendMode = true
+	}
+}
+
+// appendWriter opens the file at path for appending and checks its header.
+func appendWriter[T any](codec *Codec[T], path string) (*TypedCSVWriter[T], error) {
+	if strings.HasSuffix(path, ".gz") {
+		return nil, fmt.Errorf("typedcsv: cannot append to gzip file %s", path)
+	}
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	var expected []string
+	if !codec.options.noHeader {
+		expected = codec.fileHeader()
+	}
+	appending, err := checkAppendHeader(file, codec.options.dialect.newReader(file), expected)
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+	writer := codec.NewWriterTo(file)
+	writer.closers = []io.Closer{file}
+	writer.appending = appending
+	return writer, nil
+}
+
+// checkAppendHeader reports whether the file is not empty, after checking that its header is the expected header,
+// and terminates its last line if needed. A nil expected header, for files without a header, is not checked.
+func checkAppendHeader(file *os.File, reader *csv.Reader, expected []string) (bool, error) {
+	stat, err := file.Stat()
+	if err != nil || stat.Size() == 0 {
+		return false, err
+	}
+	if expected != nil {
+		header, err := reader.Read()
+		if err != nil && err != io.EOF {
+			return false, err
+		}
+		trimBOM(header)
+		if !slices.Equal(header, expected) {
+			return false, fmt.Errorf("%w: file has %q, expected %q", ErrHeaderMismatch, header, expected)
+		}
+	}
+	last := make([]byte, 1)
+	if _, err := file.ReadAt(last, stat.Size()-1); err != nil {
+		return false, err
+	}
+	if last[0] != '\n' {
+		if _, err := file.WriteString("\n"); err != nil {
+			return false, err
+		}
+	}
+	return true, nil
+}
@@ -0,0 +1,94 @@
+package typedcsv_test
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hoshiumiarata/typedcsv"
+)
+
+type AppendTestRecord struct {
+	ID   int    `csv:"id"`
+	Name string `csv:"name"`
+}
+
+func appendRecords(t *testing.T, path string, records ...AppendTestRecord) error {
+	csvWriter, err := typedcsv.CreateWriter[AppendTestRecord](path, typedcsv.AppendMode())
+	if err != nil {
+		return err
+	}
+	if err := csvWriter.WriteAll(records); err != nil {
+		t.Fatal(err)
+	}
+	return csvWriter.Close()
+}
+
+func TestAppendMode(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "records.csv")
+	if err := appendRecords(t, path, AppendTestRecord{1, "John"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := appendRecords(t, path, AppendTestRecord{2, "Jane"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte("\ufeffid,name\n1,John"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := appendRecords(t, path, AppendTestRecord{2, "Jane"}); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := "\ufeffid,name\n1,John\n2,Jane\n"; string(data) != expected {
+		t.Fatalf("Expected %q, got %q", expected, data)
+	}
+
+	if err := os.WriteFile(path, []byte("id,label\n1,John\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	err = appendRecords(t, path, AppendTestRecord{2, "Jane"})
+	if !errors.Is(err, typedcsv.ErrHeaderMismatch) {
+		t.Fatalf("Expected %v, got %v", typedcsv.ErrHeaderMismatch, err)
+	}
+}
+
+func TestWithoutHeader(t *testing.T) {
+	var buffer bytes.Buffer
+	csvWriter := typedcsv.NewWriter[AppendTestRecord](csv.NewWriter(&buffer), typedcsv.WithoutHeader())
+	if err := csvWriter.WriteAll([]AppendTestRecord{{1, "John"}}); err != nil {
+		t.Fatal(err)
+	}
+	if expected := "1,John\n"; buffer.String() != expected {
+		t.Fatalf("Expected %q, got %q", expected, buffer.String())
+	}
+}
+
+func TestAppendModeWithoutHeader(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "records.csv")
+	if err := os.WriteFile(path, []byte("1,John"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	csvWriter, err := typedcsv.CreateWriter[AppendTestRecord](path, typedcsv.AppendMode(), typedcsv.WithoutHeader())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := csvWriter.WriteAll([]AppendTestRecord{{2, "Jane"}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := csvWriter.Close(); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := "1,John\n2,Jane\n"; string(data) != expected {
+		t.Fatalf("Expected %q, got %q", expected, data)
+	}
+}
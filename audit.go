@@ -0,0 +1,34 @@
+package typedcsv
+
+import (
+	"reflect"
+	"strings"
+)
+
+// WithRoundTripAudit makes TypedCSVReader re-encode each decoded value with the write rules of its field,
+// the ones TypedCSVWriter uses, and report a WarningRoundTripMismatch when the result differs from the CSV value,
+// for example when a float loses precision, a number is normalized such as "007" read as 7, or a time drops its fractional seconds.
+// Reading still succeeds, so the warnings can prove that a file is read losslessly, or list the cells that are not.
+//
+// Empty values replaced by a "default" or "default_func" tag and values whose parsing reported another Warning are not audited.
+// Neither are the values with placeholders expanded by WithResolver, so that resolved secrets are never reported.
+// Auditing doubles the cost of decoding.
+func WithRoundTripAudit() Option {
+	return func(o *options) {
+		o.roundTripAudit = true
+	}
+}
+
+// audit re-encodes the decoded value of the field and reports a WarningRoundTripMismatch if it differs from the CSV value.
+func (f *field) audit(state *decodeState, fieldValue reflect.Value, value string) {
+	if state.options.trimSpace && !f.raw {
+		value = strings.TrimSpace(value)
+	}
+	if (value == "" && f.hasDefaults()) || f.resolves(state.options, value) {
+		return
+	}
+	encoded, err := f.encode(state.holder, fieldValue)
+	if err != nil || encoded != value {
+		state.warn(Warning{Kind: WarningRoundTripMismatch, Column: f.name, Index: -1, Value: value, Encoded: encoded})
+	}
+}
@@ -0,0 +1,64 @@
+package typedcsv_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hoshiumiarata/typedcsv"
+)
+
+type AuditTestRecord struct {
+	ID     int       `csv:"id"`
+	Amount float32   `csv:"amount"`
+	Ratio  float64   `csv:"ratio" format:"%.2f"`
+	At     time.Time `csv:"at" time_format:"2006-01-02T15:04:05Z07:00"`
+	Code   string    `csv:"code" default:"none"`
+}
+
+func TestRoundTripAudit(t *testing.T) {
+	input := "id,amount,ratio,at,code\n1,0.5,0.25,2024-01-02T03:04:05Z,\n007,0.1000000001,0.125,2024-01-02T03:04:05+00:00,x\n"
+	reader := typedcsv.NewReaderFrom[AuditTestRecord](strings.NewReader(input), typedcsv.WithRoundTripAudit())
+	if err := reader.ReadHeader(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := reader.ReadAll(); err != nil {
+		t.Fatal(err)
+	}
+	expected := []typedcsv.Warning{
+		{Kind: typedcsv.WarningRoundTripMismatch, Row: 2, Column: "id", Index: -1, Value: "007", Encoded: "7"},
+		{Kind: typedcsv.WarningRoundTripMismatch, Row: 2, Column: "amount", Index: -1, Value: "0.1000000001", Encoded: "0.1"},
+		{Kind: typedcsv.WarningRoundTripMismatch, Row: 2, Column: "ratio", Index: -1, Value: "0.125", Encoded: "0.12"},
+		{Kind: typedcsv.WarningRoundTripMismatch, Row: 2, Column: "at", Index: -1, Value: "2024-01-02T03:04:05+00:00", Encoded: "2024-01-02T03:04:05Z"},
+	}
+	if !reflect.DeepEqual(reader.Warnings(), expected) {
+		t.Fatalf("Expected %v, got %v", expected, reader.Warnings())
+	}
+	message := "typedcsv: round-trip mismatch 'id' in record 2 (value \"007\" re-encoded as \"7\")"
+	if expected[0].String() != message {
+		t.Fatalf("Expected %v, got %v", message, expected[0].String())
+	}
+}
+
+func TestRoundTripAuditResolver(t *testing.T) {
+	resolver := func(kind, key string) (string, error) {
+		if kind == "secret" {
+			return "hunter2", nil
+		}
+		return "5432", nil
+	}
+	input := "name,password,port,template,raw\nx,${secret:db_password},${PORT},${secret:db_password},y\n"
+	reader := typedcsv.NewReaderFrom[ResolverTestRecord](strings.NewReader(input), typedcsv.WithResolver(resolver), typedcsv.WithRoundTripAudit())
+	if err := reader.ReadHeader(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := reader.ReadRecord(); err != nil {
+		t.Fatal(err)
+	}
+	for _, warning := range reader.Warnings() {
+		if warning.Kind == typedcsv.WarningRoundTripMismatch || strings.Contains(warning.String(), "hunter2") {
+			t.Fatalf("Expected no round-trip mismatch, got %v", warning)
+		}
+	}
+}
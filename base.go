@@ -0,0 +1,62 @@
+package typedcsv
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+const baseTag = "base"
+
+// parseBaseTag parses the "base" tag value of a field whose values, or slice items, are of type t.
+func parseBaseTag(value string, t reflect.Type) (int, error) {
+	if !isIntegerKind(t.Kind()) {
+		return 0, fmt.Errorf("field type %v is not an integer", t)
+	}
+	base, err := strconv.Atoi(value)
+	if err != nil || base == 1 || base < 0 || base > 36 {
+		return 0, fmt.Errorf("invalid base %q, expected 0 or 2 to 36", value)
+	}
+	return base, nil
+}
+
+// isIntegerKind reports whether k is a signed or unsigned integer kind.
+func isIntegerKind(k reflect.Kind) bool {
+	return k >= reflect.Int && k <= reflect.Uintptr
+}
+
+// trimBasePrefix removes the prefix of base 16, 8 or 2 from the integer value, such as "0x" in "-0x1f", keeping its sign.
+// With base 0, strconv reads the prefix itself.
+func trimBasePrefix(value string, base int) string {
+	var prefix string
+	switch base {
+	case 16:
+		prefix = "0x"
+	case 8:
+		prefix = "0o"
+	case 2:
+		prefix = "0b"
+	default:
+		return value
+	}
+	sign, digits := "", value
+	if strings.HasPrefix(digits, "-") || strings.HasPrefix(digits, "+") {
+		sign, digits = digits[:1], digits[1:]
+	}
+	if len(digits) > len(prefix) && strings.EqualFold(digits[:len(prefix)], prefix) {
+		return sign + digits[len(prefix):]
+	}
+	return value
+}
+
+// formatInteger formats the integer value v in base, or in base 10 for base 0.
+func formatInteger(v reflect.Value, base int) string {
+	if base == 0 {
+		base = 10
+	}
+	if v.CanInt() {
+		return strconv.FormatInt(v.Int(), base)
+	}
+	return strconv.FormatUint(v.Uint(), base)
+}
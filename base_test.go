@@ -0,0 +1,98 @@
+package typedcsv_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/hoshiumiarata/typedcsv"
+)
+
+type BaseTestRecord struct {
+	ID     uint32   `csv:"id" base:"16"`
+	Mode   int      `csv:"mode" base:"8"`
+	Flags  uint8    `csv:"flags" base:"2"`
+	Offset *int64   `csv:"offset" base:"16" null:""`
+	Any    int      `csv:"any" base:"0"`
+	Words  []uint16 `csv:"words" base:"16" separator:" "`
+	Padded uint16   `csv:"padded" base:"16" format:"0x%04X"`
+}
+
+func TestBaseTag(t *testing.T) {
+	input := "id,mode,flags,offset,any,words,padded\n" +
+		"1f,755,1010,-0x10,0x1F,dead BEEF,0x00FF\n" +
+		"0XDEADBEEF,0o644,0b11,,0b101,0,ff\n"
+	reader := typedcsv.NewReaderFrom[BaseTestRecord](strings.NewReader(input))
+	if err := reader.ReadHeader(); err != nil {
+		t.Fatal(err)
+	}
+	records, err := reader.ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	first := records[0]
+	if first.ID != 0x1f || first.Mode != 0o755 || first.Flags != 0b1010 || *first.Offset != -16 || first.Any != 31 ||
+		len(first.Words) != 2 || first.Words[0] != 0xdead || first.Words[1] != 0xbeef || first.Padded != 0xff {
+		t.Fatalf("Expected the parsed values, got %+v", *first)
+	}
+	second := records[1]
+	if second.ID != 0xdeadbeef || second.Mode != 0o644 || second.Flags != 3 || second.Offset != nil || second.Any != 5 || second.Padded != 0xff {
+		t.Fatalf("Expected the parsed values, got %+v", *second)
+	}
+
+	var buffer strings.Builder
+	writer := typedcsv.NewWriterTo[BaseTestRecord](&buffer)
+	for _, record := range records {
+		if err := writer.WriteRecord(*record); err != nil {
+			t.Fatal(err)
+		}
+	}
+	writer.Flush()
+	expected := "1f,755,1010,-10,31,dead beef,0x00FF\n" +
+		"deadbeef,644,11,,5,0,0x00FF\n"
+	if buffer.String() != expected {
+		t.Fatalf("Expected %q, got %q", expected, buffer.String())
+	}
+
+	tests := []struct {
+		row      string
+		expected string
+	}{
+		{"1g,0,0,,0,0,0", `typedcsv: error parsing field 'id' in record 1 on line 2: strconv.ParseUint: parsing "1g": invalid syntax`},
+		{"0,8,0,,0,0,0", `typedcsv: error parsing field 'mode' in record 1 on line 2: strconv.ParseInt: parsing "8": invalid syntax`},
+		{"0,0,100000000,,0,0,0", `typedcsv: error parsing field 'flags' in record 1 on line 2: typedcsv: value out of range: '100000000' does not fit in uint8, whose range is 0 to 255`},
+		{"0,0,0,,0,1 10000,0", `typedcsv: error parsing field 'words[1]' in record 1 on line 2: typedcsv: value out of range: '10000' does not fit in uint16, whose range is 0 to 65535`},
+	}
+	for _, test := range tests {
+		reader := typedcsv.NewReaderFrom[BaseTestRecord](strings.NewReader("id,mode,flags,offset,any,words,padded\n" + test.row + "\n"))
+		if err := reader.ReadHeader(); err != nil {
+			t.Fatal(err)
+		}
+		_, err := reader.ReadRecord()
+		if err == nil || err.Error() != test.expected {
+			t.Fatalf("%q: Expected %v, got %v", test.row, test.expected, err)
+		}
+	}
+}
+
+type InvalidBaseTestRecord struct {
+	Name  string `csv:"name" base:"16"`
+	Count int    `csv:"count" base:"37"`
+}
+
+func TestBaseTagErrors(t *testing.T) {
+	err := typedcsv.NewCodec[InvalidBaseTestRecord]().Err()
+	expected := []string{
+		"typedcsv: invalid tag base on field Name: field type string is not an integer",
+		"typedcsv: invalid tag base on field Count: invalid base \"37\", expected 0 or 2 to 36",
+	}
+	for _, message := range expected {
+		if err == nil || !strings.Contains(err.Error(), message) {
+			t.Fatalf("Expected %v, got %v", message, err)
+		}
+	}
+	var tagErr typedcsv.TagError
+	if !errors.As(err, &tagErr) {
+		t.Fatalf("Expected a TagError, got %v", err)
+	}
+}
@@ -0,0 +1,113 @@
+package typedcsv_test
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/hoshiumiarata/typedcsv"
+)
+
+type BenchmarkRecord struct {
+	ID       int           `csv:"id"`
+	Name     string        `csv:"name"`
+	Score    float64       `csv:"score" format:"%.2f"`
+	Active   bool          `csv:"active"`
+	Created  time.Time     `csv:"created" time_format:"2006-01-02 15:04:05"`
+	Duration time.Duration `csv:"duration" duration_format:"seconds"`
+	Status   PersonStatus  `csv:"status"`
+}
+
+const benchmarkRows = 1000
+
+func benchmarkRecords() []BenchmarkRecord {
+	records := make([]BenchmarkRecord, benchmarkRows)
+	created := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	for i := range records {
+		records[i] = BenchmarkRecord{
+			ID:       i,
+			Name:     fmt.Sprintf("name%d", i),
+			Score:    float64(i) / 3,
+			Active:   i%2 == 0,
+			Created:  created.Add(time.Duration(i) * time.Minute),
+			Duration: time.Duration(i) * time.Second,
+			Status:   PersonStatus(i % 3),
+		}
+	}
+	return records
+}
+
+func benchmarkData(b *testing.B) []byte {
+	data, err := typedcsv.Marshal(benchmarkRecords())
+	if err != nil {
+		b.Fatal(err)
+	}
+	return data
+}
+
+func BenchmarkNewCodec(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		typedcsv.NewCodec[BenchmarkRecord]()
+	}
+}
+
+func BenchmarkReadRecord(b *testing.B) {
+	data := benchmarkData(b)
+	codec := typedcsv.NewCodec[BenchmarkRecord]()
+	b.SetBytes(int64(len(data)))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		csvReader := codec.NewReader(csv.NewReader(bytes.NewReader(data)))
+		if err := csvReader.ReadHeader(); err != nil {
+			b.Fatal(err)
+		}
+		for {
+			_, err := csvReader.ReadRecord()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
+
+func BenchmarkReadRecordInto(b *testing.B) {
+	data := benchmarkData(b)
+	codec := typedcsv.NewCodec[BenchmarkRecord]()
+	b.SetBytes(int64(len(data)))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		csvReader := codec.NewFastReader(bytes.NewReader(data))
+		if err := csvReader.ReadHeader(); err != nil {
+			b.Fatal(err)
+		}
+		record := codec.AcquireRecord()
+		for {
+			err := csvReader.ReadRecordInto(record)
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				b.Fatal(err)
+			}
+		}
+		codec.ReleaseRecord(record)
+	}
+}
+
+func BenchmarkWriteRecord(b *testing.B) {
+	records := benchmarkRecords()
+	codec := typedcsv.NewCodec[BenchmarkRecord]()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		csvWriter := codec.NewWriter(csv.NewWriter(io.Discard))
+		if err := csvWriter.WriteAll(records); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
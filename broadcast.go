@@ -0,0 +1,98 @@
+package typedcsv
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync"
+)
+
+// A Broadcaster reads the records of a RecordReader once and sends each of them to all its registered consumers,
+// which run concurrently, so that a single pass over a file can feed, for example, a validator, a loader and a stats collector.
+//
+// All the consumers receive the same records in the same order. The records are shared, not copied:
+// consumers must not modify them when they are pointers, such as the records of a TypedCSVReader.
+type Broadcaster[T any] struct {
+	reader    RecordReader[T]
+	consumers []broadcastConsumer[T]
+}
+
+type broadcastConsumer[T any] struct {
+	buffer  int
+	consume func(ctx context.Context, records <-chan T) error
+}
+
+// NewBroadcaster returns a new Broadcaster reading the records of r. The headers, if any, must be read before Run.
+func NewBroadcaster[T any](r RecordReader[T]) *Broadcaster[T] {
+	return &Broadcaster[T]{reader: r}
+}
+
+// Register adds a consumer, which Run calls in its own goroutine with the channel of the records.
+// The channel holds up to buffer records: a consumer can fall behind the fastest one by that many records,
+// after which reading waits for it to catch up. The channel is closed when there are no more records.
+//
+// A consumer returning an error stops Run, and cancels the context given to the other consumers.
+// A consumer returning nil before the channel is closed receives no more records, without blocking the others.
+// Register must not be called during Run.
+func (b *Broadcaster[T]) Register(buffer int, consume func(ctx context.Context, records <-chan T) error) {
+	b.consumers = append(b.consumers, broadcastConsumer[T]{buffer: buffer, consume: consume})
+}
+
+// Run reads all the records and sends them to the consumers, then waits for the consumers to return.
+// It returns the errors of the consumers and the first error of ReadRecord other than io.EOF, joined with errors.Join,
+// or the error of ctx if it is done before the records are read. The context given to the consumers is cancelled
+// with the error as its cause when reading fails, so that consumers can tell a truncated input from a complete one.
+func (b *Broadcaster[T]) Run(ctx context.Context) error {
+	consumerCtx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+	channels := make([]chan T, len(b.consumers))
+	errs := make([]error, len(b.consumers))
+	var wg sync.WaitGroup
+	for i, consumer := range b.consumers {
+		channels[i] = make(chan T, consumer.buffer)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			err := consumer.consume(consumerCtx, channels[i])
+			if err != nil {
+				errs[i] = err
+				cancel(err)
+			}
+			for range channels[i] {
+			}
+		}()
+	}
+	err := b.broadcast(ctx, consumerCtx, channels)
+	if err != nil {
+		cancel(err)
+	}
+	for _, channel := range channels {
+		close(channel)
+	}
+	wg.Wait()
+	return errors.Join(append([]error{err}, errs...)...)
+}
+
+// broadcast sends the records to the channels until there are no more records, reading fails,
+// or consumerCtx is done. It returns nil if consumerCtx was cancelled by a consumer.
+func (b *Broadcaster[T]) broadcast(ctx, consumerCtx context.Context, channels []chan T) error {
+	for {
+		if consumerCtx.Err() != nil {
+			return ctx.Err()
+		}
+		record, err := b.reader.ReadRecord()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		for _, channel := range channels {
+			select {
+			case channel <- record:
+			case <-consumerCtx.Done():
+				return ctx.Err()
+			}
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package typedcsv_test
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"testing"
+
+	"github.com/hoshiumiarata/typedcsv"
+)
+
+func TestBroadcaster(t *testing.T) {
+	csvReader := typedcsv.NewReader[AppendTestRecord](csv.NewReader(streamInput(100)))
+	if err := csvReader.ReadHeader(); err != nil {
+		t.Fatal(err)
+	}
+	broadcaster := typedcsv.NewBroadcaster[*AppendTestRecord](csvReader)
+	var sum, count, first int
+	broadcaster.Register(0, func(ctx context.Context, records <-chan *AppendTestRecord) error {
+		for record := range records {
+			sum += record.ID
+		}
+		return nil
+	})
+	broadcaster.Register(10, func(ctx context.Context, records <-chan *AppendTestRecord) error {
+		for range records {
+			count++
+		}
+		return nil
+	})
+	broadcaster.Register(1, func(ctx context.Context, records <-chan *AppendTestRecord) error {
+		first = (<-records).ID
+		return nil
+	})
+	if err := broadcaster.Run(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if sum != 5050 || count != 100 || first != 1 {
+		t.Fatalf("Expected 5050, 100 and 1, got %v, %v and %v", sum, count, first)
+	}
+}
+
+func TestBroadcasterError(t *testing.T) {
+	csvReader := typedcsv.NewReader[AppendTestRecord](csv.NewReader(streamInput(100)))
+	if err := csvReader.ReadHeader(); err != nil {
+		t.Fatal(err)
+	}
+	broadcaster := typedcsv.NewBroadcaster[*AppendTestRecord](csvReader)
+	invalid := errors.New("invalid record")
+	var cause error
+	broadcaster.Register(0, func(ctx context.Context, records <-chan *AppendTestRecord) error {
+		for record := range records {
+			if record.ID == 10 {
+				return invalid
+			}
+		}
+		return nil
+	})
+	broadcaster.Register(0, func(ctx context.Context, records <-chan *AppendTestRecord) error {
+		for range records {
+		}
+		cause = context.Cause(ctx)
+		return nil
+	})
+	err := broadcaster.Run(context.Background())
+	if !errors.Is(err, invalid) || cause != invalid {
+		t.Fatalf("Expected %v, got %v and %v", invalid, err, cause)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	broadcaster = typedcsv.NewBroadcaster[*AppendTestRecord](csvReader)
+	broadcaster.Register(0, func(ctx context.Context, records <-chan *AppendTestRecord) error {
+		for range records {
+		}
+		return nil
+	})
+	if err := broadcaster.Run(ctx); !errors.Is(err, context.Canceled) {
+		t.Fatalf("Expected %v, got %v", context.Canceled, err)
+	}
+}
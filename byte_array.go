@@ -0,0 +1,107 @@
+package typedcsv
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// A bytesCodec formats and parses [N]byte fields with the encoding given by the "encoding" tag value.
+type bytesCodec struct {
+	format func(b []byte) string
+	parse  func(value string, b []byte) error
+}
+
+// bytesCodecs are the encodings of [N]byte fields. Without an "encoding" tag value, [N]byte fields are arrays like others.
+var bytesCodecs = map[string]*bytesCodec{
+	"hex": {
+		format: hex.EncodeToString,
+		parse:  parseHexBytes,
+	},
+	"base64": {
+		format: base64.StdEncoding.EncodeToString,
+		parse: func(value string, b []byte) error {
+			decoded, err := base64.StdEncoding.DecodeString(value)
+			if err != nil {
+				return err
+			}
+			if len(decoded) != len(b) {
+				return fmt.Errorf("invalid length %d of %q, expected %d bytes", len(decoded), value, len(b))
+			}
+			copy(b, decoded)
+			return nil
+		},
+	},
+	"uuid": {
+		format: func(b []byte) string {
+			s := hex.EncodeToString(b)
+			return s[0:8] + "-" + s[8:12] + "-" + s[12:16] + "-" + s[16:20] + "-" + s[20:32]
+		},
+		parse: func(value string, b []byte) error {
+			text := strings.TrimPrefix(strings.ToLower(value), "urn:uuid:")
+			if strings.HasPrefix(text, "{") && strings.HasSuffix(text, "}") {
+				text = text[1 : len(text)-1]
+			}
+			if len(text) == 36 {
+				if text[8] != '-' || text[13] != '-' || text[18] != '-' || text[23] != '-' {
+					return fmt.Errorf("invalid UUID %q", value)
+				}
+				text = text[0:8] + text[9:13] + text[14:18] + text[19:23] + text[24:36]
+			}
+			if len(text) != 32 {
+				return fmt.Errorf("invalid UUID %q", value)
+			}
+			return parseHexBytes(text, b)
+		},
+	},
+}
+
+func parseHexBytes(value string, b []byte) error {
+	if hex.DecodedLen(len(value)) != len(b) {
+		return fmt.Errorf("invalid length %d of %q, expected %d hex digits", len(value), value, 2*len(b))
+	}
+	_, err := hex.Decode(b, []byte(value))
+	return err
+}
+
+// isByteArray reports whether t is an array of bytes, such as [16]byte.
+func isByteArray(t reflect.Type) bool {
+	return t.Kind() == reflect.Array && t.Elem().Kind() == reflect.Uint8
+}
+
+// bytesCodecOf returns the codec of the "encoding" tag value for the [N]byte type t.
+func bytesCodecOf(encoding string, t reflect.Type) (*bytesCodec, error) {
+	codec := bytesCodecs[encoding]
+	if codec == nil {
+		return nil, fmt.Errorf("unknown encoding %q, expected \"hex\", \"base64\" or \"uuid\"", encoding)
+	}
+	if encoding == "uuid" && t.Len() != 16 {
+		return nil, fmt.Errorf("encoding \"uuid\" requires a [16]byte field, got %v", t)
+	}
+	return codec, nil
+}
+
+// decodeBytes parses value into the [N]byte field.
+func (f *field) decodeBytes(fieldValue reflect.Value, value string) error {
+	if f.bytesErr != nil {
+		return FieldParseError{Field: f.name, NestedError: f.bytesErr}
+	}
+	b := make([]byte, f.typ.Len())
+	if err := f.bytes.parse(value, b); err != nil {
+		return FieldParseError{Field: f.name, NestedError: err}
+	}
+	reflect.Copy(fieldValue, reflect.ValueOf(b))
+	return nil
+}
+
+// encodeBytes formats the [N]byte field.
+func (f *field) encodeBytes(fieldValue reflect.Value) (string, error) {
+	if f.bytesErr != nil {
+		return "", FieldFormatError{Field: f.name, NestedError: f.bytesErr}
+	}
+	b := make([]byte, f.typ.Len())
+	reflect.Copy(reflect.ValueOf(b), fieldValue)
+	return f.bytes.format(b), nil
+}
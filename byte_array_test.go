@@ -0,0 +1,94 @@
+package typedcsv_test
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"testing"
+
+	"github.com/hoshiumiarata/typedcsv"
+)
+
+type ByteArrayTestRecord struct {
+	ID     [16]byte  `csv:"id" encoding:"uuid"`
+	Hash   [4]byte   `csv:"hash" encoding:"hex"`
+	Key    [3]byte   `csv:"key" encoding:"base64"`
+	Parent *[16]byte `csv:"parent" encoding:"uuid" null:""`
+}
+
+func TestByteArray(t *testing.T) {
+	record := ByteArrayTestRecord{
+		ID:   [16]byte{0x12, 0x3e, 0x45, 0x67, 0xe8, 0x9b, 0x12, 0xd3, 0xa4, 0x56, 0x42, 0x66, 0x14, 0x17, 0x40, 0x00},
+		Hash: [4]byte{0xde, 0xad, 0xbe, 0xef},
+		Key:  [3]byte{'a', 'b', 'c'},
+	}
+	buf := bytes.Buffer{}
+	csvWriter := typedcsv.NewWriter[ByteArrayTestRecord](csv.NewWriter(&buf))
+	err := csvWriter.WriteAll([]ByteArrayTestRecord{record})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := "id,hash,key,parent\n123e4567-e89b-12d3-a456-426614174000,deadbeef,YWJj,\n"
+	if buf.String() != expected {
+		t.Fatalf("Expected %q, got %q", expected, buf.String())
+	}
+
+	buf.WriteString("{123E4567-E89B-12D3-A456-426614174000},DEADBEEF,YWJj,urn:uuid:123e4567e89b12d3a456426614174000\n")
+	csvReader := typedcsv.NewReader[ByteArrayTestRecord](csv.NewReader(&buf))
+	err = csvReader.ReadHeader()
+	if err != nil {
+		t.Fatal(err)
+	}
+	records, err := csvReader.ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *records[0] != record {
+		t.Fatalf("Expected %v, got %v", record, *records[0])
+	}
+	if records[1].ID != record.ID || records[1].Hash != record.Hash || records[1].Parent == nil || *records[1].Parent != record.ID {
+		t.Fatalf("Expected %v, got %v", record, *records[1])
+	}
+
+	for _, row := range []string{"123e4567,deadbeef,YWJj,", "123e4567-e89b-12d3-a456-426614174000,deadbe,YWJj,", "123e4567-e89b-12d3-a456-426614174000,deadbeef,YWJjZA==,"} {
+		csvReader := typedcsv.NewReader[ByteArrayTestRecord](csv.NewReader(bytes.NewBufferString("id,hash,key,parent\n" + row + "\n")))
+		_ = csvReader.ReadHeader()
+		_, err := csvReader.ReadRecord()
+		var fieldParseError typedcsv.FieldParseError
+		if !errors.As(err, &fieldParseError) {
+			t.Fatalf("Expected a FieldParseError for %q, got %v", row, err)
+		}
+	}
+}
+
+type InvalidByteArrayTestRecord struct {
+	ID   [8]byte `csv:"id" encoding:"uuid"`
+	Name string  `csv:"name" encoding:"hex"`
+}
+
+func TestByteArrayInvalidTags(t *testing.T) {
+	err := typedcsv.NewCodec[InvalidByteArrayTestRecord]().Err()
+	var tagError typedcsv.TagError
+	if !errors.As(err, &tagError) || tagError.Field != "ID" {
+		t.Fatalf("Expected a TagError for ID, got %v", err)
+	}
+	joined := err.(interface{ Unwrap() []error }).Unwrap()
+	if len(joined) != 2 {
+		t.Fatalf("Expected 2 errors, got %v", err)
+	}
+}
+
+type ByteArrayDefaultTestRecord struct {
+	Hash [4]byte `csv:"hash"`
+}
+
+func TestByteArrayDefaultFormat(t *testing.T) {
+	data, err := typedcsv.Marshal([]ByteArrayDefaultTestRecord{{Hash: [4]byte{0xde, 0xad, 0xbe, 0xef}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := "hash\n[222 173 190 239]\n"
+	if string(data) != expected {
+		t.Fatalf("Expected %q, got %q", expected, data)
+	}
+}
@@ -0,0 +1,358 @@
+package typedcsv
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"reflect"
+	"sync"
+)
+
+// A Codec holds the options and the analysis of the struct type T shared by readers and writers.
+//
+// A Codec is immutable once created and safe for concurrent use,
+// so it can be built once and used to create many readers and writers.
+// Note that the hooks given in the options (for example, the logger or the metrics hook)
+// are shared by all readers and writers and must be safe for concurrent use themselves.
+type Codec[T any] struct {
+	fields  []field
+	header  []string
+	options options
+	err     error
+	typeErr error
+	raw     []bool
+	pool    sync.Pool
+	// positions are the column positions of the fields if they are mapped by position rather than by name, or nil.
+	positions []int
+	// selected marks the fields decoded because of WithColumns, or is nil if all the fields are decoded.
+	selected []bool
+	// layout is the index of the field of each column written by TypedCSVWriter, or nil if they are written in field order.
+	layout []int
+}
+
+// NewCodec returns a new Codec for T configured with the given options.
+func NewCodec[T any](opts ...Option) *Codec[T] {
+	o := newOptions(opts)
+	var zero [0]T
+	t := reflect.TypeOf(zero).Elem()
+	var fields []field
+	if t.Kind() == reflect.Struct {
+		fields = fieldsOf(t, &o)
+	}
+	typeErr := checkType(t, fields)
+	header := make([]string, len(fields))
+	var raw []bool
+	for i := range fields {
+		header[i] = fields[i].name
+		if fields[i].raw {
+			if raw == nil {
+				raw = make([]bool, len(fields))
+			}
+			raw[i] = true
+		}
+	}
+	positions, positionErrs := positionsOf(fields, &o)
+	layout := layoutOf(positions)
+	if raw != nil && layout != nil {
+		// raw marks the columns written by TypedCSVWriter.
+		arranged := make([]bool, len(layout))
+		for column, i := range layout {
+			arranged[column] = i >= 0 && raw[i]
+		}
+		raw = arranged
+	}
+	err := checkTags(fields, "", positionErrs...)
+	if err != nil && o.logger != nil {
+		o.logger.Warn("typedcsv: invalid struct tags", "type", t.String(), "error", err)
+	}
+	if typeErr != nil {
+		err = typeErr
+	}
+	return &Codec[T]{
+		fields:    fields,
+		header:    header,
+		options:   o,
+		err:       err,
+		typeErr:   typeErr,
+		raw:       raw,
+		positions: positions,
+		selected:  selectedFields(fields, &o),
+		layout:    layout,
+	}
+}
+
+// checkType returns a TypeError if the record type t cannot be used because none of its fields is mapped to a column.
+func checkType(t reflect.Type, fields []field) error {
+	if t.Kind() != reflect.Struct {
+		return TypeError{Type: t, Message: "not a struct", Err: ErrNotStruct}
+	}
+	if len(fields) > 0 {
+		return nil
+	}
+	for i := 0; i < t.NumField(); i++ {
+		if t.Field(i).IsExported() || t.Field(i).Anonymous {
+			return TypeError{Type: t, Message: "no field is mapped to a column", Err: ErrNoCSVFields}
+		}
+	}
+	return TypeError{Type: t, Message: "no exported fields", Err: ErrNoCSVFields}
+}
+
+// Err returns the misconfigured struct tags of T found when the Codec was built, as TagErrors joined with errors.Join,
+// or nil if there are none: duplicate column names, a "format" verb that cannot format the field type,
+// a "separator" on a field that is not a slice, or time and duration tags on fields of other types.
+// Such tags are otherwise ignored or fail when a record is read or written.
+// They are also logged with the logger set by WithLogger.
+//
+// If T is not a struct or has no field mapped to a column, Err returns a TypeError instead,
+// which is also returned by the first read or write of the readers and writers of the Codec.
+func (c *Codec[T]) Err() error {
+	return c.err
+}
+
+// NewReader returns a new TypedCSVReader that wraps the given csv.Reader and uses the Codec.
+func (c *Codec[T]) NewReader(reader *csv.Reader) *TypedCSVReader[T] {
+	return &TypedCSVReader[T]{
+		Reader: reader,
+		codec:  c,
+	}
+}
+
+// NewFastReader returns a new TypedCSVReader that reads from r with a Tokenizer and uses the Codec.
+func (c *Codec[T]) NewFastReader(r io.Reader) *TypedCSVReader[T] {
+	return &TypedCSVReader[T]{
+		codec:     c,
+		tokenizer: NewTokenizer(r),
+	}
+}
+
+// NewWriter returns a new TypedCSVWriter that wraps the given csv.Writer and uses the Codec.
+func (c *Codec[T]) NewWriter(writer *csv.Writer) *TypedCSVWriter[T] {
+	return &TypedCSVWriter[T]{
+		Writer: writer,
+		codec:  c,
+	}
+}
+
+// NewFastWriter returns a new TypedCSVWriter that writes to w with a TokenWriter and uses the Codec.
+func (c *Codec[T]) NewFastWriter(w io.Writer) *TypedCSVWriter[T] {
+	tokenWriter := NewTokenWriter(w)
+	tokenWriter.Quoting = c.options.quoting
+	return &TypedCSVWriter[T]{
+		codec:       c,
+		tokenWriter: tokenWriter,
+	}
+}
+
+// AcquireRecord returns a zero record from the pool of the Codec, allocating a new one if the pool is empty.
+// With TypedCSVReader.ReadRecordInto and ReleaseRecord, it lets consumers that fully process each record
+// before releasing it read with almost no allocations per record.
+func (c *Codec[T]) AcquireRecord() *T {
+	if record, ok := c.pool.Get().(*T); ok {
+		return record
+	}
+	return new(T)
+}
+
+// ReleaseRecord zeroes the record and puts it back in the pool of the Codec.
+// The record must not be used after it is released.
+func (c *Codec[T]) ReleaseRecord(record *T) {
+	var zero T
+	*record = zero
+	c.pool.Put(record)
+}
+
+// encode formats the fields of the record in the order of the header.
+func (c *Codec[T]) encode(record T) ([]string, error) {
+	return c.encodeRecord(record, false, true)
+}
+
+// encodeRecord formats the fields of the record in the order of the header. If generate is true,
+// the zero values of the fields with a "default_func" tag are replaced by generated values, which only TypedCSVWriter does,
+// so that comparisons and hashes of records stay deterministic. If validate is false, the formatted values are not
+// validated, so that records can be compared whatever their values.
+func (c *Codec[T]) encodeRecord(record T, generate, validate bool) ([]string, error) {
+	return c.encodeFields(record, generate, validate, nil)
+}
+
+// encodeFields is encodeRecord, also marking in nulls, if not nil, the fields written as null values:
+// nil pointers, fields of nil embedded pointers and fields excluded by their "include_if" tag.
+func (c *Codec[T]) encodeFields(record T, generate, validate bool, nulls []bool) ([]string, error) {
+	recordValue := reflect.ValueOf(record)
+	values := make([]string, len(c.fields))
+	for i := range c.fields {
+		field := &c.fields[i]
+		included, err := field.included(recordValue)
+		if err != nil {
+			return nil, err
+		}
+		fieldValue, ok := field.valueForEncode(recordValue)
+		if !ok || !included {
+			values[i] = field.null
+			if nulls != nil {
+				nulls[i] = true
+			}
+			continue
+		}
+		if generate && !field.hasDefault && field.hasDefaults() && fieldValue.IsZero() {
+			fieldValue, err = field.generateDefault(fieldValue.Type())
+			if err != nil {
+				return nil, FieldFormatError{Field: field.name, NestedError: err}
+			}
+		}
+		if nulls != nil {
+			nulls[i] = field.pointer && fieldValue.IsNil()
+		}
+		value, err := field.encodeWith(recordValue, fieldValue, validate)
+		if err != nil {
+			return nil, err
+		}
+		values[i] = value
+	}
+	return values, nil
+}
+
+// fileRow returns the row written by TypedCSVWriter for the record: the values of the fields with their generated defaults,
+// validated and arranged in the order of the columns, followed by the row hash if WithRowHash is set.
+// It is shared by all the export formats, so that they write the same values.
+func (c *Codec[T]) fileRow(record T) ([]string, error) {
+	values, err := c.encodeRecord(record, true, true)
+	if err != nil {
+		return nil, err
+	}
+	values = c.arrange(values)
+	if rowHash := c.options.rowHash; rowHash != nil {
+		values = append(values, rowHash.sum(values, -1))
+	}
+	return values, nil
+}
+
+// fileFields returns the index of the field of each column of fileRow, or -1 for an empty column and the row hash column.
+func (c *Codec[T]) fileFields() []int {
+	fields := c.layout
+	if fields == nil {
+		fields = make([]int, len(c.fields))
+		for i := range fields {
+			fields[i] = i
+		}
+	}
+	if c.options.rowHash != nil {
+		fields = append(fields[:len(fields):len(fields)], -1)
+	}
+	return fields
+}
+
+// Header returns the column names of the fields, in the order used by TypedCSVWriter.
+// If the fields are mapped by position, the columns are in position order, with empty names for the positions without a field.
+func (c *Codec[T]) Header() []string {
+	return append([]string(nil), c.arrange(c.header)...)
+}
+
+// fileHeader returns the header written by TypedCSVWriter: the column names followed by the row hash column if WithRowHash is set.
+func (c *Codec[T]) fileHeader() []string {
+	if rowHash := c.options.rowHash; rowHash != nil {
+		return append(c.Header(), rowHash.column)
+	}
+	return c.arrange(c.header)
+}
+
+// DecodeRow decodes a row into a new record. The header maps the column names to their indices in the row.
+// It can be used with row sources other than encoding/csv.
+// It returns a FieldParseError if a field cannot be parsed.
+func (c *Codec[T]) DecodeRow(header map[string]int, row []string) (*T, error) {
+	if c.typeErr != nil {
+		return nil, c.typeErr
+	}
+	state := decodeState{options: &c.options}
+	return c.decode(&state, c.bind(header), row)
+}
+
+// EncodeRow encodes the record into a row, in the order of Header.
+// It can be used with row destinations other than encoding/csv.
+// It returns a FieldFormatError if a field cannot be formatted.
+func (c *Codec[T]) EncodeRow(record T) ([]string, error) {
+	if c.typeErr != nil {
+		return nil, c.typeErr
+	}
+	values, err := c.encode(record)
+	if err != nil {
+		return nil, err
+	}
+	return c.arrange(values), nil
+}
+
+// bind returns the index in the header of the column of each field, or -1 if the field has no column.
+func (c *Codec[T]) bind(header map[string]int) []int {
+	canonical := make(map[string]int, len(header))
+	for column, index := range header {
+		key := c.options.nameMapper.Canonical(column)
+		if existing, ok := canonical[key]; !ok || index < existing {
+			canonical[key] = index
+		}
+	}
+	columns := make([]int, len(c.fields))
+	for i, f := range c.fields {
+		index, ok := canonical[f.key]
+		if !ok {
+			index = -1
+		}
+		columns[i] = index
+	}
+	return columns
+}
+
+// decode decodes the values into a new record. columns are the indices of the fields in the values, as returned by bind.
+func (c *Codec[T]) decode(state *decodeState, columns []int, values []string) (*T, error) {
+	record := new(T)
+	return record, c.decodeInto(state, columns, values, record)
+}
+
+// decodeInto decodes the values into the given zero record.
+func (c *Codec[T]) decodeInto(state *decodeState, columns []int, values []string, record *T) error {
+	recordValue := reflect.ValueOf(record).Elem()
+	state.holder = recordValue
+	for i := range c.fields {
+		if c.selected != nil && !c.selected[i] {
+			// The fields not selected by WithColumns keep their zero values.
+			continue
+		}
+		field := &c.fields[i]
+		index := columns[i]
+		if (index < 0 || index >= len(values)) && field.hasDefaults() {
+			if err := field.decode(state, field.valueForDecode(recordValue), field.defaultValue); err != nil {
+				return err
+			}
+			continue
+		}
+		if index < 0 {
+			if field.required() {
+				return field.errMissingColumn()
+			}
+			continue
+		}
+		if index >= len(values) {
+			if field.required() {
+				return FieldParseError{Field: field.name, NestedError: fmt.Errorf("%w: value is required", ErrValidation)}
+			}
+			state.warn(Warning{Kind: WarningMissingValue, Column: field.name, Index: -1})
+			continue
+		}
+		fieldValue := field.valueForDecode(recordValue)
+		state.warned = false
+		if state.cache == nil || !state.cache.get(i, field, values[index], fieldValue) {
+			err := field.decode(state, fieldValue, values[index])
+			if err != nil {
+				return err
+			}
+			if state.cache != nil && !state.warned {
+				state.cache.put(i, field, values[index], fieldValue)
+			}
+			if state.interner != nil {
+				state.interner.internField(i, fieldValue)
+			}
+		}
+		if state.options.roundTripAudit && !state.warned {
+			field.audit(state, fieldValue, values[index])
+		}
+	}
+	return nil
+}
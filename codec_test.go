@@ -0,0 +1,80 @@
+package typedcsv_test
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/hoshiumiarata/typedcsv"
+)
+
+func TestCodec(t *testing.T) {
+	codec := typedcsv.NewCodec[MarshalTextTestRecord]()
+	var wg sync.WaitGroup
+	errs := make(chan error, 10)
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(status PersonStatus) {
+			defer wg.Done()
+			writer := bytes.Buffer{}
+			csvWriter := codec.NewWriter(csv.NewWriter(&writer))
+			err := csvWriter.WriteHeader()
+			if err == nil {
+				err = csvWriter.WriteRecord(MarshalTextTestRecord{PersonStatus: status})
+			}
+			csvWriter.Flush()
+			if err != nil {
+				errs <- err
+				return
+			}
+			csvReader := codec.NewReader(csv.NewReader(strings.NewReader(writer.String())))
+			err = csvReader.ReadHeader()
+			if err != nil {
+				errs <- err
+				return
+			}
+			record, err := csvReader.ReadRecord()
+			if err != nil {
+				errs <- err
+				return
+			}
+			if record.PersonStatus != status {
+				t.Errorf("Expected %v, got %v", status, record.PersonStatus)
+			}
+		}(PersonStatus(i % 3))
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Fatal(err)
+	}
+}
+
+func TestCodecDecodeEncodeRow(t *testing.T) {
+	codec := typedcsv.NewCodec[MarshalTextTestRecord]()
+	record, err := codec.DecodeRow(map[string]int{"other": 0, "person_status": 1}, []string{"x", "inactive"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if record.PersonStatus != PersonStatusInactive {
+		t.Fatalf("Expected %v, got %v", PersonStatusInactive, record.PersonStatus)
+	}
+	row, err := codec.EncodeRow(*record)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(row) != 1 || row[0] != "inactive" {
+		t.Fatalf("Expected %v, got %v", []string{"inactive"}, row)
+	}
+	if header := codec.Header(); len(header) != 1 || header[0] != "person_status" {
+		t.Fatalf("Expected %v, got %v", []string{"person_status"}, header)
+	}
+	_, err = codec.DecodeRow(map[string]int{"person_status": 0}, []string{"abcdef"})
+	var fieldParseError typedcsv.FieldParseError
+	if !errors.As(err, &fieldParseError) {
+		t.Fatalf("Expected %T, got %T", fieldParseError, err)
+	}
+}
@@ -0,0 +1,76 @@
+package typedcsv
+
+import "bytes"
+
+// WithColumns makes TypedCSVReader decode only the fields mapped to the given columns; the other fields keep their zero values
+// and are not reported as missing. For a reader created by NewFastReader, the Tokenizer also skips the cells of the other columns
+// and stops splitting a line after the last selected column, which saves most of the work on very wide files.
+// The fast path is not used with WithRowHash, which needs all the cells.
+func WithColumns(columns ...string) Option {
+	return func(o *options) {
+		o.columns = columns
+	}
+}
+
+// selectedFields reports, for each field, whether it is decoded because of WithColumns, or returns nil if all the fields are.
+func selectedFields(fields []field, o *options) []bool {
+	if o.columns == nil {
+		return nil
+	}
+	keys := make(map[string]bool, len(o.columns))
+	for _, column := range o.columns {
+		keys[o.nameMapper.Canonical(column)] = true
+	}
+	selected := make([]bool, len(fields))
+	for i := range fields {
+		selected[i] = keys[fields[i].key]
+	}
+	return selected
+}
+
+// selectColumns unbinds the fields that are not selected by WithColumns and makes the Tokenizer, if any,
+// split only the cells of the selected columns.
+func (r *TypedCSVReader[T]) selectColumns() {
+	if r.codec.selected == nil {
+		return
+	}
+	columns := make([]int, len(r.columns))
+	last := -1
+	for i, index := range r.columns {
+		columns[i] = -1
+		if r.codec.selected[i] {
+			columns[i] = index
+			last = max(last, index)
+		}
+	}
+	r.columns = columns
+	if r.tokenizer == nil || r.codec.options.rowHash != nil {
+		return
+	}
+	r.tokenizer.needed = make([]bool, last+1)
+	for _, index := range columns {
+		if index >= 0 {
+			r.tokenizer.needed[index] = true
+		}
+	}
+}
+
+// splitNeeded splits a line without quotes like strings.Split, but only converts the cells marked in needed to strings
+// and stops after the last of them. The other cells are empty.
+func (t *Tokenizer) splitNeeded(line, comma []byte) []string {
+	values := make([]string, len(t.needed))
+	for i, needed := range t.needed {
+		end := bytes.Index(line, comma)
+		if end < 0 {
+			if needed {
+				values[i] = string(line)
+			}
+			return values[:i+1]
+		}
+		if needed {
+			values[i] = string(line[:end])
+		}
+		line = line[end+len(comma):]
+	}
+	return values
+}
@@ -0,0 +1,118 @@
+package typedcsv_test
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hoshiumiarata/typedcsv"
+)
+
+type ColumnsTestRecord struct {
+	ID    int    `csv:"id"`
+	Name  string `csv:"name"`
+	Email string `csv:"email"`
+	Note  string `csv:"note"`
+}
+
+func TestWithColumns(t *testing.T) {
+	input := "id,x,name,email,y\n1,a,John,john@example.com,b\n2,\"c,d\",Jane,jane@example.com,e\n3\n"
+	for _, fast := range []bool{false, true} {
+		var csvReader *typedcsv.TypedCSVReader[ColumnsTestRecord]
+		if fast {
+			csvReader = typedcsv.NewFastReader[ColumnsTestRecord](strings.NewReader(input), typedcsv.WithColumns("id", "name"))
+		} else {
+			reader := csv.NewReader(strings.NewReader(input))
+			reader.FieldsPerRecord = -1
+			csvReader = typedcsv.NewReader[ColumnsTestRecord](reader, typedcsv.WithColumns("id", "name"))
+		}
+		if err := csvReader.ReadHeader(); err != nil {
+			t.Fatal(err)
+		}
+		if warnings := csvReader.Warnings(); len(warnings) != 2 || warnings[0].Column != "x" {
+			t.Fatalf("Expected unknown columns x and y only, got %v", warnings)
+		}
+		records, err := csvReader.ReadAll()
+		if err != nil {
+			t.Fatal(err)
+		}
+		expected := []ColumnsTestRecord{{ID: 1, Name: "John"}, {ID: 2, Name: "Jane"}, {ID: 3}}
+		if len(records) != len(expected) {
+			t.Fatalf("Expected %v records, got %v", len(expected), len(records))
+		}
+		for i := range expected {
+			if *records[i] != expected[i] {
+				t.Fatalf("fast=%v: Expected %v, got %v", fast, expected[i], *records[i])
+			}
+		}
+	}
+}
+
+func BenchmarkReadRecordColumns(b *testing.B) {
+	var buffer bytes.Buffer
+	for i := 0; i < 300; i++ {
+		if i > 0 {
+			buffer.WriteByte(',')
+		}
+		switch i {
+		case 10:
+			buffer.WriteString("id")
+		case 250:
+			buffer.WriteString("name")
+		default:
+			fmt.Fprintf(&buffer, "c%d", i)
+		}
+	}
+	buffer.WriteByte('\n')
+	for row := 0; row < benchmarkRows; row++ {
+		for i := 0; i < 300; i++ {
+			if i > 0 {
+				buffer.WriteByte(',')
+			}
+			fmt.Fprintf(&buffer, "%d", row*i)
+		}
+		buffer.WriteByte('\n')
+	}
+	data := buffer.Bytes()
+	codec := typedcsv.NewCodec[ColumnsTestRecord](typedcsv.WithColumns("id", "name"))
+	b.SetBytes(int64(len(data)))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		csvReader := codec.NewFastReader(bytes.NewReader(data))
+		if err := csvReader.ReadHeader(); err != nil {
+			b.Fatal(err)
+		}
+		if _, err := csvReader.ReadAll(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+type RequiredColumnsTestRecord struct {
+	ID    int    `csv:"id"`
+	Name  string `csv:"name" required:"true"`
+	Score int    `csv:"score" default:"5"`
+}
+
+func TestWithColumnsRequiredNotSelected(t *testing.T) {
+	input := "id,name,score\n1,John,3\n2,,\n"
+	reader := typedcsv.NewReaderFrom[RequiredColumnsTestRecord](strings.NewReader(input), typedcsv.WithColumns("id"))
+	if err := reader.ReadHeader(); err != nil {
+		t.Fatal(err)
+	}
+	records, err := reader.ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []RequiredColumnsTestRecord{{ID: 1}, {ID: 2}}
+	if len(records) != len(expected) {
+		t.Fatalf("Expected %v records, got %v", len(expected), len(records))
+	}
+	for i := range expected {
+		if *records[i] != expected[i] {
+			t.Fatalf("Expected %v, got %v", expected[i], *records[i])
+		}
+	}
+}
@@ -0,0 +1,103 @@
+package typedcsv
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// WithNullValue sets the value representing nil of the pointer fields without a "null" tag,
+// so that one struct can be used with feeds writing null values differently, such as "" and "NULL".
+func WithNullValue(value string) Option {
+	return func(o *options) {
+		o.tagDefaults = append(o.tagDefaults, tagDefault{key: nullTag, value: value, applies: isPointerType})
+	}
+}
+
+// WithTimeFormat sets the time layout of the time fields without a "time_format" tag. See WithColumnTimeFormat
+// to set the layout of one column instead, overriding its tag.
+func WithTimeFormat(layout string) Option {
+	return func(o *options) {
+		o.tagDefaults = append(o.tagDefaults, tagDefault{key: timeFormatTag, value: layout, applies: isTimeType})
+	}
+}
+
+// WithSeparator sets the separator of the items of the slice fields without a "separator" tag.
+func WithSeparator(separator string) Option {
+	return func(o *options) {
+		o.tagDefaults = append(o.tagDefaults, tagDefault{key: separatorTag, value: separator, applies: isSliceType})
+	}
+}
+
+// WithTrimSpace makes TypedCSVReader remove the leading and trailing white space of the header columns and of the values,
+// except the values of raw fields, before they are parsed.
+func WithTrimSpace() Option {
+	return func(o *options) {
+		o.trimSpace = true
+	}
+}
+
+// WithStrictHeader makes ReadHeader fail if the header does not have exactly the columns of the fields:
+// a duplicate column, a missing column or an unknown column makes it return an error wrapping ErrHeaderMismatch,
+// which lists all of them. The columns of the fields not selected by WithColumns may be missing.
+func WithStrictHeader() Option {
+	return func(o *options) {
+		o.strictHeader = true
+	}
+}
+
+// A tagDefault is the value of a tag for the struct fields of the types it applies to that do not have the tag.
+type tagDefault struct {
+	key     string
+	value   string
+	applies func(reflect.Type) bool
+}
+
+// defaultTags returns the tag of a struct field of type t with the defaults that apply to it appended,
+// so that they are only found by StructTag.Lookup if the tag does not have their key.
+func (o *options) defaultTags(t reflect.Type, tag reflect.StructTag) reflect.StructTag {
+	var builder strings.Builder
+	// StructTag.Lookup returns the first match, so the last default of a key must come first.
+	for i := len(o.tagDefaults) - 1; i >= 0; i-- {
+		tagDefault := o.tagDefaults[i]
+		if !tagDefault.applies(t) {
+			continue
+		}
+		builder.WriteByte(' ')
+		builder.WriteString(tagDefault.key)
+		builder.WriteByte(':')
+		builder.WriteString(strconv.Quote(tagDefault.value))
+	}
+	if builder.Len() == 0 {
+		return tag
+	}
+	return reflect.StructTag(string(tag) + builder.String())
+}
+
+func isPointerType(t reflect.Type) bool {
+	return t.Kind() == reflect.Ptr
+}
+
+func isTimeType(t reflect.Type) bool {
+	return indirectType(t).ConvertibleTo(timeType)
+}
+
+func isSliceType(t reflect.Type) bool {
+	return indirectType(t).Kind() == reflect.Slice
+}
+
+// checkStrictHeader returns an error wrapping ErrHeaderMismatch listing the header warnings, if any.
+func checkStrictHeader(warnings []Warning) error {
+	var mismatches []string
+	for _, w := range warnings {
+		switch w.Kind {
+		case WarningDuplicateColumn, WarningMissingColumn, WarningUnknownColumn:
+			mismatches = append(mismatches, fmt.Sprintf("%s '%s'", w.Kind, w.Column))
+		}
+	}
+	if mismatches == nil {
+		return nil
+	}
+	return fmt.Errorf("%w: %s", ErrHeaderMismatch, strings.Join(mismatches, ", "))
+}
@@ -0,0 +1,67 @@
+package typedcsv_test
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hoshiumiarata/typedcsv"
+)
+
+type FeedTestRecord struct {
+	ID      int        `csv:"id"`
+	Date    time.Time  `csv:"date"`
+	Shipped *time.Time `csv:"shipped"`
+	Tags    []string   `csv:"tags"`
+	Note    *string    `csv:"note" null:""`
+	Code    string     `csv:"code"`
+}
+
+func TestConventionOptions(t *testing.T) {
+	opts := []typedcsv.Option{
+		typedcsv.WithNullValue("NULL"),
+		typedcsv.WithTimeFormat("02/01/2006"),
+		typedcsv.WithSeparator("|"),
+		typedcsv.WithTrimSpace(),
+	}
+	input := " id , date,shipped,tags,note,code\n1, 31/12/2024 ,NULL,a|b,NULL, x \n"
+	reader := typedcsv.NewReaderFrom[FeedTestRecord](strings.NewReader(input), opts...)
+	if err := reader.ReadHeader(); err != nil {
+		t.Fatal(err)
+	}
+	record, err := reader.ReadRecord()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if record.ID != 1 || !record.Date.Equal(time.Date(2024, 12, 31, 0, 0, 0, 0, time.UTC)) || record.Shipped != nil ||
+		!slices.Equal(record.Tags, []string{"a", "b"}) || record.Note == nil || *record.Note != "NULL" || record.Code != "x" {
+		t.Fatalf("Expected the record of the feed, got %+v", record)
+	}
+
+	var buffer bytes.Buffer
+	writer := typedcsv.NewWriter[FeedTestRecord](csv.NewWriter(&buffer), opts...)
+	if err := writer.WriteAll([]FeedTestRecord{*record}); err != nil {
+		t.Fatal(err)
+	}
+	expected := "id,date,shipped,tags,note,code\n1,31/12/2024,NULL,a|b,NULL,x\n"
+	if buffer.String() != expected {
+		t.Fatalf("Expected %q, got %q", expected, buffer.String())
+	}
+}
+
+func TestStrictHeader(t *testing.T) {
+	reader := typedcsv.NewReaderFrom[AppendTestRecord](strings.NewReader("id,id,extra\n1,2,x\n"), typedcsv.WithStrictHeader())
+	err := reader.ReadHeader()
+	expected := "typedcsv: header mismatch: duplicate column 'id', missing column 'name', unknown column 'extra'"
+	if err == nil || err.Error() != expected || !errors.Is(err, typedcsv.ErrHeaderMismatch) {
+		t.Fatalf("Expected %v, got %v", expected, err)
+	}
+	reader = typedcsv.NewReaderFrom[AppendTestRecord](strings.NewReader("name,id\nAlice,1\n"), typedcsv.WithStrictHeader())
+	if err := reader.ReadHeader(); err != nil {
+		t.Fatal(err)
+	}
+}
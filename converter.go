@@ -0,0 +1,69 @@
+package typedcsv
+
+import (
+	"reflect"
+	"sync"
+)
+
+// A converter parses and formats the values of a type that cannot implement encoding.TextUnmarshaler
+// and encoding.TextMarshaler, such as a type of a third-party package.
+type converter struct {
+	parse  func(value string) (reflect.Value, error)
+	format func(value reflect.Value) (string, error)
+}
+
+var (
+	convertersMutex sync.RWMutex
+	converters      = make(map[reflect.Type]*converter)
+)
+
+// RegisterConverter registers the functions parsing and formatting the values of type V for all the readers and writers,
+// so that types that cannot be modified to implement encoding.TextUnmarshaler and encoding.TextMarshaler can be used as fields.
+// A nil function leaves the values parsed or formatted as usual. Use WithConverter to set the functions of some readers
+// and writers only.
+//
+// The functions apply to the fields of type V and *V, which still use their "null" tag, and take precedence over
+// the other tags and methods of V. They must be registered before creating the readers, writers and codecs using them,
+// typically in an init function. Registering the functions of a type again replaces them.
+func RegisterConverter[V any](parse func(string) (V, error), format func(V) (string, error)) {
+	convertersMutex.Lock()
+	defer convertersMutex.Unlock()
+	converters[reflect.TypeFor[V]()] = newConverter(parse, format)
+}
+
+// WithConverter sets the functions parsing and formatting the values of type V, like RegisterConverter,
+// for the readers and writers created with the option only. It overrides RegisterConverter.
+func WithConverter[V any](parse func(string) (V, error), format func(V) (string, error)) Option {
+	return func(o *options) {
+		if o.converters == nil {
+			o.converters = make(map[reflect.Type]*converter)
+		}
+		o.converters[reflect.TypeFor[V]()] = newConverter(parse, format)
+	}
+}
+
+func newConverter[V any](parse func(string) (V, error), format func(V) (string, error)) *converter {
+	c := &converter{}
+	if parse != nil {
+		c.parse = func(value string) (reflect.Value, error) {
+			v, err := parse(value)
+			return reflect.ValueOf(&v).Elem(), err
+		}
+	}
+	if format != nil {
+		c.format = func(value reflect.Value) (string, error) {
+			return format(value.Interface().(V))
+		}
+	}
+	return c
+}
+
+// converterOf returns the converter of the type t set by WithConverter or RegisterConverter, or nil.
+func (o *options) converterOf(t reflect.Type) *converter {
+	if c, ok := o.converters[t]; ok {
+		return c
+	}
+	convertersMutex.RLock()
+	defer convertersMutex.RUnlock()
+	return converters[t]
+}
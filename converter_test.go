@@ -0,0 +1,87 @@
+package typedcsv_test
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hoshiumiarata/typedcsv"
+)
+
+// Money stands for a type of a third-party package, without UnmarshalText and MarshalText methods.
+type Money struct {
+	Cents int64
+}
+
+type Coordinates struct {
+	Lat, Lon float64
+}
+
+type ConverterTestRecord struct {
+	Price    Money        `csv:"price"`
+	Discount *Money       `csv:"discount" null:""`
+	Position Coordinates  `csv:"position"`
+	Previous *Coordinates `csv:"previous" null:"-"`
+}
+
+func parseMoney(value string) (Money, error) {
+	var units, cents int64
+	if _, err := fmt.Sscanf(value, "%d.%02d", &units, &cents); err != nil {
+		return Money{}, err
+	}
+	return Money{Cents: units*100 + cents}, nil
+}
+
+func formatMoney(m Money) (string, error) {
+	if m.Cents < 0 {
+		return "", errors.New("negative amount")
+	}
+	return fmt.Sprintf("%d.%02d", m.Cents/100, m.Cents%100), nil
+}
+
+func init() {
+	typedcsv.RegisterConverter(parseMoney, formatMoney)
+}
+
+func TestConverter(t *testing.T) {
+	coordinates := typedcsv.WithConverter(
+		func(value string) (Coordinates, error) {
+			var c Coordinates
+			_, err := fmt.Sscanf(value, "%f %f", &c.Lat, &c.Lon)
+			return c, err
+		},
+		func(c Coordinates) (string, error) {
+			return fmt.Sprintf("%g %g", c.Lat, c.Lon), nil
+		},
+	)
+	input := "price,discount,position,previous\n12.50,,48.85 2.35,-\n"
+	reader := typedcsv.NewReaderFrom[ConverterTestRecord](strings.NewReader(input), coordinates)
+	if err := reader.ReadHeader(); err != nil {
+		t.Fatal(err)
+	}
+	record, err := reader.ReadRecord()
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := ConverterTestRecord{Price: Money{Cents: 1250}, Position: Coordinates{Lat: 48.85, Lon: 2.35}}
+	if *record != expected {
+		t.Fatalf("Expected %+v, got %+v", expected, *record)
+	}
+
+	var buffer bytes.Buffer
+	record.Discount = &Money{Cents: 5}
+	if err := typedcsv.NewWriterTo[ConverterTestRecord](&buffer, coordinates).WriteAll([]ConverterTestRecord{*record}); err != nil {
+		t.Fatal(err)
+	}
+	if expected := "price,discount,position,previous\n12.50,0.05,48.85 2.35,-\n"; buffer.String() != expected {
+		t.Fatalf("Expected %q, got %q", expected, buffer.String())
+	}
+
+	err = typedcsv.NewWriterTo[ConverterTestRecord](&buffer).WriteRecord(ConverterTestRecord{Price: Money{Cents: -1}})
+	expectedErr := "typedcsv: error formatting field 'price' in record 1: negative amount"
+	if err == nil || err.Error() != expectedErr {
+		t.Fatalf("Expected %v, got %v", expectedErr, err)
+	}
+}
@@ -0,0 +1,73 @@
+package typedcsv
+
+import (
+	"reflect"
+	"strings"
+)
+
+// WithCSVUtilTags makes the "csv" tag follow the semantics of github.com/jszwec/csvutil, to ease migration from it:
+//
+//   - the tag value is a column name followed by comma-separated options, for example `csv:"name,omitempty"`.
+//   - "-" means that the field is not mapped. Fields without a tag are mapped to their Go name.
+//   - the "omitempty" option writes an empty value when the field has its zero value.
+//   - the "inline" option maps the fields of a struct field as if they were fields of the outer struct,
+//     with their column names prefixed by the tag name. Embedded structs without a tag are inlined without a prefix.
+//
+// The other tags, such as "null" or "time_format", keep working as usual.
+// It overrides any previous WithNameMapper or WithTagKey option.
+func WithCSVUtilTags() Option {
+	return func(o *options) {
+		o.csvutil = true
+		o.nameMapper = csvutilNameMapper{}
+	}
+}
+
+type csvutilNameMapper struct{}
+
+func (csvutilNameMapper) ColumnName(field reflect.StructField) string {
+	name, _ := parseCSVUtilTag(field)
+	if name == "-" {
+		return ""
+	}
+	if name == "" {
+		return field.Name
+	}
+	return name
+}
+
+func (csvutilNameMapper) Canonical(column string) string {
+	return column
+}
+
+func parseCSVUtilTag(field reflect.StructField) (name string, tagOptions []string) {
+	tag, ok := field.Tag.Lookup(csvTag)
+	if !ok {
+		if field.Anonymous && indirectType(field.Type).Kind() == reflect.Struct {
+			return "", []string{"inline"}
+		}
+		return "", nil
+	}
+	parts := strings.Split(tag, ",")
+	return parts[0], parts[1:]
+}
+
+func hasTagOption(tagOptions []string, option string) bool {
+	for _, tagOption := range tagOptions {
+		if tagOption == option {
+			return true
+		}
+	}
+	return false
+}
+
+func isInlineStruct(field reflect.StructField, tagOptions []string) bool {
+	t := indirectType(field.Type)
+	return hasTagOption(tagOptions, "inline") && t.Kind() == reflect.Struct && t != timeType
+}
+
+func indirectType(t reflect.Type) reflect.Type {
+	if t.Kind() == reflect.Ptr {
+		return t.Elem()
+	}
+	return t
+}
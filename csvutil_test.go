@@ -0,0 +1,74 @@
+package typedcsv_test
+
+import (
+	"bytes"
+	"encoding/csv"
+	"reflect"
+	"testing"
+
+	"github.com/hoshiumiarata/typedcsv"
+)
+
+type CSVUtilAddress struct {
+	Street string `csv:"street"`
+	City   string `csv:"city,omitempty"`
+}
+
+type CSVUtilBase struct {
+	ID int `csv:"id"`
+}
+
+type CSVUtilTestRecord struct {
+	CSVUtilBase
+	Name     string
+	Age      int             `csv:"age,omitempty"`
+	Address  CSVUtilAddress  `csv:"address_,inline"`
+	Shipping *CSVUtilAddress `csv:"shipping_,inline"`
+	Ignored  string          `csv:"-"`
+}
+
+func TestWithCSVUtilTags(t *testing.T) {
+	writer := bytes.Buffer{}
+	csvWriter := typedcsv.NewWriter[CSVUtilTestRecord](csv.NewWriter(&writer), typedcsv.WithCSVUtilTags())
+	err := csvWriter.WriteHeader()
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = csvWriter.WriteRecord(CSVUtilTestRecord{
+		CSVUtilBase: CSVUtilBase{ID: 1},
+		Name:        "John",
+		Address:     CSVUtilAddress{Street: "Main St"},
+		Ignored:     "x",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	csvWriter.Flush()
+	expected := "id,Name,age,address_street,address_city,shipping_street,shipping_city\n1,John,,Main St,,,\n"
+	if writer.String() != expected {
+		t.Fatalf("Expected %q, got %q", expected, writer.String())
+	}
+
+	reader := bytes.Buffer{}
+	reader.WriteString("Name,id,age,address_city,shipping_street\n")
+	reader.WriteString("Mary,2,30,Tokyo,Broadway\n")
+	csvReader := typedcsv.NewReader[CSVUtilTestRecord](csv.NewReader(&reader), typedcsv.WithCSVUtilTags())
+	err = csvReader.ReadHeader()
+	if err != nil {
+		t.Fatal(err)
+	}
+	record, err := csvReader.ReadRecord()
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectedRecord := &CSVUtilTestRecord{
+		CSVUtilBase: CSVUtilBase{ID: 2},
+		Name:        "Mary",
+		Age:         30,
+		Address:     CSVUtilAddress{City: "Tokyo"},
+		Shipping:    &CSVUtilAddress{Street: "Broadway"},
+	}
+	if !reflect.DeepEqual(record, expectedRecord) {
+		t.Fatalf("Expected %v, got %v", expectedRecord, record)
+	}
+}
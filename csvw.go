@@ -0,0 +1,192 @@
+package typedcsv
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// CSVWMetadata returns W3C CSV on the Web (CSVW) metadata describing a CSV file at the given URL written by TypedCSVWriter for T.
+//
+// Each column is described with its title, its datatype derived from the field type and tags,
+// its null value from the "null" tag, and its separator from the "separator" tag.
+// The "desc" and "unit" tag values are given as the dc:description and schema:unitText annotations of the column.
+// Time layouts are converted to the date format patterns of CSVW when possible.
+func CSVWMetadata[T any](fileURL string, opts ...Option) ([]byte, error) {
+	codec := NewCodec[T](opts...)
+	if codec.typeErr != nil {
+		return nil, codec.typeErr
+	}
+	columns := make([]map[string]any, 0, len(codec.fields))
+	for i := range codec.fields {
+		field := &codec.fields[i]
+		column := map[string]any{
+			"name":     csvwName(field.name),
+			"titles":   field.name,
+			"datatype": field.csvwDatatype(),
+		}
+		if field.hasNull {
+			column["null"] = field.null
+		}
+		if field.slice && field.separator != "" {
+			column["separator"] = field.separator
+		}
+		if field.description != "" {
+			column["dc:description"] = field.description
+		}
+		if field.unit != "" {
+			column["schema:unitText"] = field.unit
+		}
+		columns = append(columns, column)
+	}
+	metadata := map[string]any{
+		"@context": "http://www.w3.org/ns/csvw",
+		"url":      fileURL,
+		"tableSchema": map[string]any{
+			"columns": columns,
+		},
+	}
+	return json.MarshalIndent(metadata, "", "  ")
+}
+
+func csvwName(column string) string {
+	var builder strings.Builder
+	for _, b := range []byte(column) {
+		if b == '_' || (b >= 'a' && b <= 'z') || (b >= 'A' && b <= 'Z') || (b >= '0' && b <= '9') {
+			builder.WriteByte(b)
+		} else {
+			fmt.Fprintf(&builder, "%%%02X", b)
+		}
+	}
+	return builder.String()
+}
+
+func (f *field) csvwDatatype() any {
+	if f.enum != nil {
+		patterns := make([]string, len(f.enum))
+		for i, value := range f.enum {
+			patterns[i] = regexp.QuoteMeta(value)
+		}
+		return map[string]any{"base": "string", "format": strings.Join(patterns, "|")}
+	}
+	if f.timeFormatted() {
+		switch f.timeFormat {
+		case time.RFC3339, time.RFC3339Nano:
+			return "dateTime"
+		}
+		pattern, ok := csvwDatePattern(f.timeFormat)
+		if !ok {
+			return "string"
+		}
+		return map[string]any{"base": csvwTimeBase(f.timeFormat), "format": pattern}
+	}
+	if f.time && f.marshaler {
+		return "dateTime"
+	}
+	if f.marshaler || f.hasFormat {
+		return "string"
+	}
+	t := f.typ
+	if f.slice {
+		t = f.sliceItemType
+	}
+	return csvwBaseType(t)
+}
+
+func csvwBaseType(t reflect.Type) string {
+	if t.Implements(textMarshalerType) {
+		return "string"
+	}
+	switch t.Kind() {
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Int8:
+		return "byte"
+	case reflect.Int16:
+		return "short"
+	case reflect.Int32:
+		return "int"
+	case reflect.Int, reflect.Int64:
+		return "long"
+	case reflect.Uint8:
+		return "unsignedByte"
+	case reflect.Uint16:
+		return "unsignedShort"
+	case reflect.Uint32:
+		return "unsignedInt"
+	case reflect.Uint, reflect.Uint64, reflect.Uintptr:
+		return "unsignedLong"
+	case reflect.Float32:
+		return "float"
+	case reflect.Float64:
+		return "double"
+	default:
+		return "string"
+	}
+}
+
+func csvwTimeBase(layout string) string {
+	hasDate := strings.Contains(layout, "2006") || strings.Contains(layout, "06")
+	hasTime := strings.Contains(layout, "15") || strings.Contains(layout, "03") || strings.Contains(layout, "04")
+	switch {
+	case hasDate && hasTime:
+		return "dateTime"
+	case hasTime:
+		return "time"
+	default:
+		return "date"
+	}
+}
+
+// csvwLayoutTokens maps Go layout elements to CSVW (UAX35) date field patterns, longest first.
+var csvwLayoutTokens = []struct {
+	layout  string
+	pattern string
+}{
+	{"January", "MMMM"}, {"Monday", "EEEE"},
+	{"Z07:00", "XXX"}, {"-07:00", "xxx"}, {"Z0700", "XX"}, {"-0700", "xx"},
+	{".000000000", ".SSSSSSSSS"}, {".000000", ".SSSSSS"}, {".000", ".SSS"},
+	{"2006", "yyyy"}, {"Jan", "MMM"}, {"Mon", "EEE"}, {"MST", "z"},
+	{"01", "MM"}, {"02", "dd"}, {"_2", "d"}, {"15", "HH"}, {"03", "hh"}, {"04", "mm"}, {"05", "ss"},
+	{"06", "yy"}, {"PM", "a"}, {"pm", "a"},
+	{"1", "M"}, {"2", "d"}, {"3", "h"}, {"4", "m"}, {"5", "s"},
+}
+
+// csvwDatePattern converts a Go time layout to a CSVW date format pattern.
+// It returns false if the layout contains elements that cannot be converted.
+func csvwDatePattern(layout string) (string, bool) {
+	var builder strings.Builder
+	var literal strings.Builder
+	flush := func() {
+		if literal.Len() == 0 {
+			return
+		}
+		text := literal.String()
+		if strings.ContainsFunc(text, func(r rune) bool { return (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || r == '\'' }) {
+			text = "'" + strings.ReplaceAll(text, "'", "''") + "'"
+		}
+		builder.WriteString(text)
+		literal.Reset()
+	}
+next:
+	for len(layout) > 0 {
+		for _, token := range csvwLayoutTokens {
+			if strings.HasPrefix(layout, token.layout) {
+				flush()
+				builder.WriteString(token.pattern)
+				layout = layout[len(token.layout):]
+				continue next
+			}
+		}
+		if strings.HasPrefix(layout, ".9") || strings.HasPrefix(layout, ",9") || strings.HasPrefix(layout, "002") {
+			return "", false
+		}
+		literal.WriteByte(layout[0])
+		layout = layout[1:]
+	}
+	flush()
+	return builder.String(), true
+}
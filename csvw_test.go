@@ -0,0 +1,47 @@
+package typedcsv_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/hoshiumiarata/typedcsv"
+)
+
+type CSVWTestRecord struct {
+	Name     string     `csv:"full name"`
+	Level    string     `csv:"level" enum:"low|high"`
+	Age      uint8      `csv:"age"`
+	Tags     []int      `csv:"tags" separator:";"`
+	Optional *float64   `csv:"optional" null:"NULL"`
+	Time     CustomTime `csv:"time" time_format:"02/01/2006 15:04 MST"`
+}
+
+func TestCSVWMetadata(t *testing.T) {
+	data, err := typedcsv.CSVWMetadata[CSVWTestRecord]("records.csv")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var metadata map[string]any
+	err = json.Unmarshal(data, &metadata)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := map[string]any{
+		"@context": "http://www.w3.org/ns/csvw",
+		"url":      "records.csv",
+		"tableSchema": map[string]any{
+			"columns": []any{
+				map[string]any{"name": "full%20name", "titles": "full name", "datatype": "string"},
+				map[string]any{"name": "level", "titles": "level", "datatype": map[string]any{"base": "string", "format": "low|high"}},
+				map[string]any{"name": "age", "titles": "age", "datatype": "unsignedByte"},
+				map[string]any{"name": "tags", "titles": "tags", "datatype": "long", "separator": ";"},
+				map[string]any{"name": "optional", "titles": "optional", "datatype": "double", "null": "NULL"},
+				map[string]any{"name": "time", "titles": "time", "datatype": map[string]any{"base": "dateTime", "format": "dd/MM/yyyy HH:mm z"}},
+			},
+		},
+	}
+	if !reflect.DeepEqual(metadata, expected) {
+		t.Fatalf("Expected %v, got %v", expected, metadata)
+	}
+}
@@ -0,0 +1,145 @@
+package typedcsv
+
+import (
+	"hash/fnv"
+	"math"
+	"reflect"
+)
+
+// A DedupeReader reads the records of a RecordReader and drops the records whose key was already seen,
+// for upstream systems that send some rows twice. To drop them within a TypedCSVReader, use WithDedupeWindow instead.
+type DedupeReader[T any] struct {
+	reader  RecordReader[T]
+	key     func(T) string
+	seen    func(key string) bool
+	dropped int
+}
+
+// NewDedupeReader returns a new DedupeReader that drops the records whose key was seen within the last window records read
+// from r, including the dropped ones. If window is zero or negative, the keys of all the records are kept in memory,
+// and a record is dropped if its key was seen anywhere before; see NewBloomDedupeReader for very large inputs.
+func NewDedupeReader[T any](r RecordReader[T], window int, key func(T) string) *DedupeReader[T] {
+	return &DedupeReader[T]{reader: r, key: key, seen: newWindowFilter(window)}
+}
+
+// NewBloomDedupeReader returns a new DedupeReader that drops the records whose key was seen anywhere before,
+// remembering the keys in a Bloom filter sized for the expected number of records and the false positive rate,
+// such as 0.001, instead of keeping them in memory. A false positive drops a record whose key was never seen.
+func NewBloomDedupeReader[T any](r RecordReader[T], expected int, falsePositiveRate float64, key func(T) string) *DedupeReader[T] {
+	filter := newBloomFilter(expected, falsePositiveRate)
+	return &DedupeReader[T]{reader: r, key: key, seen: filter.testAndAdd}
+}
+
+// WithDedupeWindow makes TypedCSVReader drop the records whose key was seen within the last window records read,
+// including the dropped ones, like NewDedupeReader. If window is zero or negative, a record is dropped if its key was seen
+// anywhere before. T is the record type of the reader; with another record type, ReadRecord returns an error.
+// The records are dropped after they are decoded, so the records that cannot be decoded are not deduplicated.
+// Dropped returns the number of records dropped. The keys seen are forgotten by Reset and Rewind.
+func WithDedupeWindow[T any](window int, key func(T) string) Option {
+	return withDedupe(key, func() func(string) bool { return newWindowFilter(window) })
+}
+
+// WithBloomDedupe makes TypedCSVReader drop the records whose key was seen anywhere before, remembering the keys in a
+// Bloom filter like NewBloomDedupeReader, for very large inputs. It is otherwise like WithDedupeWindow.
+func WithBloomDedupe[T any](expected int, falsePositiveRate float64, key func(T) string) Option {
+	return withDedupe(key, func() func(string) bool { return newBloomFilter(expected, falsePositiveRate).testAndAdd })
+}
+
+// A dedupeOption is the deduplication set by WithDedupeWindow or WithBloomDedupe.
+type dedupeOption struct {
+	typ reflect.Type
+	// key returns the key of a *T record.
+	key func(record any) string
+	// newFilter returns a new filter reporting whether a key was seen, and remembering it, for each reader.
+	newFilter func() func(key string) bool
+}
+
+func withDedupe[T any](key func(T) string, newFilter func() func(string) bool) Option {
+	return func(o *options) {
+		o.dedupe = &dedupeOption{
+			typ:       reflect.TypeFor[T](),
+			key:       func(record any) string { return key(*record.(*T)) },
+			newFilter: newFilter,
+		}
+	}
+}
+
+// newWindowFilter returns a filter reporting whether a key was seen within the last window keys, or anywhere before
+// if window is zero or negative, and remembering it.
+func newWindowFilter(window int) func(key string) bool {
+	counts := make(map[string]int)
+	var ring []string
+	next := 0
+	return func(key string) bool {
+		duplicate := counts[key] > 0
+		if window <= 0 {
+			counts[key] = 1
+			return duplicate
+		}
+		if len(ring) < window {
+			ring = append(ring, key)
+		} else {
+			evicted := ring[next]
+			if counts[evicted]--; counts[evicted] == 0 {
+				delete(counts, evicted)
+			}
+			ring[next] = key
+			next = (next + 1) % window
+		}
+		counts[key]++
+		return duplicate
+	}
+}
+
+// ReadRecord returns the next record whose key was not seen. It returns io.EOF when there are no more records,
+// and any error of the underlying RecordReader.
+func (r *DedupeReader[T]) ReadRecord() (T, error) {
+	for {
+		record, err := r.reader.ReadRecord()
+		if err != nil {
+			return record, err
+		}
+		if !r.seen(r.key(record)) {
+			return record, nil
+		}
+		r.dropped++
+	}
+}
+
+// Dropped returns the number of records dropped so far.
+func (r *DedupeReader[T]) Dropped() int {
+	return r.dropped
+}
+
+// A bloomFilter is a Bloom filter of strings using double hashing of their FNV-1a hash.
+type bloomFilter struct {
+	bits   []uint64
+	size   uint64
+	hashes int
+}
+
+func newBloomFilter(expected int, falsePositiveRate float64) *bloomFilter {
+	n := math.Max(float64(expected), 1)
+	p := math.Min(math.Max(falsePositiveRate, 1e-12), 0.5)
+	size := uint64(math.Ceil(-n * math.Log(p) / (math.Ln2 * math.Ln2)))
+	hashes := int(math.Max(math.Round(float64(size)/n*math.Ln2), 1))
+	return &bloomFilter{bits: make([]uint64, (size+63)/64), size: size, hashes: hashes}
+}
+
+// testAndAdd reports whether the key may have been added before, and adds it.
+func (f *bloomFilter) testAndAdd(key string) bool {
+	hash := fnv.New64a()
+	hash.Write([]byte(key))
+	sum := hash.Sum64()
+	h1, h2 := sum&math.MaxUint32, sum>>32|1
+	present := true
+	for i := 0; i < f.hashes; i++ {
+		bit := (h1 + uint64(i)*h2) % f.size
+		word, mask := bit/64, uint64(1)<<(bit%64)
+		if f.bits[word]&mask == 0 {
+			present = false
+			f.bits[word] |= mask
+		}
+	}
+	return present
+}
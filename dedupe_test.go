@@ -0,0 +1,103 @@
+package typedcsv_test
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/hoshiumiarata/typedcsv"
+)
+
+func readDedupeIDs(t *testing.T, reader *typedcsv.DedupeReader[*AppendTestRecord]) []int {
+	var ids []int
+	for {
+		record, err := reader.ReadRecord()
+		if err == io.EOF {
+			return ids
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids = append(ids, record.ID)
+	}
+}
+
+func TestDedupeReader(t *testing.T) {
+	input := "id,name\n1,a\n2,b\n1,c\n3,d\n4,e\n1,f\n"
+	key := func(record *AppendTestRecord) string { return fmt.Sprint(record.ID) }
+	tests := []struct {
+		window   int
+		expected string
+		dropped  int
+	}{
+		{2, "[1 2 3 4 1]", 1},
+		{0, "[1 2 3 4]", 2},
+	}
+	for _, test := range tests {
+		csvReader := typedcsv.NewReader[AppendTestRecord](csv.NewReader(bytes.NewBufferString(input)))
+		if err := csvReader.ReadHeader(); err != nil {
+			t.Fatal(err)
+		}
+		reader := typedcsv.NewDedupeReader[*AppendTestRecord](csvReader, test.window, key)
+		if ids := fmt.Sprint(readDedupeIDs(t, reader)); ids != test.expected || reader.Dropped() != test.dropped {
+			t.Fatalf("window %d: Expected %v with %d dropped, got %v with %d dropped", test.window, test.expected, test.dropped, ids, reader.Dropped())
+		}
+	}
+}
+
+func TestBloomDedupeReader(t *testing.T) {
+	var buffer bytes.Buffer
+	buffer.WriteString("id,name\n")
+	for i := 0; i < 2000; i++ {
+		fmt.Fprintf(&buffer, "%d,x\n", i%1000)
+	}
+	csvReader := typedcsv.NewReader[AppendTestRecord](csv.NewReader(&buffer))
+	if err := csvReader.ReadHeader(); err != nil {
+		t.Fatal(err)
+	}
+	reader := typedcsv.NewBloomDedupeReader[*AppendTestRecord](csvReader, 1000, 0.001, func(record *AppendTestRecord) string { return fmt.Sprint(record.ID) })
+	ids := readDedupeIDs(t, reader)
+	if len(ids) < 995 || len(ids) > 1000 || reader.Dropped() != 2000-len(ids) {
+		t.Fatalf("Expected about 1000 records, got %v with %d dropped", len(ids), reader.Dropped())
+	}
+}
+
+func TestWithDedupeWindow(t *testing.T) {
+	input := "id,name\n1,a\n2,b\n1,c\n3,d\n4,e\n1,f\n"
+	key := func(record AppendTestRecord) string { return fmt.Sprint(record.ID) }
+	tests := []struct {
+		option   typedcsv.Option
+		expected string
+		dropped  int
+	}{
+		{typedcsv.WithDedupeWindow(2, key), "[1 2 3 4 1]", 1},
+		{typedcsv.WithDedupeWindow(0, key), "[1 2 3 4]", 2},
+		{typedcsv.WithBloomDedupe(10, 0.001, key), "[1 2 3 4]", 2},
+	}
+	for _, test := range tests {
+		csvReader := typedcsv.NewReader[AppendTestRecord](csv.NewReader(bytes.NewBufferString(input)), test.option)
+		if err := csvReader.ReadHeader(); err != nil {
+			t.Fatal(err)
+		}
+		var ids []int
+		for record, err := range csvReader.All() {
+			if err != nil {
+				t.Fatal(err)
+			}
+			ids = append(ids, record.ID)
+		}
+		if fmt.Sprint(ids) != test.expected || csvReader.Dropped() != test.dropped {
+			t.Fatalf("Expected %v with %d dropped, got %v with %d dropped", test.expected, test.dropped, ids, csvReader.Dropped())
+		}
+	}
+
+	csvReader := typedcsv.NewReader[Person](csv.NewReader(bytes.NewBufferString("name\nJohn\n")), typedcsv.WithDedupeWindow(0, key))
+	if err := csvReader.ReadHeader(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := csvReader.ReadRecord(); err == nil {
+		t.Fatal("Expected error, got nil")
+	}
+}
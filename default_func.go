@@ -0,0 +1,80 @@
+package typedcsv
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+)
+
+const defaultFuncTag = "default_func"
+
+var (
+	defaultFuncsMutex sync.RWMutex
+	defaultFuncs      = map[string]func() any{
+		"now": func() any { return time.Now() },
+	}
+)
+
+// RegisterDefaultFunc registers a function generating default values under the given name, for the "default_func" tag value
+// of all the readers and writers, such as a generator of IDs. The function returns a value of the field type,
+// or of a type convertible to it; strings are only converted to strings. The "now" function, returning time.Now(),
+// is registered by default. Use WithDefaultFunc to register a function for some readers and writers only.
+//
+// Functions must be registered before creating the readers, writers and codecs using them, typically in an init function,
+// and may be called concurrently. Registering a function under the same name again replaces it.
+func RegisterDefaultFunc(name string, fn func() any) {
+	defaultFuncsMutex.Lock()
+	defer defaultFuncsMutex.Unlock()
+	defaultFuncs[name] = fn
+}
+
+// WithDefaultFunc registers a function generating default values, like RegisterDefaultFunc,
+// for the readers and writers created with the option only. It overrides RegisterDefaultFunc.
+func WithDefaultFunc(name string, fn func() any) Option {
+	return func(o *options) {
+		if o.defaultFuncs == nil {
+			o.defaultFuncs = make(map[string]func() any)
+		}
+		o.defaultFuncs[name] = fn
+	}
+}
+
+// defaultFuncOf returns the function registered under the name by WithDefaultFunc or RegisterDefaultFunc.
+func (o *options) defaultFuncOf(name string) (func() any, error) {
+	if fn, ok := o.defaultFuncs[name]; ok {
+		return fn, nil
+	}
+	defaultFuncsMutex.RLock()
+	defer defaultFuncsMutex.RUnlock()
+	if fn, ok := defaultFuncs[name]; ok {
+		return fn, nil
+	}
+	return nil, fmt.Errorf("unknown default_func %q", name)
+}
+
+// hasDefaults reports whether the field has a "default" or a "default_func" tag.
+func (f *field) hasDefaults() bool {
+	return f.hasDefault || f.defaultFunc != nil || f.defaultFuncErr != nil
+}
+
+// generateDefault calls the default function of the field and returns its result as a value of type t,
+// the type of the struct field.
+func (f *field) generateDefault(t reflect.Type) (reflect.Value, error) {
+	if f.defaultFuncErr != nil {
+		return reflect.Value{}, f.defaultFuncErr
+	}
+	result := f.defaultFunc()
+	generated := reflect.ValueOf(result)
+	if !generated.IsValid() || !generated.Type().ConvertibleTo(f.typ) ||
+		(generated.Kind() == reflect.String) != (f.typ.Kind() == reflect.String) {
+		return reflect.Value{}, fmt.Errorf("default_func %q returned %T, not %v", f.defaultFuncName, result, f.typ)
+	}
+	value := generated.Convert(f.typ)
+	if t.Kind() == reflect.Ptr {
+		pointer := reflect.New(f.typ)
+		pointer.Elem().Set(value)
+		return pointer, nil
+	}
+	return value, nil
+}
@@ -0,0 +1,83 @@
+package typedcsv_test
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hoshiumiarata/typedcsv"
+)
+
+type DefaultFuncTestRecord struct {
+	ID        string     `csv:"id" default_func:"sequence"`
+	Name      string     `csv:"name"`
+	CreatedAt time.Time  `csv:"created_at" time_format:"2006-01-02" default_func:"now"`
+	UpdatedAt *time.Time `csv:"updated_at" time_format:"2006-01-02" null:"" default_func:"now"`
+}
+
+func sequenceOption() typedcsv.Option {
+	next := 0
+	return typedcsv.WithDefaultFunc("sequence", func() any {
+		next++
+		return fmt.Sprintf("id-%d", next)
+	})
+}
+
+func TestDefaultFunc(t *testing.T) {
+	today := time.Now().Format("2006-01-02")
+	reader := typedcsv.NewReaderFrom[DefaultFuncTestRecord](strings.NewReader("id,name,created_at\n,Alice,\nx,Bob,2020-01-02\n"), sequenceOption())
+	if err := reader.ReadHeader(); err != nil {
+		t.Fatal(err)
+	}
+	records, err := reader.ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if records[0].ID != "id-1" || records[0].CreatedAt.Format("2006-01-02") != today || records[0].UpdatedAt == nil {
+		t.Fatalf("Expected generated values, got %+v", *records[0])
+	}
+	if records[1].ID != "x" || records[1].CreatedAt.Format("2006-01-02") != "2020-01-02" {
+		t.Fatalf("Expected the CSV values, got %+v", *records[1])
+	}
+
+	var buffer bytes.Buffer
+	writer := typedcsv.NewWriterTo[DefaultFuncTestRecord](&buffer, sequenceOption())
+	if err := writer.WriteAll([]DefaultFuncTestRecord{{Name: "Carol"}, {ID: "y", Name: "Dave", CreatedAt: records[1].CreatedAt}}); err != nil {
+		t.Fatal(err)
+	}
+	expected := "id,name,created_at,updated_at\nid-1,Carol," + today + "," + today + "\ny,Dave,2020-01-02," + today + "\n"
+	if buffer.String() != expected {
+		t.Fatalf("Expected %q, got %q", expected, buffer.String())
+	}
+
+	reader = typedcsv.NewReaderFrom[DefaultFuncTestRecord](strings.NewReader("id,name\n,Alice\n"))
+	if err := reader.ReadHeader(); err != nil {
+		t.Fatal(err)
+	}
+	_, err = reader.ReadRecord()
+	expectedErr := `typedcsv: error parsing field 'id' in record 1 on line 2: unknown default_func "sequence"`
+	if err == nil || err.Error() != expectedErr {
+		t.Fatalf("Expected %v, got %v", expectedErr, err)
+	}
+	var tagError typedcsv.TagError
+	if err := typedcsv.NewCodec[DefaultFuncTestRecord]().Err(); !errors.As(err, &tagError) {
+		t.Fatalf("Expected a TagError, got %v", err)
+	}
+}
+
+func TestDefaultFuncEqual(t *testing.T) {
+	record := DefaultFuncTestRecord{Name: "Alice"}
+	if !typedcsv.Equal(record, record, sequenceOption()) {
+		t.Fatal("Expected equal records, got different ones")
+	}
+	diffs, err := typedcsv.FieldDiffs(record, record, sequenceOption())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(diffs) != 0 {
+		t.Fatalf("Expected no differences, got %v", diffs)
+	}
+}
@@ -0,0 +1,59 @@
+package typedcsv_test
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/hoshiumiarata/typedcsv"
+)
+
+type DefaultTestRecord struct {
+	Name    string        `csv:"name"`
+	Country string        `csv:"country" default:"FR"`
+	Score   *float64      `csv:"score" null:"" default:"1.5"`
+	Since   time.Time     `csv:"since" time_format:"2006-01-02" default:"2000-01-01"`
+	Status  PersonStatus  `csv:"status" default:"active"`
+	Limit   int           `csv:"limit,required" default:"10"`
+	Timeout time.Duration `csv:"timeout" duration_format:"seconds" default:"30"`
+}
+
+func TestDefault(t *testing.T) {
+	input := "name,country,score\nJohn,,\nJane,US,2\nJim\n"
+	csvReader := typedcsv.NewReader[DefaultTestRecord](csv.NewReader(bytes.NewBufferString(input)))
+	csvReader.Reader.FieldsPerRecord = -1
+	if err := csvReader.ReadHeader(); err != nil {
+		t.Fatal(err)
+	}
+	records, err := csvReader.ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defaultScore, score := 1.5, 2.0
+	since := time.Date(2000, time.January, 1, 0, 0, 0, 0, time.UTC)
+	expected := []DefaultTestRecord{
+		{Name: "John", Country: "FR", Score: &defaultScore, Since: since, Status: PersonStatusActive, Limit: 10, Timeout: 30 * time.Second},
+		{Name: "Jane", Country: "US", Score: &score, Since: since, Status: PersonStatusActive, Limit: 10, Timeout: 30 * time.Second},
+		{Name: "Jim", Country: "FR", Score: &defaultScore, Since: since, Status: PersonStatusActive, Limit: 10, Timeout: 30 * time.Second},
+	}
+	for i := range expected {
+		if !reflect.DeepEqual(*records[i], expected[i]) {
+			t.Fatalf("Expected %v, got %v", expected[i], *records[i])
+		}
+	}
+}
+
+type InvalidDefaultTestRecord struct {
+	Count int `csv:"count" default:"many"`
+}
+
+func TestInvalidDefault(t *testing.T) {
+	err := typedcsv.NewCodec[InvalidDefaultTestRecord]().Err()
+	var tagErr typedcsv.TagError
+	if !errors.As(err, &tagErr) || tagErr.Field != "Count" || tagErr.Tag != "default" {
+		t.Fatalf("Expected a default TagError on Count, got %v", err)
+	}
+}
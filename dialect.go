@@ -0,0 +1,111 @@
+package typedcsv
+
+import (
+	"encoding/csv"
+	"io"
+)
+
+// A dialect configures the csv.Reader and csv.Writer created by NewReaderFrom, NewWriterTo, OpenReader and CreateWriter.
+type dialect struct {
+	comma            rune
+	comment          rune
+	lazyQuotes       bool
+	trimLeadingSpace bool
+	fieldsPerRecord  *int
+	useCRLF          bool
+}
+
+// WithComma sets the field delimiter, such as ';' or '\t', of the CSV files read by NewReaderFrom and OpenReader
+// and written by NewWriterTo and CreateWriter. It is ',' by default.
+func WithComma(comma rune) Option {
+	return func(o *options) {
+		o.dialect.comma = comma
+	}
+}
+
+// WithComment sets the character starting the comment lines skipped by NewReaderFrom and OpenReader.
+// By default, there are no comment lines.
+func WithComment(comment rune) Option {
+	return func(o *options) {
+		o.dialect.comment = comment
+	}
+}
+
+// WithLazyQuotes makes NewReaderFrom and OpenReader accept quotes in unquoted fields and non-doubled quotes in quoted fields,
+// like the LazyQuotes field of csv.Reader.
+func WithLazyQuotes() Option {
+	return func(o *options) {
+		o.dialect.lazyQuotes = true
+	}
+}
+
+// WithTrimLeadingSpace makes NewReaderFrom and OpenReader ignore the leading white space of the fields,
+// like the TrimLeadingSpace field of csv.Reader.
+func WithTrimLeadingSpace() Option {
+	return func(o *options) {
+		o.dialect.trimLeadingSpace = true
+	}
+}
+
+// WithFieldsPerRecord sets the FieldsPerRecord field of the csv.Reader created by NewReaderFrom and OpenReader:
+// a positive number of fields that every record must have, or a negative number to allow records of any length.
+// By default, all the records must have as many fields as the header.
+func WithFieldsPerRecord(n int) Option {
+	return func(o *options) {
+		o.dialect.fieldsPerRecord = &n
+	}
+}
+
+// WithCRLF makes NewWriterTo and CreateWriter end the lines with \r\n instead of \n.
+func WithCRLF() Option {
+	return func(o *options) {
+		o.dialect.useCRLF = true
+	}
+}
+
+// newReader returns a new csv.Reader reading from r with the dialect.
+func (d *dialect) newReader(r io.Reader) *csv.Reader {
+	reader := csv.NewReader(skipBOM(r))
+	if d.comma != 0 {
+		reader.Comma = d.comma
+	}
+	reader.Comment = d.comment
+	reader.LazyQuotes = d.lazyQuotes
+	reader.TrimLeadingSpace = d.trimLeadingSpace
+	if d.fieldsPerRecord != nil {
+		reader.FieldsPerRecord = *d.fieldsPerRecord
+	}
+	return reader
+}
+
+// newWriter returns a new csv.Writer writing to w with the dialect.
+func (d *dialect) newWriter(w io.Writer) *csv.Writer {
+	writer := csv.NewWriter(w)
+	if d.comma != 0 {
+		writer.Comma = d.comma
+	}
+	writer.UseCRLF = d.useCRLF
+	return writer
+}
+
+// NewReaderFrom returns a new TypedCSVReader reading from r with a csv.Reader configured by the options,
+// such as WithComma, WithComment and WithLazyQuotes.
+func NewReaderFrom[T any](r io.Reader, opts ...Option) *TypedCSVReader[T] {
+	return NewCodec[T](opts...).NewReaderFrom(r)
+}
+
+// NewWriterTo returns a new TypedCSVWriter writing to w with a csv.Writer configured by the options,
+// such as WithComma and WithCRLF. The writer must be flushed, or closed with Close.
+func NewWriterTo[T any](w io.Writer, opts ...Option) *TypedCSVWriter[T] {
+	return NewCodec[T](opts...).NewWriterTo(w)
+}
+
+// NewReaderFrom returns a new TypedCSVReader reading from r with a csv.Reader configured by the options of the Codec.
+func (c *Codec[T]) NewReaderFrom(r io.Reader) *TypedCSVReader[T] {
+	return c.NewReader(c.options.dialect.newReader(r))
+}
+
+// NewWriterTo returns a new TypedCSVWriter writing to w with a csv.Writer configured by the options of the Codec.
+func (c *Codec[T]) NewWriterTo(w io.Writer) *TypedCSVWriter[T] {
+	return c.NewWriter(c.options.dialect.newWriter(w))
+}
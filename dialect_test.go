@@ -0,0 +1,60 @@
+package typedcsv_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/hoshiumiarata/typedcsv"
+)
+
+func TestNewReaderFrom(t *testing.T) {
+	input := "# exported\nid; name\n1; Alice\n2; B\"ob\n"
+	reader := typedcsv.NewReaderFrom[AppendTestRecord](strings.NewReader(input),
+		typedcsv.WithComma(';'), typedcsv.WithComment('#'), typedcsv.WithTrimLeadingSpace(), typedcsv.WithLazyQuotes())
+	if reader.Reader == nil || reader.Reader.Comma != ';' {
+		t.Fatalf("Expected a csv.Reader with ';', got %+v", reader.Reader)
+	}
+	if err := reader.ReadHeader(); err != nil {
+		t.Fatal(err)
+	}
+	records, err := reader.ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []AppendTestRecord{{ID: 1, Name: "Alice"}, {ID: 2, Name: "B\"ob"}}
+	if len(records) != len(expected) {
+		t.Fatalf("Expected %v records, got %v", len(expected), len(records))
+	}
+	for i, record := range records {
+		if *record != expected[i] {
+			t.Fatalf("Expected %v, got %v", expected[i], *record)
+		}
+	}
+
+	reader = typedcsv.NewReaderFrom[AppendTestRecord](strings.NewReader("id,name\n1\n"), typedcsv.WithFieldsPerRecord(-1))
+	if err := reader.ReadHeader(); err != nil {
+		t.Fatal(err)
+	}
+	record, err := reader.ReadRecord()
+	if err != nil || record.ID != 1 {
+		t.Fatalf("Expected 1, got %v and %v", record, err)
+	}
+	if _, err := reader.ReadRecord(); !errors.Is(err, io.EOF) {
+		t.Fatalf("Expected %v, got %v", io.EOF, err)
+	}
+}
+
+func TestNewWriterTo(t *testing.T) {
+	var buffer bytes.Buffer
+	writer := typedcsv.NewWriterTo[AppendTestRecord](&buffer, typedcsv.WithComma('\t'), typedcsv.WithCRLF())
+	if err := writer.WriteAll([]AppendTestRecord{{ID: 1, Name: "Alice"}}); err != nil {
+		t.Fatal(err)
+	}
+	expected := "id\tname\r\n1\tAlice\r\n"
+	if buffer.String() != expected {
+		t.Fatalf("Expected %q, got %q", expected, buffer.String())
+	}
+}
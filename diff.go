@@ -0,0 +1,50 @@
+package typedcsv
+
+// A FieldDiff is a column whose values differ between two records, as written by TypedCSVWriter.
+type FieldDiff struct {
+	// Field is the Go name of the field. Fields of inlined structs are given as a dotted path.
+	Field string
+	// Column is the column name of the field.
+	Column string
+	// A and B are the CSV values of the field in the two records.
+	A, B string
+}
+
+// Equal reports whether a and b are written as the same CSV row, comparing only the mapped fields
+// with the same formatting as TypedCSVWriter: for example, times that differ below the precision of their
+// "time_format" tag value are equal. The values are not validated, so records whose values do not pass
+// the validation tags, such as "min" or "pattern", are compared too. Records that cannot be formatted are not equal.
+func Equal[T any](a, b T, opts ...Option) bool {
+	return NewCodec[T](opts...).Equal(a, b)
+}
+
+// FieldDiffs returns the columns whose CSV values differ between a and b, in the order of the header.
+// It returns a FieldFormatError if a field of a or b cannot be formatted.
+func FieldDiffs[T any](a, b T, opts ...Option) ([]FieldDiff, error) {
+	return NewCodec[T](opts...).FieldDiffs(a, b)
+}
+
+// Equal reports whether a and b are written as the same CSV row. See the Equal function.
+func (c *Codec[T]) Equal(a, b T) bool {
+	diffs, err := c.FieldDiffs(a, b)
+	return err == nil && len(diffs) == 0
+}
+
+// FieldDiffs returns the columns whose CSV values differ between a and b. See the FieldDiffs function.
+func (c *Codec[T]) FieldDiffs(a, b T) ([]FieldDiff, error) {
+	valuesA, err := c.encodeRecord(a, false, false)
+	if err != nil {
+		return nil, err
+	}
+	valuesB, err := c.encodeRecord(b, false, false)
+	if err != nil {
+		return nil, err
+	}
+	var diffs []FieldDiff
+	for i := range c.fields {
+		if valuesA[i] != valuesB[i] {
+			diffs = append(diffs, FieldDiff{Field: c.fields[i].path, Column: c.fields[i].name, A: valuesA[i], B: valuesB[i]})
+		}
+	}
+	return diffs, nil
+}
@@ -0,0 +1,70 @@
+package typedcsv_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/hoshiumiarata/typedcsv"
+)
+
+func TestFieldDiffs(t *testing.T) {
+	a := Person{Name: "Alice", Birthday: time.Date(2000, 1, 2, 3, 4, 5, 0, time.UTC), Age: 24, Percentage: 0.501}
+	b := a
+	b.Birthday = b.Birthday.Add(time.Hour)
+	b.Percentage = 0.499
+	b.PetNames = []string{}
+	if !typedcsv.Equal(a, b) {
+		diffs, _ := typedcsv.FieldDiffs(a, b)
+		t.Fatalf("Expected equal records, got %v", diffs)
+	}
+	if reflect.DeepEqual(a, b) {
+		t.Fatal("Expected the records to differ with reflect.DeepEqual")
+	}
+
+	b.Age = 25
+	b.Status = PersonStatusActive
+	diffs, err := typedcsv.FieldDiffs(a, b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []typedcsv.FieldDiff{
+		{Field: "Age", Column: "age", A: "24", B: "25"},
+		{Field: "Status", Column: "status", A: "unknown", B: "active"},
+	}
+	if !reflect.DeepEqual(diffs, expected) {
+		t.Fatalf("Expected %v, got %v", expected, diffs)
+	}
+	if typedcsv.Equal(a, b) {
+		t.Fatal("Expected different records")
+	}
+}
+
+func TestFieldDiffsError(t *testing.T) {
+	_, err := typedcsv.FieldDiffs(MarshalTextTestRecord{PersonStatus: 100}, MarshalTextTestRecord{})
+	var fieldFormatError typedcsv.FieldFormatError
+	if !errors.As(err, &fieldFormatError) {
+		t.Fatalf("Expected a FieldFormatError, got %v", err)
+	}
+	if typedcsv.Equal(MarshalTextTestRecord{PersonStatus: 100}, MarshalTextTestRecord{PersonStatus: 100}) {
+		t.Fatal("Expected records that cannot be formatted to differ")
+	}
+}
+
+func TestFieldDiffsInvalidValues(t *testing.T) {
+	a := ValidationTestRecord{Code: "abcd", Status: "unknown", Age: -1, Name: "Jonathan"}
+	if !typedcsv.Equal(a, a) {
+		t.Fatal("Expected equal records")
+	}
+	b := a
+	b.Age = 200
+	diffs, err := typedcsv.FieldDiffs(a, b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []typedcsv.FieldDiff{{Field: "Age", Column: "age", A: "-1", B: "200"}}
+	if !reflect.DeepEqual(diffs, expected) {
+		t.Fatalf("Expected %v, got %v", expected, diffs)
+	}
+}
@@ -0,0 +1,39 @@
+package typedcsv
+
+import "fmt"
+
+// Digits is a string of ASCII digits, such as a ZIP code or an account number.
+// Unlike an integer field, it keeps the leading zeros, so such identifiers round-trip exactly,
+// and unlike a string field, its values are checked to be numeric when read and written.
+// An empty value is invalid; use a *Digits field with a "null" tag for optional values.
+type Digits string
+
+// MarshalText implements encoding.TextMarshaler. It returns an error if d is not a string of digits.
+func (d Digits) MarshalText() ([]byte, error) {
+	if err := d.validate(); err != nil {
+		return nil, err
+	}
+	return []byte(d), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler. It returns an error if text is not a string of digits.
+func (d *Digits) UnmarshalText(text []byte) error {
+	value := Digits(text)
+	if err := value.validate(); err != nil {
+		return err
+	}
+	*d = value
+	return nil
+}
+
+func (d Digits) validate() error {
+	if d == "" {
+		return fmt.Errorf("invalid digits %q: empty value", string(d))
+	}
+	for i := 0; i < len(d); i++ {
+		if d[i] < '0' || d[i] > '9' {
+			return fmt.Errorf("invalid digits %q", string(d))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+package typedcsv_test
+
+import (
+	"bytes"
+	"encoding/csv"
+	"testing"
+
+	"github.com/hoshiumiarata/typedcsv"
+)
+
+type DigitsTestRecord struct {
+	ZIP     typedcsv.Digits  `csv:"zip"`
+	Account *typedcsv.Digits `csv:"account" null:""`
+}
+
+func TestDigits(t *testing.T) {
+	reader := bytes.Buffer{}
+	reader.WriteString("zip,account\n01234,\n00501,000042\n")
+	csvReader := typedcsv.NewReader[DigitsTestRecord](csv.NewReader(&reader))
+	err := csvReader.ReadHeader()
+	if err != nil {
+		t.Fatal(err)
+	}
+	records, err := csvReader.ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if records[0].ZIP != "01234" || records[0].Account != nil || *records[1].Account != "000042" {
+		t.Fatalf("Expected the digits, got %+v, %+v", records[0], records[1])
+	}
+
+	writer := bytes.Buffer{}
+	csvWriter := typedcsv.NewWriter[DigitsTestRecord](csv.NewWriter(&writer))
+	for _, record := range records {
+		err = csvWriter.WriteRecord(*record)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	csvWriter.Flush()
+	if writer.String() != "01234,\n00501,000042\n" {
+		t.Fatalf("Expected %q, got %q", "01234,\n00501,000042\n", writer.String())
+	}
+
+	err = csvWriter.WriteRecord(DigitsTestRecord{ZIP: "12a"})
+	expected := `typedcsv: error formatting field 'zip' in record 3: invalid digits "12a"`
+	if err == nil || err.Error() != expected {
+		t.Fatalf("Expected %q, got %v", expected, err)
+	}
+
+	for _, value := range []string{"1 2", "", "-1"} {
+		reader.Reset()
+		reader.WriteString("zip\n\"" + value + "\"\n")
+		csvReader = typedcsv.NewReader[DigitsTestRecord](csv.NewReader(&reader))
+		err = csvReader.ReadHeader()
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = csvReader.ReadRecord()
+		if err == nil {
+			t.Fatalf("Expected error for %q, got nil", value)
+		}
+	}
+}
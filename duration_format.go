@@ -0,0 +1,86 @@
+package typedcsv
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// A durationCodec parses and formats a "duration_format" tag value.
+type durationCodec struct {
+	parse  func(value string) (time.Duration, error)
+	format func(d time.Duration) string
+}
+
+// durationCodecs are the "duration_format" tag values.
+var durationCodecs = map[string]*durationCodec{
+	// Go duration strings such as 1h2m3s.
+	"string": {
+		parse:  time.ParseDuration,
+		format: time.Duration.String,
+	},
+	// Integer seconds. Fractions of a second are truncated on write.
+	"seconds": unitDurationCodec("seconds", time.Second),
+	// Integer milliseconds. Fractions of a millisecond are truncated on write.
+	"milliseconds": unitDurationCodec("milliseconds", time.Millisecond),
+	// HH:MM:SS, where the hours may exceed 24. Fractions of a second are truncated on write.
+	"hms": {
+		parse:  parseHMSDuration,
+		format: formatHMSDuration,
+	},
+}
+
+// unitDurationCodec returns the codec of durations as an integer number of units.
+func unitDurationCodec(name string, unit time.Duration) *durationCodec {
+	return &durationCodec{
+		parse: func(value string) (time.Duration, error) {
+			n, err := strconv.ParseInt(strings.TrimSpace(value), 10, 64)
+			if err != nil || n > int64(1<<63-1)/int64(unit) || n < -int64(1<<63-1)/int64(unit) {
+				return 0, fmt.Errorf("invalid duration in %s %q", name, value)
+			}
+			return time.Duration(n) * unit, nil
+		},
+		format: func(d time.Duration) string {
+			return strconv.FormatInt(int64(d/unit), 10)
+		},
+	}
+}
+
+func parseHMSDuration(value string) (time.Duration, error) {
+	invalid := fmt.Errorf("invalid duration in HH:MM:SS %q", value)
+	text, negative := strings.CutPrefix(value, "-")
+	parts := strings.Split(text, ":")
+	if len(parts) != 3 || len(parts[1]) != 2 || len(parts[2]) != 2 {
+		return 0, invalid
+	}
+	var numbers [3]int64
+	for i, part := range parts {
+		n, err := strconv.ParseUint(part, 10, 63)
+		if err != nil {
+			return 0, invalid
+		}
+		numbers[i] = int64(n)
+	}
+	if numbers[1] >= 60 || numbers[2] >= 60 || numbers[0] > int64(time.Duration(1<<63-1)/time.Hour) {
+		return 0, invalid
+	}
+	d := time.Duration(numbers[0])*time.Hour + time.Duration(numbers[1])*time.Minute + time.Duration(numbers[2])*time.Second
+	if d < 0 {
+		return 0, invalid
+	}
+	if negative {
+		d = -d
+	}
+	return d, nil
+}
+
+func formatHMSDuration(d time.Duration) string {
+	sign := ""
+	seconds := int64(d / time.Second)
+	if seconds < 0 {
+		sign = "-"
+		seconds = -seconds
+	}
+	return fmt.Sprintf("%s%02d:%02d:%02d", sign, seconds/3600, seconds/60%60, seconds%60)
+}
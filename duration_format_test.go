@@ -0,0 +1,84 @@
+package typedcsv_test
+
+import (
+	"bytes"
+	"encoding/csv"
+	"testing"
+	"time"
+
+	"github.com/hoshiumiarata/typedcsv"
+)
+
+type DurationFormatTestRecord struct {
+	String       time.Duration  `csv:"string" duration_format:"string"`
+	Seconds      time.Duration  `csv:"seconds" duration_format:"seconds"`
+	Milliseconds *time.Duration `csv:"milliseconds" duration_format:"milliseconds" null:""`
+	HMS          time.Duration  `csv:"hms" duration_format:"hms"`
+}
+
+type DurationWithWrongFormatTestRecord struct {
+	Duration time.Duration `csv:"duration" duration_format:"minutes"`
+}
+
+func TestDurationFormat(t *testing.T) {
+	reader := bytes.Buffer{}
+	reader.WriteString("string,seconds,milliseconds,hms\n")
+	reader.WriteString("1h2m3s,90,1500,-26:03:04\n")
+	csvReader := typedcsv.NewReader[DurationFormatTestRecord](csv.NewReader(&reader))
+	err := csvReader.ReadHeader()
+	if err != nil {
+		t.Fatal(err)
+	}
+	record, err := csvReader.ReadRecord()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if record.String != time.Hour+2*time.Minute+3*time.Second {
+		t.Fatalf("Expected %v, got %v", time.Hour+2*time.Minute+3*time.Second, record.String)
+	}
+	if record.Seconds != 90*time.Second {
+		t.Fatalf("Expected %v, got %v", 90*time.Second, record.Seconds)
+	}
+	if record.Milliseconds == nil || *record.Milliseconds != 1500*time.Millisecond {
+		t.Fatalf("Expected %v, got %v", 1500*time.Millisecond, record.Milliseconds)
+	}
+	expected := -(26*time.Hour + 3*time.Minute + 4*time.Second)
+	if record.HMS != expected {
+		t.Fatalf("Expected %v, got %v", expected, record.HMS)
+	}
+
+	writer := bytes.Buffer{}
+	csvWriter := typedcsv.NewWriter[DurationFormatTestRecord](csv.NewWriter(&writer))
+	err = csvWriter.WriteRecord(*record)
+	if err != nil {
+		t.Fatal(err)
+	}
+	csvWriter.Flush()
+	if writer.String() != "1h2m3s,90,1500,-26:03:04\n" {
+		t.Fatalf("Expected %q, got %q", "1h2m3s,90,1500,-26:03:04\n", writer.String())
+	}
+
+	for _, row := range []string{"1x,0,,00:00:00", "0s,1.5,,00:00:00", "0s,0,,00:60:00", "0s,0,,1:2"} {
+		reader.Reset()
+		reader.WriteString("string,seconds,milliseconds,hms\n" + row + "\n")
+		csvReader = typedcsv.NewReader[DurationFormatTestRecord](csv.NewReader(&reader))
+		err = csvReader.ReadHeader()
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = csvReader.ReadRecord()
+		if err == nil {
+			t.Fatalf("Expected error for %q, got nil", row)
+		}
+	}
+}
+
+func TestDurationFormatUnknown(t *testing.T) {
+	writer := bytes.Buffer{}
+	csvWriter := typedcsv.NewWriter[DurationWithWrongFormatTestRecord](csv.NewWriter(&writer))
+	err := csvWriter.WriteRecord(DurationWithWrongFormatTestRecord{})
+	expected := `typedcsv: error formatting field 'duration' in record 1: unknown duration_format "minutes"`
+	if err == nil || err.Error() != expected {
+		t.Fatalf("Expected %q, got %v", expected, err)
+	}
+}
@@ -0,0 +1,138 @@
+package typedcsv
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+)
+
+// A DynamicReader reads records described by a Schema as maps from column names to values.
+//
+// The values have the Go type of their column type: string, int64, uint64, float64, bool, time.Time or time.Duration.
+// Null values are nil.
+type DynamicReader struct {
+	Reader *csv.Reader
+	Schema *Schema
+	Header map[string]int
+}
+
+// NewDynamicReader returns a new DynamicReader that wraps the given csv.Reader.
+// The schema must be compiled.
+func NewDynamicReader(reader *csv.Reader, schema *Schema) *DynamicReader {
+	return &DynamicReader{
+		Reader: reader,
+		Schema: schema,
+	}
+}
+
+// ReadHeader reads the CSV header from the underlying reader.
+// It returns a FieldParseError wrapping ErrValidation if a required column is missing.
+// It returns io.EOF if there is no header.
+func (r *DynamicReader) ReadHeader() error {
+	header, err := r.Reader.Read()
+	if err != nil {
+		return err
+	}
+	r.Header = make(map[string]int)
+	for i, column := range header {
+		if _, ok := r.Header[column]; !ok {
+			r.Header[column] = i
+		}
+	}
+	for _, column := range r.Schema.Columns {
+		if _, ok := r.Header[column.Name]; !ok && column.Required {
+			return FieldParseError{Field: column.Name, NestedError: fmt.Errorf("%w: column is missing", ErrValidation)}
+		}
+	}
+	return nil
+}
+
+// ReadRecord reads the CSV record from the underlying reader.
+// Columns missing from the header are not present in the map.
+// It returns ErrHeaderNotRead if ReadHeader was not called.
+// It returns io.EOF if there are no more records.
+// It returns a FieldParseError if a value cannot be parsed or is invalid.
+// Otherwise, it returns any error returned by the underlying reader.
+func (r *DynamicReader) ReadRecord() (map[string]any, error) {
+	if r.Header == nil {
+		return nil, ErrHeaderNotRead
+	}
+	values, err := r.Reader.Read()
+	if err != nil {
+		return nil, err
+	}
+	record := make(map[string]any, len(r.Schema.Columns))
+	for i := range r.Schema.Columns {
+		column := &r.Schema.Columns[i]
+		index, ok := r.Header[column.Name]
+		if !ok || index >= len(values) {
+			continue
+		}
+		value, err := column.parse(values[index])
+		if err != nil {
+			return record, FieldParseError{Field: column.Name, NestedError: err}
+		}
+		record[column.Name] = value
+	}
+	return record, nil
+}
+
+// ReadAll reads all the remaining records from the underlying reader.
+func (r *DynamicReader) ReadAll() (records []map[string]any, err error) {
+	for {
+		record, err := r.ReadRecord()
+		if err == io.EOF {
+			return records, nil
+		}
+		if err != nil {
+			return records, err
+		}
+		records = append(records, record)
+	}
+}
+
+// A DynamicWriter writes records described by a Schema from maps from column names to values.
+type DynamicWriter struct {
+	Writer *csv.Writer
+	Schema *Schema
+}
+
+// NewDynamicWriter returns a new DynamicWriter that wraps the given csv.Writer.
+// The schema must be compiled.
+func NewDynamicWriter(writer *csv.Writer, schema *Schema) *DynamicWriter {
+	return &DynamicWriter{
+		Writer: writer,
+		Schema: schema,
+	}
+}
+
+// WriteHeader writes the column names of the schema to the underlying writer.
+func (w *DynamicWriter) WriteHeader() error {
+	return w.Writer.Write(w.Schema.Header())
+}
+
+// WriteRecord writes the record to the underlying writer. Missing values are written as null values.
+// It returns a FieldFormatError if a value cannot be formatted or is invalid.
+// Otherwise, it returns any error returned by the underlying writer.
+func (w *DynamicWriter) WriteRecord(record map[string]any) error {
+	values := make([]string, len(w.Schema.Columns))
+	for i := range w.Schema.Columns {
+		column := &w.Schema.Columns[i]
+		value, err := column.format(record[column.Name])
+		if err != nil {
+			return FieldFormatError{Field: column.Name, NestedError: err}
+		}
+		values[i] = value
+	}
+	return w.Writer.Write(values)
+}
+
+// Flush writes any buffered data to the underlying csv.Writer.
+func (w *DynamicWriter) Flush() {
+	w.Writer.Flush()
+}
+
+// Error reports any error that has occurred during a previous WriteHeader, WriteRecord or Flush.
+func (w *DynamicWriter) Error() error {
+	return w.Writer.Error()
+}
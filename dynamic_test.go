@@ -0,0 +1,119 @@
+package typedcsv_test
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hoshiumiarata/typedcsv"
+)
+
+const testSchema = `{
+	"columns": [
+		{"name": "name", "required": true, "pattern": "^[A-Z]"},
+		{"name": "birthday", "type": "time", "format": "2006-01-02"},
+		{"name": "age", "type": "uint", "max": 150},
+		{"name": "status", "enum": ["active", "inactive"]},
+		{"name": "score", "type": "float", "format": "%.1f", "null": "NULL"}
+	]
+}`
+
+func TestDynamicReader(t *testing.T) {
+	schema, err := typedcsv.LoadSchema(strings.NewReader(testSchema))
+	if err != nil {
+		t.Fatal(err)
+	}
+	reader := bytes.Buffer{}
+	reader.WriteString("age,name,birthday,status,score\n")
+	reader.WriteString("55,John,1970-06-17,active,NULL\n")
+	reader.WriteString("200,Mary,1971-07-18,inactive,1.5\n")
+	dynamicReader := typedcsv.NewDynamicReader(csv.NewReader(&reader), schema)
+	err = dynamicReader.ReadHeader()
+	if err != nil {
+		t.Fatal(err)
+	}
+	record, err := dynamicReader.ReadRecord()
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := map[string]any{
+		"name":     "John",
+		"birthday": time.Date(1970, 6, 17, 0, 0, 0, 0, time.UTC),
+		"age":      uint64(55),
+		"status":   "active",
+		"score":    nil,
+	}
+	if !reflect.DeepEqual(record, expected) {
+		t.Fatalf("Expected %v, got %v", expected, record)
+	}
+	_, err = dynamicReader.ReadRecord()
+	var fieldParseError typedcsv.FieldParseError
+	if !errors.As(err, &fieldParseError) || !errors.Is(err, typedcsv.ErrValidation) {
+		t.Fatalf("Expected %T wrapping %v, got %v", fieldParseError, typedcsv.ErrValidation, err)
+	}
+	if fieldParseError.Field != "age" {
+		t.Fatalf("Expected %v, got %v", "age", fieldParseError.Field)
+	}
+}
+
+func TestDynamicReaderMissingRequiredColumn(t *testing.T) {
+	schema, err := typedcsv.LoadSchema(strings.NewReader(testSchema))
+	if err != nil {
+		t.Fatal(err)
+	}
+	dynamicReader := typedcsv.NewDynamicReader(csv.NewReader(strings.NewReader("age\n1\n")), schema)
+	err = dynamicReader.ReadHeader()
+	if !errors.Is(err, typedcsv.ErrValidation) {
+		t.Fatalf("Expected %v, got %v", typedcsv.ErrValidation, err)
+	}
+}
+
+func TestDynamicWriter(t *testing.T) {
+	schema, err := typedcsv.LoadSchema(strings.NewReader(testSchema))
+	if err != nil {
+		t.Fatal(err)
+	}
+	writer := bytes.Buffer{}
+	dynamicWriter := typedcsv.NewDynamicWriter(csv.NewWriter(&writer), schema)
+	err = dynamicWriter.WriteHeader()
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = dynamicWriter.WriteRecord(map[string]any{
+		"name":     "John",
+		"birthday": time.Date(1970, 6, 17, 0, 0, 0, 0, time.UTC),
+		"age":      55,
+		"status":   "active",
+		"score":    1.25,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = dynamicWriter.WriteRecord(map[string]any{"name": "mary"})
+	if !errors.Is(err, typedcsv.ErrValidation) {
+		t.Fatalf("Expected %v, got %v", typedcsv.ErrValidation, err)
+	}
+	dynamicWriter.Flush()
+	expected := "name,birthday,age,status,score\nJohn,1970-06-17,55,active,1.2\n"
+	if writer.String() != expected {
+		t.Fatalf("Expected %q, got %q", expected, writer.String())
+	}
+}
+
+func TestLoadSchemaInvalid(t *testing.T) {
+	for _, schema := range []string{
+		`{"columns": [{"name": ""}]}`,
+		`{"columns": [{"name": "a"}, {"name": "a"}]}`,
+		`{"columns": [{"name": "a", "type": "decimal"}]}`,
+		`{"columns": [{"name": "a", "pattern": "("}]}`,
+	} {
+		_, err := typedcsv.LoadSchema(strings.NewReader(schema))
+		if err == nil {
+			t.Fatalf("Expected error for %s, got nil", schema)
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package typedcsv_test
+
+import (
+	"bytes"
+	"encoding/csv"
+	"reflect"
+	"testing"
+
+	"github.com/hoshiumiarata/typedcsv"
+)
+
+type EmbeddedContact struct {
+	Email string `csv:"email"`
+}
+
+type embeddedAudit struct {
+	Version int `csv:"version"`
+}
+
+type Employee struct {
+	Person
+	*EmbeddedContact
+	embeddedAudit
+	Salary float64 `csv:"salary"`
+}
+
+func TestEmbeddedStruct(t *testing.T) {
+	codec := typedcsv.NewCodec[Employee]()
+	expectedHeader := []string{"name", "birthday", "age", "pet names", "active", "status", "percentage", "optional", "email", "version", "salary"}
+	if !reflect.DeepEqual(codec.Header(), expectedHeader) {
+		t.Fatalf("Expected %v, got %v", expectedHeader, codec.Header())
+	}
+
+	buf := bytes.Buffer{}
+	csvWriter := codec.NewWriter(csv.NewWriter(&buf))
+	employee := Employee{Salary: 1000}
+	employee.Name = "Alice"
+	employee.Version = 2
+	err := csvWriter.WriteAll([]Employee{employee})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.HasSuffix(buf.Bytes(), []byte(",NULL,,2,1000\n")) || !bytes.Contains(buf.Bytes(), []byte("\nAlice,")) {
+		t.Fatalf("Expected the embedded fields to be written, got %q", buf.String())
+	}
+
+	csvReader := codec.NewReader(csv.NewReader(bytes.NewBufferString("name,email,version,salary\nBob,bob@example.com,3,1500\n")))
+	err = csvReader.ReadHeader()
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := csvReader.ReadRecord()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Name != "Bob" || got.EmbeddedContact == nil || got.Email != "bob@example.com" || got.Version != 3 || got.Salary != 1500 {
+		t.Fatalf("Expected Bob, got %+v", got)
+	}
+	mapping, err := csvReader.Mapping()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mapping.Fields[0].Field != "Person.Name" {
+		t.Fatalf("Expected Person.Name, got %s", mapping.Fields[0].Field)
+	}
+}
+
+type TaggedEmbedded struct {
+	EmbeddedContact `csv:"contact" encoding:"csv"`
+	Salary          float64 `csv:"salary"`
+}
+
+func TestEmbeddedStructTagged(t *testing.T) {
+	expected := []string{"contact", "salary"}
+	if header := typedcsv.NewCodec[TaggedEmbedded]().Header(); !reflect.DeepEqual(header, expected) {
+		t.Fatalf("Expected %v, got %v", expected, header)
+	}
+}
@@ -1,23 +1,92 @@
 package typedcsv
 
 import (
+	"encoding/csv"
 	"errors"
 	"fmt"
+	"reflect"
 )
 
 // ErrHeaderNotRead is returned when ReadRecord is called before ReadHeader.
 var ErrHeaderNotRead = errors.New("typedcsv: header not read")
 
+// ErrNoCSVFields is wrapped by the TypeError returned when the record type is a struct without exported fields
+// or without any field mapped to a column.
+var ErrNoCSVFields = errors.New("typedcsv: no CSV fields")
+
+// ErrNotStruct is wrapped by the TypeError returned when the record type is not a struct.
+var ErrNotStruct = errors.New("typedcsv: not a struct")
+
+// A TypeError reports a record type that cannot be read or written, because it is not a struct, wrapping ErrNotStruct,
+// or it has no exported fields or none of its fields is mapped to a column, wrapping ErrNoCSVFields.
+// It is returned by Codec.Err and by the first read or write.
+type TypeError struct {
+	// Type is the record type.
+	Type reflect.Type
+	// Message describes the problem.
+	Message string
+	// Err is ErrNotStruct or ErrNoCSVFields.
+	Err error
+}
+
+// Error returns the error message.
+func (e TypeError) Error() string {
+	return fmt.Sprintf("typedcsv: type %v cannot be used as a record: %s", e.Type, e.Message)
+}
+
+// Unwrap returns ErrNotStruct or ErrNoCSVFields.
+func (e TypeError) Unwrap() error {
+	return e.Err
+}
+
+// A RowReadError is returned by TypedCSVReader when the underlying reader cannot read a record,
+// such as a record with a bare quote or a wrong number of fields. It wraps the *csv.ParseError,
+// so that errors.As and errors.Is still find it and its csv.ErrQuote, csv.ErrBareQuote or csv.ErrFieldCount.
+type RowReadError struct {
+	// Record is the number of the record in the file, starting at 1 after the header.
+	Record int
+	// StartLine is the line where the record starts, and Line and Column locate the error, as in csv.ParseError.
+	StartLine int
+	Line      int
+	Column    int
+	// Header maps the column names of the header to their indexes.
+	Header map[string]int
+	// Err is the error of the underlying reader.
+	Err *csv.ParseError
+}
+
+// Error returns the error message.
+func (e RowReadError) Error() string {
+	return fmt.Sprintf("typedcsv: error reading record %d: %v", e.Record, e.Err)
+}
+
+// Unwrap returns the *csv.ParseError.
+func (e RowReadError) Unwrap() error {
+	return e.Err
+}
+
 // FieldParseError is returned when a field cannot be parsed.
 type FieldParseError struct {
 	// Field is the name of the field that could not be parsed.
 	Field string
 	// NestedError is the error returned by the underlying parser.
 	NestedError error
+	// Record is the number of the record in the file, starting at 1 after the header, or 0 if unknown.
+	// It is set by TypedCSVReader.
+	Record int
+	// Line is the line of the start of the record in the file, starting at 1, or 0 if unknown.
+	// It is set by TypedCSVReader.
+	Line int
 }
 
 // Error returns the error message.
 func (e FieldParseError) Error() string {
+	switch {
+	case e.Line > 0:
+		return fmt.Sprintf("typedcsv: error parsing field '%s' in record %d on line %d: %v", e.Field, e.Record, e.Line, e.NestedError)
+	case e.Record > 0:
+		return fmt.Sprintf("typedcsv: error parsing field '%s' in record %d: %v", e.Field, e.Record, e.NestedError)
+	}
 	return fmt.Sprintf("typedcsv: error parsing field '%s': %v", e.Field, e.NestedError)
 }
 
@@ -30,10 +99,15 @@ func (e FieldParseError) Unwrap() error {
 type FieldFormatError struct {
 	Field       string
 	NestedError error
+	// Record is the number of the record written by the TypedCSVWriter, starting at 1, or 0 if unknown.
+	Record int
 }
 
 // Error returns the error message.
 func (e FieldFormatError) Error() string {
+	if e.Record > 0 {
+		return fmt.Sprintf("typedcsv: error formatting field '%s' in record %d: %v", e.Field, e.Record, e.NestedError)
+	}
 	return fmt.Sprintf("typedcsv: error formatting field '%s': %v", e.Field, e.NestedError)
 }
 
@@ -41,3 +115,18 @@ func (e FieldFormatError) Error() string {
 func (e FieldFormatError) Unwrap() error {
 	return e.NestedError
 }
+
+// A TagError reports a misconfigured struct tag, found when the Codec is built. See Codec.Err.
+type TagError struct {
+	// Field is the Go name path of the struct field.
+	Field string
+	// Tag is the key of the misconfigured tag.
+	Tag string
+	// Message describes the problem.
+	Message string
+}
+
+// Error returns the error message.
+func (e TagError) Error() string {
+	return fmt.Sprintf("typedcsv: invalid tag %s on field %s: %s", e.Tag, e.Field, e.Message)
+}
@@ -1,6 +1,8 @@
 package typedcsv_test
 
 import (
+	"bytes"
+	"encoding/csv"
 	"errors"
 	"testing"
 
@@ -21,3 +23,40 @@ func TestFieldParseError(t *testing.T) {
 		t.Fatalf("Expected %v, got %v", customErr, errors.Unwrap(err))
 	}
 }
+
+func TestFieldErrorLocation(t *testing.T) {
+	err := typedcsv.FieldParseError{Field: "age", NestedError: errors.New("bad"), Record: 3, Line: 5}
+	expected := "typedcsv: error parsing field 'age' in record 3 on line 5: bad"
+	if err.Error() != expected {
+		t.Fatalf("Expected %v, got %v", expected, err.Error())
+	}
+	formatErr := typedcsv.FieldFormatError{Field: "age", NestedError: errors.New("bad"), Record: 2}
+	expected = "typedcsv: error formatting field 'age' in record 2: bad"
+	if formatErr.Error() != expected {
+		t.Fatalf("Expected %v, got %v", expected, formatErr.Error())
+	}
+}
+
+func TestRowReadError(t *testing.T) {
+	input := "name,age\nAlice,30\nBob,31,extra\nCarol,\"32\n"
+	csvReader := typedcsv.NewReader[Person](csv.NewReader(bytes.NewBufferString(input)))
+	if err := csvReader.ReadHeader(); err != nil {
+		t.Fatal(err)
+	}
+	records, err := csvReader.ReadAllLenient()
+	if len(records) != 1 || records[0].Name != "Alice" {
+		t.Fatalf("Expected Alice, got %v", records)
+	}
+	var rowReadError typedcsv.RowReadError
+	if !errors.As(err, &rowReadError) || rowReadError.Record != 2 || rowReadError.Line != 3 || rowReadError.Header["age"] != 1 {
+		t.Fatalf("Expected a RowReadError in record 2 on line 3, got %v", err)
+	}
+	expected := "typedcsv: error reading record 2: record on line 3: wrong number of fields"
+	if rowReadError.Error() != expected {
+		t.Fatalf("Expected %v, got %v", expected, rowReadError.Error())
+	}
+	var parseError *csv.ParseError
+	if !errors.As(err, &parseError) || !errors.Is(err, csv.ErrFieldCount) || !errors.Is(err, csv.ErrQuote) {
+		t.Fatalf("Expected csv.ErrFieldCount and csv.ErrQuote, got %v", err)
+	}
+}
@@ -0,0 +1,68 @@
+package typedcsv
+
+import (
+	"bytes"
+	"compress/gzip"
+	"math"
+)
+
+// A SizeEstimate is the estimated size of a CSV export, returned by EstimateSize.
+type SizeEstimate struct {
+	// Rows is the number of records the estimate is extrapolated to.
+	Rows int
+	// HeaderBytes is the size of the header line, including its line ending and the byte order mark of WithBOM.
+	HeaderBytes int
+	// RowBytes is the mean size of a record line in the sample, including its line ending.
+	RowBytes float64
+	// Bytes is the estimated size of the uncompressed export.
+	Bytes int64
+	// GzipBytes is the estimated size of the export compressed with gzip, extrapolated from the compression ratio of the sample.
+	GzipBytes int64
+}
+
+// EstimateSize writes the header and the sample records to memory with the options,
+// and extrapolates the size of an export of totalRows records with the same mean record size,
+// so that jobs can provision storage or choose splitting thresholds before writing.
+//
+// The estimate is only as good as the sample: a few hundred records taken across the data are usually enough.
+// Small samples compress worse than whole files, so GzipBytes tends to overestimate.
+// It returns the first error that occurred while writing the sample.
+func EstimateSize[T any](sampleRecords []T, totalRows int, opts ...Option) (SizeEstimate, error) {
+	estimate := SizeEstimate{Rows: totalRows}
+	var buffer bytes.Buffer
+	writer := NewWriterTo[T](&buffer, opts...)
+	if err := writer.WriteHeader(); err != nil {
+		return estimate, err
+	}
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return estimate, err
+	}
+	estimate.HeaderBytes = buffer.Len()
+	for _, record := range sampleRecords {
+		if err := writer.WriteRecord(record); err != nil {
+			return estimate, err
+		}
+	}
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return estimate, err
+	}
+	if len(sampleRecords) > 0 {
+		estimate.RowBytes = float64(buffer.Len()-estimate.HeaderBytes) / float64(len(sampleRecords))
+	}
+	estimate.Bytes = int64(estimate.HeaderBytes) + int64(math.Ceil(estimate.RowBytes*float64(totalRows)))
+	if buffer.Len() == 0 {
+		return estimate, nil
+	}
+
+	var compressed bytes.Buffer
+	gzipWriter := gzip.NewWriter(&compressed)
+	gzipWriter.Write(buffer.Bytes())
+	if err := gzipWriter.Close(); err != nil {
+		return estimate, err
+	}
+	ratio := float64(compressed.Len()) / float64(buffer.Len())
+	estimate.GzipBytes = int64(math.Ceil(float64(estimate.Bytes) * ratio))
+	return estimate, nil
+}
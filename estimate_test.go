@@ -0,0 +1,43 @@
+package typedcsv_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"testing"
+
+	"github.com/hoshiumiarata/typedcsv"
+)
+
+func TestEstimateSize(t *testing.T) {
+	records := make([]AppendTestRecord, benchmarkRows)
+	for i := range records {
+		records[i] = AppendTestRecord{ID: 100 + i%900, Name: "name"}
+	}
+	estimate, err := typedcsv.EstimateSize(records[:100], len(records))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if estimate.HeaderBytes != len("id,name\n") || estimate.RowBytes != float64(len("100,name\n")) {
+		t.Fatalf("Expected header and row sizes %v and %v, got %+v", len("id,name\n"), len("100,name\n"), estimate)
+	}
+
+	var buffer bytes.Buffer
+	if err := typedcsv.NewWriterTo[AppendTestRecord](&buffer).WriteAll(records); err != nil {
+		t.Fatal(err)
+	}
+	if estimate.Bytes != int64(buffer.Len()) {
+		t.Fatalf("Expected %v, got %v", buffer.Len(), estimate.Bytes)
+	}
+	var compressed bytes.Buffer
+	gzipWriter := gzip.NewWriter(&compressed)
+	gzipWriter.Write(buffer.Bytes())
+	gzipWriter.Close()
+	if estimate.GzipBytes < int64(compressed.Len()) || estimate.GzipBytes > estimate.Bytes {
+		t.Fatalf("Expected between %v and %v, got %v", compressed.Len(), estimate.Bytes, estimate.GzipBytes)
+	}
+
+	estimate, err = typedcsv.EstimateSize[AppendTestRecord](nil, 10, typedcsv.WithoutHeader())
+	if err != nil || estimate.Bytes != 0 || estimate.GzipBytes != 0 {
+		t.Fatalf("Expected an empty estimate, got %+v and %v", estimate, err)
+	}
+}
@@ -0,0 +1,104 @@
+package typedcsv
+
+import (
+	"io"
+	"strings"
+)
+
+// bom is the UTF-8 byte order mark written before the header by WithBOM and skipped by ReadHeader.
+const bom = "\ufeff"
+
+// WithBOM makes TypedCSVWriter write a UTF-8 byte order mark before the header,
+// as expected by some spreadsheet applications to detect the encoding.
+// TypedCSVReader always skips a byte order mark at the start of the header.
+func WithBOM() Option {
+	return func(o *options) {
+		o.bom = true
+	}
+}
+
+// WriteAll writes the header, all the records and flushes the writer.
+// The header is written even if there are no records, so that an empty export is still a valid CSV file.
+// It returns the first error that occurred while writing or flushing.
+func (w *TypedCSVWriter[T]) WriteAll(records []T) error {
+	if err := w.WriteHeader(); err != nil {
+		return err
+	}
+	for _, record := range records {
+		if err := w.WriteRecord(record); err != nil {
+			return err
+		}
+	}
+	w.Flush()
+	return w.Error()
+}
+
+// WriteAll writes the header and all the records, and closes the writer to write the manifest.
+// An export with no records produces a file with the header only and a manifest with zero rows.
+// It returns the first error that occurred while writing the CSV data or the manifest.
+func (w *ManifestWriter[T]) WriteAll(records []T) error {
+	if err := w.WriteHeader(); err != nil {
+		return err
+	}
+	for _, record := range records {
+		if err := w.WriteRecord(record); err != nil {
+			return err
+		}
+	}
+	return w.Close()
+}
+
+// withBOM returns the header with a byte order mark before the first column.
+func withBOM(header []string) []string {
+	if len(header) == 0 {
+		return header
+	}
+	header = append([]string(nil), header...)
+	header[0] = bom + header[0]
+	return header
+}
+
+// trimBOM removes a byte order mark at the start of the first column of the header,
+// for the csv.Readers given to NewReader, which do not skip it.
+func trimBOM(header []string) {
+	if len(header) > 0 {
+		header[0] = strings.TrimPrefix(header[0], bom)
+	}
+}
+
+// skipBOM returns a reader reading r without the byte order mark at its start, if any,
+// so that a csv.Reader reads a quoted first column as quoted instead of failing on a bare quote.
+func skipBOM(r io.Reader) io.Reader {
+	return &bomReader{reader: r}
+}
+
+// A bomReader skips the byte order mark at the start of its reader.
+type bomReader struct {
+	reader  io.Reader
+	checked bool
+	pending []byte
+	err     error
+}
+
+func (r *bomReader) Read(p []byte) (int, error) {
+	if !r.checked {
+		r.checked = true
+		prefix := make([]byte, len(bom))
+		n, err := io.ReadFull(r.reader, prefix)
+		if string(prefix[:n]) != bom {
+			r.pending = prefix[:n]
+		}
+		if err != io.EOF && err != io.ErrUnexpectedEOF {
+			r.err = err
+		}
+	}
+	if len(r.pending) > 0 {
+		n := copy(p, r.pending)
+		r.pending = r.pending[n:]
+		return n, nil
+	}
+	if r.err != nil {
+		return 0, r.err
+	}
+	return r.reader.Read(p)
+}
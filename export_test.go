@@ -0,0 +1,102 @@
+package typedcsv_test
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/hoshiumiarata/typedcsv"
+)
+
+func TestWriteAllEmpty(t *testing.T) {
+	buf := bytes.Buffer{}
+	csvWriter := typedcsv.NewWriter[MarshalTextTestRecord](csv.NewWriter(&buf))
+	err := csvWriter.WriteAll(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := "person_status\n"
+	if buf.String() != expected {
+		t.Fatalf("Expected %q, got %q", expected, buf.String())
+	}
+}
+
+func TestReadQuotedHeaderAfterBOM(t *testing.T) {
+	input := "\ufeff\"person_status\"\nactive\n"
+	readers := map[string]*typedcsv.TypedCSVReader[MarshalTextTestRecord]{
+		"csv.Reader": typedcsv.NewReaderFrom[MarshalTextTestRecord](strings.NewReader(input)),
+		"Tokenizer":  typedcsv.NewFastReader[MarshalTextTestRecord](strings.NewReader(input)),
+	}
+	for name, csvReader := range readers {
+		if err := csvReader.ReadHeader(); err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		record, err := csvReader.ReadRecord()
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if record.PersonStatus != PersonStatusActive {
+			t.Fatalf("%s: Expected %v, got %v", name, PersonStatusActive, record.PersonStatus)
+		}
+	}
+	records, err := typedcsv.Unmarshal[MarshalTextTestRecord]([]byte(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 1 || records[0].PersonStatus != PersonStatusActive {
+		t.Fatalf("Expected one active record, got %v", records)
+	}
+}
+
+func TestWriteAllBOM(t *testing.T) {
+	buf := bytes.Buffer{}
+	csvWriter := typedcsv.NewFastWriter[MarshalTextTestRecord](&buf, typedcsv.WithBOM())
+	err := csvWriter.WriteAll([]MarshalTextTestRecord{{PersonStatus: PersonStatusActive}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := "\ufeffperson_status\nactive\n"
+	if buf.String() != expected {
+		t.Fatalf("Expected %q, got %q", expected, buf.String())
+	}
+
+	csvReader := typedcsv.NewReader[MarshalTextTestRecord](csv.NewReader(&buf))
+	err = csvReader.ReadHeader()
+	if err != nil {
+		t.Fatal(err)
+	}
+	records, err := csvReader.ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 1 || records[0].PersonStatus != PersonStatusActive {
+		t.Fatalf("Expected one active record, got %v", records)
+	}
+	if warnings := csvReader.Warnings(); len(warnings) != 0 {
+		t.Fatalf("Expected no warnings, got %v", warnings)
+	}
+}
+
+func TestManifestWriterWriteAllEmpty(t *testing.T) {
+	data := bytes.Buffer{}
+	manifest := bytes.Buffer{}
+	csvWriter := typedcsv.NewManifestWriter[MarshalTextTestRecord](&data, &manifest, typedcsv.WithBOM())
+	err := csvWriter.WriteAll(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectedData := "\ufeffperson_status\n"
+	if data.String() != expectedData {
+		t.Fatalf("Expected %q, got %q", expectedData, data.String())
+	}
+	var got typedcsv.Manifest
+	err = json.Unmarshal(manifest.Bytes(), &got)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Rows != 0 || got.Bytes != int64(len(expectedData)) {
+		t.Fatalf("Expected 0 rows and %d bytes, got %+v", len(expectedData), got)
+	}
+}
@@ -0,0 +1,44 @@
+package typedcsv
+
+import "reflect"
+
+// A FieldInfo describes a struct field mapped to a CSV column, for documentation and introspection.
+type FieldInfo struct {
+	// Field is the Go name of the field. Fields of inlined structs are given as a dotted path.
+	Field string
+	// Column is the column name of the field.
+	Column string
+	// Type is the type of the field.
+	Type reflect.Type
+	// Null is the value of the "null" tag, if HasNull is true.
+	Null    string
+	HasNull bool
+	// Description is the value of the "desc" tag, a human-readable description of the column.
+	Description string
+	// Unit is the value of the "unit" tag, the unit of the values of the column such as "ms" or "EUR".
+	Unit string
+}
+
+// Fields returns the fields of T mapped to CSV columns, in the order of the header written by TypedCSVWriter.
+// The descriptions and units are also used by JSONSchema, CSVWMetadata and TableSchemaOf.
+func Fields[T any](opts ...Option) []FieldInfo {
+	codec := NewCodec[T](opts...)
+	infos := make([]FieldInfo, len(codec.fields))
+	for i := range codec.fields {
+		field := &codec.fields[i]
+		typ := field.typ
+		if field.pointer {
+			typ = reflect.PointerTo(typ)
+		}
+		infos[i] = FieldInfo{
+			Field:       field.path,
+			Column:      field.name,
+			Type:        typ,
+			Null:        field.null,
+			HasNull:     field.hasNull,
+			Description: field.description,
+			Unit:        field.unit,
+		}
+	}
+	return infos
+}
@@ -0,0 +1,76 @@
+package typedcsv_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/hoshiumiarata/typedcsv"
+)
+
+type DescribedRecord struct {
+	ID      int      `csv:"id" desc:"Identifier of the order"`
+	Latency *float64 `csv:"latency" desc:"Time to first byte" unit:"ms" null:"NULL"`
+	Note    string   `csv:"note"`
+}
+
+func TestFields(t *testing.T) {
+	got := typedcsv.Fields[DescribedRecord]()
+	expected := []typedcsv.FieldInfo{
+		{Field: "ID", Column: "id", Type: reflect.TypeOf(0), Description: "Identifier of the order"},
+		{Field: "Latency", Column: "latency", Type: reflect.TypeOf((*float64)(nil)), Null: "NULL", HasNull: true, Description: "Time to first byte", Unit: "ms"},
+		{Field: "Note", Column: "note", Type: reflect.TypeOf("")},
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Fatalf("Expected %+v, got %+v", expected, got)
+	}
+}
+
+func TestFieldsInSchemas(t *testing.T) {
+	data, err := typedcsv.JSONSchema[DescribedRecord]()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var jsonSchema struct {
+		Properties map[string]map[string]any `json:"properties"`
+	}
+	if err := json.Unmarshal(data, &jsonSchema); err != nil {
+		t.Fatal(err)
+	}
+	latency := jsonSchema.Properties["latency"]
+	if latency["description"] != "Time to first byte" || latency["x-unit"] != "ms" {
+		t.Fatalf("Expected a description and a unit, got %v", latency)
+	}
+	if _, ok := jsonSchema.Properties["note"]["description"]; ok {
+		t.Fatalf("Expected no description, got %v", jsonSchema.Properties["note"])
+	}
+
+	data, err = typedcsv.CSVWMetadata[DescribedRecord]("orders.csv")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var metadata struct {
+		TableSchema struct {
+			Columns []map[string]any `json:"columns"`
+		} `json:"tableSchema"`
+	}
+	if err := json.Unmarshal(data, &metadata); err != nil {
+		t.Fatal(err)
+	}
+	column := metadata.TableSchema.Columns[1]
+	if column["dc:description"] != "Time to first byte" || column["schema:unitText"] != "ms" {
+		t.Fatalf("Expected a description and a unit, got %v", column)
+	}
+
+	data, err = typedcsv.TableSchemaOf[DescribedRecord]()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var tableSchema typedcsv.TableSchema
+	if err := json.Unmarshal(data, &tableSchema); err != nil {
+		t.Fatal(err)
+	}
+	if field := tableSchema.Fields[1]; field.Description != "Time to first byte" || field.Unit != "ms" {
+		t.Fatalf("Expected a description and a unit, got %+v", field)
+	}
+}
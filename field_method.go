@@ -0,0 +1,92 @@
+package typedcsv
+
+import (
+	"fmt"
+	"reflect"
+)
+
+const (
+	parseMethodTag  = "parse_method"
+	formatMethodTag = "format_method"
+)
+
+var errorType = reflect.TypeFor[error]()
+
+// A fieldMethod is a method of the struct holding a field, named by the "parse_method" or "format_method" tag value,
+// which converts the values of that field only.
+type fieldMethod struct {
+	name    string
+	holder  reflect.Type
+	index   int
+	pointer bool
+	err     error
+}
+
+// resolveFieldMethods looks up the parse and format methods of the fields of the struct type t.
+func resolveFieldMethods(fields []field, t reflect.Type) {
+	for i := range fields {
+		f := &fields[i]
+		if f.parseMethod != nil {
+			method := f.parseMethod.resolve(t)
+			if f.parseMethod.err == nil && (method.Type.NumIn() != 2 || method.Type.In(1).Kind() != reflect.String ||
+				method.Type.NumOut() != 2 || method.Type.Out(0) != f.typ || method.Type.Out(1) != errorType) {
+				f.parseMethod.err = fmt.Errorf("method %s of type %v must have the signature func(string) (%v, error)", f.parseMethod.name, t, f.typ)
+			}
+			if f.hasDefault {
+				// The default value could not be parsed before the method was resolved.
+				f.defaultErr = f.decode(&decodeState{options: &options{}}, reflect.New(f.structType()).Elem(), f.defaultValue)
+			}
+		}
+		if f.formatMethod != nil {
+			method := f.formatMethod.resolve(t)
+			if f.formatMethod.err == nil && (method.Type.NumIn() != 2 || method.Type.In(1) != f.typ ||
+				method.Type.NumOut() != 2 || method.Type.Out(0).Kind() != reflect.String || method.Type.Out(1) != errorType) {
+				f.formatMethod.err = fmt.Errorf("method %s of type %v must have the signature func(%v) (string, error)", f.formatMethod.name, t, f.typ)
+			}
+		}
+	}
+}
+
+// structType returns the type of the struct field.
+func (f *field) structType() reflect.Type {
+	if f.pointer {
+		return reflect.PointerTo(f.typ)
+	}
+	return f.typ
+}
+
+// resolve looks up the method on the struct type t, or on a pointer to it.
+func (m *fieldMethod) resolve(t reflect.Type) reflect.Method {
+	m.holder = t
+	method, ok := t.MethodByName(m.name)
+	if !ok {
+		method, ok = reflect.PointerTo(t).MethodByName(m.name)
+		m.pointer = true
+	}
+	if !ok {
+		m.err = fmt.Errorf("type %v has no method %s", t, m.name)
+	}
+	m.index = method.Index
+	return method
+}
+
+// call calls the method on the struct value holder, or on a zero struct if holder is not valid.
+func (m *fieldMethod) call(holder reflect.Value, arg reflect.Value) (reflect.Value, error) {
+	if m.err != nil {
+		return reflect.Value{}, m.err
+	}
+	if !holder.IsValid() {
+		holder = reflect.New(m.holder).Elem()
+	}
+	if m.pointer {
+		if !holder.CanAddr() {
+			pointer := reflect.New(holder.Type())
+			pointer.Elem().Set(holder)
+			holder = pointer.Elem()
+		}
+		holder = holder.Addr()
+	}
+	results := holder.Method(m.index).Call([]reflect.Value{arg})
+	err, _ := results[1].Interface().(error)
+	return results[0], err
+}
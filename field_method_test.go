@@ -0,0 +1,86 @@
+package typedcsv_test
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/hoshiumiarata/typedcsv"
+)
+
+type FieldMethodTestRecord struct {
+	Currency string   `csv:"currency"`
+	Amount   int64    `csv:"amount" parse_method:"ParseAmount" format_method:"FormatAmount"`
+	Rate     *float64 `csv:"rate" null:"" parse_method:"ParsePercent" format_method:"FormatPercent" default:"0%"`
+}
+
+// ParseAmount parses amounts in the minor unit of the currency read before.
+func (r *FieldMethodTestRecord) ParseAmount(value string) (int64, error) {
+	units, err := strconv.ParseFloat(value, 64)
+	if r.Currency == "JPY" {
+		return int64(units), err
+	}
+	return int64(units*100 + 0.5), err
+}
+
+func (r FieldMethodTestRecord) FormatAmount(amount int64) (string, error) {
+	if r.Currency == "JPY" {
+		return strconv.FormatInt(amount, 10), nil
+	}
+	return fmt.Sprintf("%d.%02d", amount/100, amount%100), nil
+}
+
+func (FieldMethodTestRecord) ParsePercent(value string) (float64, error) {
+	percent, err := strconv.ParseFloat(strings.TrimSuffix(value, "%"), 64)
+	return percent / 100, err
+}
+
+func (FieldMethodTestRecord) FormatPercent(rate float64) (string, error) {
+	return strconv.FormatFloat(rate*100, 'f', -1, 64) + "%", nil
+}
+
+type InvalidFieldMethodTestRecord struct {
+	Amount int64 `csv:"amount" parse_method:"Missing" format_method:"FormatAmount"`
+}
+
+func (InvalidFieldMethodTestRecord) FormatAmount(amount int) string {
+	return ""
+}
+
+func TestFieldMethod(t *testing.T) {
+	input := "currency,amount,rate\nEUR,12.5,5%\nJPY,1200,\n"
+	reader := typedcsv.NewReaderFrom[FieldMethodTestRecord](strings.NewReader(input))
+	if err := reader.ReadHeader(); err != nil {
+		t.Fatal(err)
+	}
+	records, err := reader.ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if records[0].Amount != 1250 || *records[0].Rate != 0.05 || records[1].Amount != 1200 || *records[1].Rate != 0 {
+		t.Fatalf("Expected 1250 at 5%% and 1200 at 0%%, got %+v and %+v", *records[0], *records[1])
+	}
+
+	var buffer bytes.Buffer
+	records[1].Rate = nil
+	if err := typedcsv.NewWriterTo[FieldMethodTestRecord](&buffer).WriteAll([]FieldMethodTestRecord{*records[0], *records[1]}); err != nil {
+		t.Fatal(err)
+	}
+	expected := "currency,amount,rate\nEUR,12.50,5%\nJPY,1200,\n"
+	if buffer.String() != expected {
+		t.Fatalf("Expected %q, got %q", expected, buffer.String())
+	}
+
+	err = typedcsv.NewCodec[InvalidFieldMethodTestRecord]().Err()
+	var tagError typedcsv.TagError
+	if !errors.As(err, &tagError) || tagError.Tag != "parse_method" {
+		t.Fatalf("Expected a TagError, got %v", err)
+	}
+	expectedErr := "method FormatAmount of type typedcsv_test.InvalidFieldMethodTestRecord must have the signature func(int64) (string, error)"
+	if !strings.Contains(err.Error(), expectedErr) || !strings.Contains(err.Error(), "has no method Missing") {
+		t.Fatalf("Expected %v, got %v", expectedErr, err)
+	}
+}
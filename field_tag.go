@@ -0,0 +1,63 @@
+package typedcsv
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// A fieldTagOverride applies to the struct field with the Go name path field, or to the field mapped to column.
+type fieldTagOverride struct {
+	field  string
+	column string
+	key    string
+	value  string
+}
+
+// WithFieldTag overrides the tag with the given key of a struct field at runtime,
+// as if the struct field had the tag `key:"value"`. It can be used to adapt types that cannot be modified.
+//
+// The field is identified by its Go name. Fields of inlined structs are identified by the dotted path of Go names,
+// for example "Address.Street". Any tag can be overridden, including "csv".
+func WithFieldTag(field, key, value string) Option {
+	return func(o *options) {
+		o.fieldTags = append(o.fieldTags, fieldTagOverride{field: field, key: key, value: value})
+	}
+}
+
+// WithColumnTimeFormat overrides the "time_format" tag of the field mapped to the given column.
+// It allows one struct to be used with sources that use different time formats.
+func WithColumnTimeFormat(column, layout string) Option {
+	return func(o *options) {
+		o.fieldTags = append(o.fieldTags, fieldTagOverride{column: column, key: timeFormatTag, value: layout})
+	}
+}
+
+// WithColumnTimeLocation overrides the "time_location" tag of the field mapped to the given column.
+func WithColumnTimeLocation(column, location string) Option {
+	return func(o *options) {
+		o.fieldTags = append(o.fieldTags, fieldTagOverride{column: column, key: timeLocationTag, value: location})
+	}
+}
+
+// overrideTag returns the tag with the overrides for the struct field at path or mapped to column applied.
+// Empty path or column match nothing.
+func (o *options) overrideTag(path, column string, tag reflect.StructTag) reflect.StructTag {
+	var builder strings.Builder
+	// StructTag.Lookup returns the first match, so the last override of a key must come first.
+	for i := len(o.fieldTags) - 1; i >= 0; i-- {
+		override := o.fieldTags[i]
+		if (override.field == "" || override.field != path) && (override.column == "" || override.column != column) {
+			continue
+		}
+		builder.WriteString(override.key)
+		builder.WriteByte(':')
+		builder.WriteString(strconv.Quote(override.value))
+		builder.WriteByte(' ')
+	}
+	if builder.Len() == 0 {
+		return tag
+	}
+	builder.WriteString(string(tag))
+	return reflect.StructTag(builder.String())
+}
@@ -0,0 +1,81 @@
+package typedcsv_test
+
+import (
+	"bytes"
+	"encoding/csv"
+	"testing"
+	"time"
+
+	"github.com/hoshiumiarata/typedcsv"
+)
+
+type VendoredRecord struct {
+	Name     string
+	Birthday time.Time `csv:"birthday" time_format:"2006-01-02"`
+}
+
+func TestWithFieldTag(t *testing.T) {
+	opts := []typedcsv.Option{
+		typedcsv.WithFieldTag("Name", "csv", "name"),
+		typedcsv.WithFieldTag("Birthday", "time_format", "2006/01/02"),
+		typedcsv.WithFieldTag("Birthday", "time_format", "02/01/2006"),
+	}
+	reader := bytes.Buffer{}
+	reader.WriteString("name,birthday\n")
+	reader.WriteString("John,17/06/1970\n")
+	csvReader := typedcsv.NewReader[VendoredRecord](csv.NewReader(&reader), opts...)
+	err := csvReader.ReadHeader()
+	if err != nil {
+		t.Fatal(err)
+	}
+	record, err := csvReader.ReadRecord()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if record.Name != "John" {
+		t.Fatalf("Expected %v, got %v", "John", record.Name)
+	}
+	expected := time.Date(1970, 6, 17, 0, 0, 0, 0, time.UTC)
+	if !record.Birthday.Equal(expected) {
+		t.Fatalf("Expected %v, got %v", expected, record.Birthday)
+	}
+
+	writer := bytes.Buffer{}
+	csvWriter := typedcsv.NewWriter[VendoredRecord](csv.NewWriter(&writer), opts...)
+	err = csvWriter.WriteRecord(*record)
+	if err != nil {
+		t.Fatal(err)
+	}
+	csvWriter.Flush()
+	if writer.String() != "John,17/06/1970\n" {
+		t.Fatalf("Expected %q, got %q", "John,17/06/1970\n", writer.String())
+	}
+}
+
+func TestWithColumnTimeFormat(t *testing.T) {
+	reader := bytes.Buffer{}
+	reader.WriteString("time_with_location,time_without_location\n")
+	reader.WriteString("06/17/1970 01:02,06/17/1970 01:02\n")
+	csvReader := typedcsv.NewReader[TimeFormatTestRecord](
+		csv.NewReader(&reader),
+		typedcsv.WithColumnTimeFormat("time_with_location", "01/02/2006 15:04"),
+		typedcsv.WithColumnTimeFormat("time_without_location", "01/02/2006 15:04"),
+		typedcsv.WithColumnTimeLocation("time_with_location", "America/New_York"),
+	)
+	err := csvReader.ReadHeader()
+	if err != nil {
+		t.Fatal(err)
+	}
+	record, err := csvReader.ReadRecord()
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := time.Date(1970, 6, 17, 5, 2, 0, 0, time.UTC)
+	if !record.TimeWithLocation.Equal(expected) {
+		t.Fatalf("Expected %v, got %v", expected, record.TimeWithLocation)
+	}
+	expected = time.Date(1970, 6, 17, 1, 2, 0, 0, time.UTC)
+	if !record.TimeWithoutLocation.Equal(expected) {
+		t.Fatalf("Expected %v, got %v", expected, record.TimeWithoutLocation)
+	}
+}
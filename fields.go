@@ -0,0 +1,692 @@
+package typedcsv
+
+import (
+	"encoding"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// A field describes how a struct field is mapped to a CSV column.
+// It is computed once per type and never modified afterwards, so that the tags are parsed and the kind of the field
+// (time, duration, marshaler, slice, ...) is resolved once by NewCodec rather than for every record.
+type field struct {
+	index   []int
+	path    string
+	name    string
+	key     string
+	typ     reflect.Type
+	pointer bool
+
+	null            string
+	hasNull         bool
+	format          string
+	hasFormat       bool
+	scan            string
+	scanWidth       int
+	scalar          bool
+	base            int
+	hasBase         bool
+	baseErr         error
+	separator       string
+	timeFormat      string
+	hasTimeFormat   bool
+	timeLocation    string
+	hasTimeLocation bool
+	location        *time.Location
+	locationErr     error
+	timeZone        *time.Location
+	hasTimeZone     bool
+	timeZoneErr     error
+	timeCodec       *timeCodec
+	timeTruncate    time.Duration
+	timeRound       time.Duration
+	timeAdjustErr   error
+	durationCodec   *durationCodec
+	durationErr     error
+	duration        bool
+	prefer          string
+	raw             bool
+	validation      *SchemaColumn
+	validationErr   error
+
+	time            bool
+	unmarshaler     bool
+	marshaler       bool
+	slice           bool
+	sliceItemType   reflect.Type
+	quoted          bool
+	nested          []field
+	nestedComma     rune
+	omitEmpty       bool
+	enum            []string
+	includeIf       *includeIf
+	description     string
+	unit            string
+	encoding        string
+	hasPrefix       bool
+	bytes           *bytesCodec
+	bytesErr        error
+	locale          *locale
+	localeErr       error
+	hasLocale       bool
+	defaultValue    string
+	hasDefault      bool
+	defaultErr      error
+	translation     *translation
+	rangeEnd        bool
+	defaultFunc     func() any
+	defaultFuncName string
+	defaultFuncErr  error
+	position        int
+	hasPosition     bool
+	positionErr     error
+	noResolve       bool
+	converter       *converter
+	parseMethod     *fieldMethod
+	formatMethod    *fieldMethod
+	cacheable       bool
+}
+
+func fieldsOf(t reflect.Type, o *options) []field {
+	fields := appendFields(nil, t, nil, "", "", o)
+	resolveIncludeIf(fields, t)
+	resolveFieldMethods(fields, t)
+	return fields
+}
+
+// appendFields appends the fields of the struct type t to fields.
+// parent and path are the index and the Go name path of t in the record type, prefix is prepended to the column names.
+func appendFields(fields []field, t reflect.Type, parent []int, path string, prefix string, o *options) []field {
+	for i := 0; i < t.NumField(); i++ {
+		structField := t.Field(i)
+		flatten := !o.csvutil && isEmbeddedStruct(structField, o)
+		if !structField.IsExported() && !(flatten && structField.Type.Kind() == reflect.Struct) {
+			continue
+		}
+		index := append(append([]int(nil), parent...), i)
+		fieldPath := path + structField.Name
+		if flatten {
+			fields = appendFields(fields, indirectType(structField.Type), index, fieldPath+".", prefix, o)
+			continue
+		}
+		structField.Tag = o.overrideTag(fieldPath, "", structField.Tag)
+		if columnPrefix, ok := structField.Tag.Lookup(prefixTag); ok && isNestedStruct(structField.Type) {
+			fields = appendFields(fields, indirectType(structField.Type), index, fieldPath+".", prefix+columnPrefix, o)
+			continue
+		}
+		if o.csvutil {
+			name, tagOptions := parseCSVUtilTag(structField)
+			if name == "-" {
+				continue
+			}
+			if isInlineStruct(structField, tagOptions) {
+				fields = appendFields(fields, indirectType(structField.Type), index, fieldPath+".", prefix+name, o)
+				continue
+			}
+		}
+		name := o.nameMapper.ColumnName(structField)
+		if name == "" {
+			continue
+		}
+		name = prefix + name
+		structField.Tag = o.overrideTag("", name, structField.Tag)
+		fields = append(fields, newField(index, fieldPath, name, structField, o))
+		if structField.Name == "End" && isRangeType(t) {
+			// The start and the end are compared after the end is decoded, so it cannot be cached.
+			fields[len(fields)-1].rangeEnd = true
+			fields[len(fields)-1].cacheable = false
+		}
+	}
+	return fields
+}
+
+// isEmbeddedStruct reports whether the struct field is an embedded struct without a name tag, whose fields are flattened.
+// Embedded times and types implementing encoding.TextMarshaler or encoding.TextUnmarshaler are not flattened.
+func isEmbeddedStruct(structField reflect.StructField, o *options) bool {
+	t := indirectType(structField.Type)
+	if !structField.Anonymous || t.Kind() != reflect.Struct || t == timeType ||
+		t.Implements(textMarshalerType) || reflect.PointerTo(t).Implements(textUnmarshalerType) {
+		return false
+	}
+	key := ""
+	switch mapper := o.nameMapper.(type) {
+	case TagNameMapper:
+		key = mapper.TagKey
+	case SnakeCaseNameMapper:
+		key = mapper.TagKey
+	}
+	if key == "" {
+		key = csvTag
+	}
+	_, tagged := structField.Tag.Lookup(key)
+	return !tagged
+}
+
+// isNestedStruct reports whether t is a struct or a pointer to a struct whose fields can be expanded into columns.
+func isNestedStruct(t reflect.Type) bool {
+	t = indirectType(t)
+	return t.Kind() == reflect.Struct && t != timeType
+}
+
+func newField(index []int, path, name string, structField reflect.StructField, o *options) field {
+	tag := o.defaultTags(structField.Type, structField.Tag)
+	f := field{
+		index: index,
+		path:  path,
+		name:  name,
+		key:   o.nameMapper.Canonical(name),
+		typ:   structField.Type,
+	}
+	if f.typ.Kind() == reflect.Ptr {
+		f.pointer = true
+		f.typ = f.typ.Elem()
+	}
+	f.converter = o.converterOf(f.typ)
+	f.null, f.hasNull = tag.Lookup(nullTag)
+	f.format, f.hasFormat = tag.Lookup(formatTag)
+	f.scan = "%v"
+	if parse, ok := tag.Lookup(parseTag); ok {
+		f.scan = parse
+	} else if f.hasFormat {
+		f.scan, f.scanWidth = scanFormat(f.format)
+	}
+	f.separator = tag.Get(separatorTag)
+	f.timeFormat, f.hasTimeFormat = tag.Lookup(timeFormatTag)
+	f.timeLocation, f.hasTimeLocation = tag.Lookup(timeLocationTag)
+	f.timeCodec = timeCodecs[f.timeFormat]
+	if f.hasTimeLocation {
+		f.location, f.locationErr = time.LoadLocation(f.timeLocation)
+	}
+	if zone, ok := tag.Lookup(timeZoneTag); ok {
+		f.hasTimeZone = true
+		f.timeZone, f.timeZoneErr = time.LoadLocation(zone)
+	}
+	if truncate, ok := tag.Lookup(timeTruncateTag); ok {
+		f.timeTruncate, f.timeAdjustErr = parseTimeAdjustTag(timeTruncateTag, truncate)
+	}
+	if round, ok := tag.Lookup(timeRoundTag); ok && f.timeAdjustErr == nil {
+		f.timeRound, f.timeAdjustErr = parseTimeAdjustTag(timeRoundTag, round)
+	}
+	if durationFormat, ok := tag.Lookup(durationTag); ok {
+		f.durationCodec = durationCodecs[durationFormat]
+		if f.durationCodec == nil {
+			f.durationErr = fmt.Errorf("unknown duration_format %q", durationFormat)
+		}
+	}
+	if enum, ok := tag.Lookup(enumTag); ok {
+		f.enum = parseEnum(enum)
+	}
+	f.validation, f.validationErr = validationOf(tag, f.enum)
+	f.raw = f.typ == rawType
+	f.time = f.typ.ConvertibleTo(timeType)
+	f.duration = f.typ.ConvertibleTo(durationType)
+	f.unmarshaler = reflect.PointerTo(f.typ).Implements(textUnmarshalerType)
+	f.marshaler = f.typ.Implements(textMarshalerType)
+	f.prefer = tag.Get(preferTag)
+	if f.prefer == "time" && f.time && !f.hasTimeFormat {
+		f.timeFormat, f.hasTimeFormat = time.RFC3339Nano, true
+	}
+	if f.typ.Kind() == reflect.Slice {
+		f.slice = true
+		f.sliceItemType = f.typ.Elem()
+		f.quoted = tag.Get(quotedTag) == "true" && f.separator != ""
+		// Items split by width are padded, and fmt.Sscanf trims the padding of strings.
+		f.scalar = scalarVerb(f.sliceItemType.Kind(), f.scan) &&
+			(f.sliceItemType.Kind() != reflect.String || f.separator != "" || f.scanWidth == 0)
+	} else {
+		f.scalar = scalarVerb(f.typ.Kind(), f.scan)
+	}
+	f.base = 10
+	if value, ok := tag.Lookup(baseTag); ok {
+		itemType := f.typ
+		if f.slice {
+			itemType = f.sliceItemType
+		}
+		f.hasBase = true
+		f.base, f.baseErr = parseBaseTag(value, itemType)
+		f.scalar = f.baseErr == nil
+	}
+	f.encoding = tag.Get(encodingTag)
+	_, f.hasPrefix = tag.Lookup(prefixTag)
+	if isByteArray(f.typ) && f.encoding != "" && !f.marshaler && !f.unmarshaler {
+		f.bytes, f.bytesErr = bytesCodecOf(f.encoding, f.typ)
+	}
+	if isRangeType(f.typ) && f.encoding == "" {
+		f.encoding = "csv"
+		if f.separator == "" {
+			f.separator = defaultRangeSeparator
+		}
+	}
+	if f.encoding == "csv" && f.typ.Kind() == reflect.Struct {
+		f.nested = fieldsOf(f.typ, o)
+		f.nestedComma = ','
+		if separator := []rune(f.separator); len(separator) == 1 {
+			f.nestedComma = separator[0]
+		}
+	}
+	f.description = tag.Get(descTag)
+	f.unit = tag.Get(unitTag)
+	if name, ok := tag.Lookup(includeIfTag); ok {
+		f.includeIf = &includeIf{name: name}
+	}
+	if name, ok := tag.Lookup(parseMethodTag); ok {
+		f.parseMethod = &fieldMethod{name: name}
+	}
+	if name, ok := tag.Lookup(formatMethodTag); ok {
+		f.formatMethod = &fieldMethod{name: name}
+	}
+	localeName, hasLocale := tag.Lookup(localeTag)
+	if !hasLocale {
+		localeName = o.locale
+	}
+	f.hasLocale = hasLocale
+	if localeName != "" && f.localizable() {
+		f.locale, f.localeErr = localeOf(localeName)
+	}
+	if o.csvutil {
+		_, tagOptions := parseCSVUtilTag(structField)
+		f.omitEmpty = hasTagOption(tagOptions, "omitempty")
+	}
+	f.noResolve = tag.Get(resolveTag) == "false"
+	f.cacheable = tag.Get(cacheTag) != "false" && !f.raw && isCacheable(f.typ)
+	f.translation = o.translationOf(f.key)
+	if index, ok := tag.Lookup(indexTag); ok {
+		f.hasPosition = true
+		f.position, f.positionErr = parseIndexTag(index)
+	}
+	if name, ok := tag.Lookup(defaultFuncTag); ok {
+		f.defaultFuncName = name
+		f.defaultFunc, f.defaultFuncErr = o.defaultFuncOf(name)
+		f.cacheable = false
+	}
+	f.defaultValue, f.hasDefault = tag.Lookup(defaultTag)
+	if f.hasDefault && f.parseMethod == nil {
+		f.defaultErr = f.decode(&decodeState{options: &options{}}, reflect.New(structField.Type).Elem(), f.defaultValue)
+	}
+	return f
+}
+
+// timeFormatted reports whether the field is parsed and formatted with its time format,
+// rather than as an encoding.TextUnmarshaler and encoding.TextMarshaler, as chosen by the "prefer" tag value.
+func (f *field) timeFormatted() bool {
+	return f.time && f.hasTimeFormat && f.prefer != "text"
+}
+
+// valueForDecode returns the value of the field in the struct value v, allocating nil embedded pointers on the way.
+func (f *field) valueForDecode(v reflect.Value) reflect.Value {
+	for i, x := range f.index {
+		if i > 0 && v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v
+}
+
+// valueForEncode returns the value of the field in the struct value v.
+// It returns false if a nil embedded pointer is on the way.
+func (f *field) valueForEncode(v reflect.Value) (reflect.Value, bool) {
+	for i, x := range f.index {
+		if i > 0 && v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				return reflect.Value{}, false
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v, true
+}
+
+// decodeState carries the per-row context used while decoding fields.
+type decodeState struct {
+	options  *options
+	row      int
+	warnings *[]Warning
+	interner *interner
+	cache    *valueCache
+	// holder is the struct value holding the decoded fields, on which parse methods are called.
+	holder reflect.Value
+	// warned is set when a warning is reported, so that the values parsed with warnings are not cached.
+	warned bool
+}
+
+func (s *decodeState) warn(w Warning) {
+	s.warned = true
+	w.Row = s.row
+	s.options.warn(w)
+	if s.warnings != nil {
+		s.options.keepWarning(s.warnings, w)
+	}
+}
+
+// decode parses value into fieldValue, which must be the settable struct field, and validates it.
+func (f *field) decode(state *decodeState, fieldValue reflect.Value, value string) error {
+	if state.options.trimSpace && !f.raw {
+		value = strings.TrimSpace(value)
+	}
+	if f.translation != nil {
+		value = f.translation.translate(value)
+	}
+	if value == "" && f.hasDefault {
+		value = f.defaultValue
+	} else if value == "" && f.hasDefaults() {
+		generated, err := f.generateDefault(fieldValue.Type())
+		if err != nil {
+			return FieldParseError{Field: f.name, NestedError: err}
+		}
+		fieldValue.Set(generated)
+		return nil
+	}
+	// The values of nested rows are expanded, rather than their cell, so that placeholders are expanded once.
+	if f.nested == nil && f.resolves(state.options, value) {
+		expanded, err := state.options.resolver.expand(value)
+		if err != nil {
+			return FieldParseError{Field: f.name, NestedError: err}
+		}
+		value = expanded
+	}
+	err := f.decodeValue(state, fieldValue, value)
+	if err == nil && f.time {
+		err = f.normalizeTime(state, fieldValue)
+	}
+	if err == nil && (f.validation != nil || f.validationErr != nil) {
+		if err := f.validate(value, fieldValue); err != nil {
+			return FieldParseError{Field: f.name, NestedError: err}
+		}
+	}
+	if err == nil && f.rangeEnd && state.holder.IsValid() {
+		err = f.checkRange(state.holder)
+	}
+	return err
+}
+
+func (f *field) decodeValue(state *decodeState, fieldValue reflect.Value, value string) error {
+	// Pointer
+	if f.pointer {
+		if f.hasNull && value == f.null {
+			fieldValue.Set(reflect.Zero(fieldValue.Type()))
+			return nil
+		}
+		fieldValue.Set(reflect.New(f.typ))
+		fieldValue = fieldValue.Elem()
+	}
+	// Raw
+	if f.raw {
+		fieldValue.SetString(value)
+		return nil
+	}
+	// Nested row
+	if f.nested != nil {
+		return f.decodeNested(state, fieldValue, value)
+	}
+	// Parse method
+	if f.parseMethod != nil {
+		parsed, err := f.parseMethod.call(state.holder, reflect.ValueOf(value))
+		if err != nil {
+			return FieldParseError{Field: f.name, NestedError: err}
+		}
+		fieldValue.Set(parsed)
+		return nil
+	}
+	// Converter
+	if f.converter != nil && f.converter.parse != nil {
+		converted, err := f.converter.parse(value)
+		if err != nil {
+			return FieldParseError{Field: f.name, NestedError: err}
+		}
+		fieldValue.Set(converted)
+		return nil
+	}
+	if f.localeErr != nil {
+		return FieldParseError{Field: f.name, NestedError: f.localeErr}
+	}
+	if f.baseErr != nil {
+		return FieldParseError{Field: f.name, NestedError: f.baseErr}
+	}
+	// Time
+	if f.timeFormatted() && f.timeFormat != "" {
+		var timeValue time.Time
+		var err error
+		if f.locale != nil {
+			value = f.locale.parseTime(value, f.timeFormat)
+		}
+		if f.timeLocation != "" {
+			if f.locationErr != nil {
+				return FieldParseError{Field: f.name, NestedError: f.locationErr}
+			}
+			timeValue, err = f.parseTime(value, f.location)
+		} else {
+			timeValue, err = f.parseTime(value, nil)
+		}
+		if err != nil {
+			return FieldParseError{Field: f.name, NestedError: err}
+		}
+		fieldValue.Set(reflect.ValueOf(timeValue).Convert(f.typ))
+		return nil
+	}
+	// Duration
+	if f.duration && (f.durationCodec != nil || f.durationErr != nil) {
+		if f.durationErr != nil {
+			return FieldParseError{Field: f.name, NestedError: f.durationErr}
+		}
+		d, err := f.durationCodec.parse(value)
+		if err != nil {
+			return FieldParseError{Field: f.name, NestedError: err}
+		}
+		fieldValue.Set(reflect.ValueOf(d).Convert(f.typ))
+		return nil
+	}
+	// TextUnmarshaler
+	if f.unmarshaler {
+		err := fieldValue.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(value))
+		if err != nil {
+			return FieldParseError{Field: f.name, NestedError: err}
+		}
+		return nil
+	}
+	// Byte array
+	if f.bytes != nil || f.bytesErr != nil {
+		return f.decodeBytes(fieldValue, value)
+	}
+	// Slice
+	if f.slice {
+		items, err := f.splitItems(value)
+		if err != nil {
+			return FieldParseError{Field: f.name, NestedError: err}
+		}
+		slice := reflect.MakeSlice(f.typ, 0, len(items))
+		for itemIndex, item := range items {
+			itemValue := reflect.New(f.sliceItemType)
+			if f.locale != nil {
+				item = f.locale.parseNumber(item)
+			}
+			var err error
+			if f.scalar {
+				err = parseScalar(itemValue.Elem(), item, f.base)
+			} else {
+				err = scanValue(itemValue.Elem(), f.scan, item)
+			}
+			if err != nil {
+				return FieldParseError{Field: fmt.Sprintf("%s[%d]", f.name, itemIndex), NestedError: err}
+			}
+			slice = reflect.Append(slice, itemValue.Elem())
+		}
+		fieldValue.Set(slice)
+		return nil
+	}
+	// Default
+	if f.locale != nil {
+		value = f.locale.parseNumber(value)
+	}
+	if f.scalar {
+		if f.typ.Kind() == reflect.String && f.scanWidth > 0 {
+			// Strings formatted with a width, such as "%8s", are padded, like items split by width.
+			value = strings.Trim(value, " ")
+		}
+		if strings.TrimSpace(value) == "" && f.typ.Kind() != reflect.String {
+			state.warn(Warning{Kind: WarningCoercedValue, Column: f.name, Index: -1, Value: value})
+			fieldValue.Set(reflect.Zero(f.typ))
+			return nil
+		}
+		if err := parseScalar(fieldValue, value, f.base); err != nil {
+			return FieldParseError{Field: f.name, NestedError: err}
+		}
+		return nil
+	}
+	err := scanValue(fieldValue, f.scan, value)
+	if err == io.EOF {
+		state.warn(Warning{Kind: WarningCoercedValue, Column: f.name, Index: -1, Value: value})
+		fieldValue.Set(reflect.Zero(f.typ))
+		err = nil
+	}
+	if err != nil {
+		return FieldParseError{Field: f.name, NestedError: err}
+	}
+	return nil
+}
+
+// encode validates fieldValue, which must be the struct field of the struct value holder, and formats it as a CSV value.
+func (f *field) encode(holder, fieldValue reflect.Value) (string, error) {
+	return f.encodeWith(holder, fieldValue, true)
+}
+
+// encodeWith formats fieldValue like encode, validating the value, and the values of a nested row, only if validate is true.
+func (f *field) encodeWith(holder, fieldValue reflect.Value, validate bool) (string, error) {
+	value, err := f.encodeValue(holder, fieldValue, validate)
+	if err == nil && validate && (f.validation != nil || f.validationErr != nil) {
+		if err := f.validate(value, fieldValue); err != nil {
+			return "", FieldFormatError{Field: f.name, NestedError: err}
+		}
+	}
+	if err == nil && f.translation != nil {
+		value = f.translation.untranslate(value)
+	}
+	return value, err
+}
+
+func (f *field) encodeValue(holder, fieldValue reflect.Value, validate bool) (string, error) {
+	if f.omitEmpty && fieldValue.IsZero() {
+		return "", nil
+	}
+	// Pointer
+	if f.pointer {
+		if fieldValue.IsNil() {
+			return f.null, nil
+		}
+		fieldValue = fieldValue.Elem()
+	}
+	// Raw
+	if f.raw {
+		return fieldValue.String(), nil
+	}
+	// Nested row
+	if f.nested != nil {
+		return f.encodeNested(fieldValue, validate)
+	}
+	// Format method
+	if f.formatMethod != nil {
+		formatted, err := f.formatMethod.call(holder, fieldValue)
+		if err != nil {
+			return "", FieldFormatError{Field: f.name, NestedError: err}
+		}
+		return formatted.String(), nil
+	}
+	// Converter
+	if f.converter != nil && f.converter.format != nil {
+		value, err := f.converter.format(fieldValue)
+		if err != nil {
+			return "", FieldFormatError{Field: f.name, NestedError: err}
+		}
+		return value, nil
+	}
+	if f.localeErr != nil {
+		return "", FieldFormatError{Field: f.name, NestedError: f.localeErr}
+	}
+	if f.baseErr != nil {
+		return "", FieldFormatError{Field: f.name, NestedError: f.baseErr}
+	}
+	// Time truncation and rounding
+	if f.time && (f.timeTruncate != 0 || f.timeRound != 0 || f.timeAdjustErr != nil) {
+		if f.timeAdjustErr != nil {
+			return "", FieldFormatError{Field: f.name, NestedError: f.timeAdjustErr}
+		}
+		timeValue := f.adjustTime(fieldValue.Convert(timeType).Interface().(time.Time))
+		fieldValue = reflect.ValueOf(timeValue).Convert(f.typ)
+	}
+	// Time
+	if f.timeFormatted() {
+		timeValue := fieldValue.Convert(timeType).Interface().(time.Time)
+		if f.hasTimeLocation {
+			if f.locationErr != nil {
+				return "", FieldFormatError{Field: f.name, NestedError: f.locationErr}
+			}
+			timeValue = timeValue.In(f.location)
+		}
+		if f.locale != nil {
+			return f.locale.formatTime(f.formatTime(timeValue), f.timeFormat), nil
+		}
+		return f.formatTime(timeValue), nil
+	}
+	// Duration
+	if f.duration && (f.durationCodec != nil || f.durationErr != nil) {
+		if f.durationErr != nil {
+			return "", FieldFormatError{Field: f.name, NestedError: f.durationErr}
+		}
+		return f.durationCodec.format(fieldValue.Convert(durationType).Interface().(time.Duration)), nil
+	}
+	// TextMarshaler
+	if f.marshaler {
+		text, err := fieldValue.Interface().(encoding.TextMarshaler).MarshalText()
+		if err != nil {
+			return "", FieldFormatError{Field: f.name, NestedError: err}
+		}
+		return string(text), nil
+	}
+	// Byte array
+	if f.bytes != nil || f.bytesErr != nil {
+		return f.encodeBytes(fieldValue)
+	}
+	// Slice
+	if f.slice {
+		format := "%v"
+		if f.hasFormat {
+			format = f.format
+		}
+		var builder strings.Builder
+		for i := 0; i < fieldValue.Len(); i++ {
+			if i > 0 {
+				builder.WriteString(f.separator)
+			}
+			item := fmt.Sprintf(format, fieldValue.Index(i).Interface())
+			if f.hasBase && !f.hasFormat {
+				item = formatInteger(fieldValue.Index(i), f.base)
+			}
+			if f.locale != nil {
+				item = f.locale.formatNumber(item)
+			}
+			builder.WriteString(f.quoteItem(item))
+		}
+		return builder.String(), nil
+	}
+	// Base
+	if f.hasBase && !f.hasFormat {
+		return formatInteger(fieldValue, f.base), nil
+	}
+	// Format
+	format := "%v"
+	if f.hasFormat {
+		format = f.format
+	}
+	value := fmt.Sprintf(format, fieldValue.Interface())
+	if f.locale != nil {
+		value = f.locale.formatNumber(value)
+	}
+	return value, nil
+}
@@ -0,0 +1,102 @@
+package typedcsv
+
+import (
+	"compress/gzip"
+	"errors"
+	"io"
+	"os"
+	"strings"
+)
+
+// OpenReader opens the CSV file at path and returns a new TypedCSVReader reading it.
+// A file whose name ends with ".gz" is decompressed with gzip. The CSV dialect is set by options such as WithComma, like NewReaderFrom.
+// The reader owns the file, which is closed by Close.
+func OpenReader[T any](path string, opts ...Option) (*TypedCSVReader[T], error) {
+	source, closers, err := openFile(path)
+	if err != nil {
+		return nil, err
+	}
+	reader := NewReaderFrom[T](source, opts...)
+	reader.closers = closers
+	return reader, nil
+}
+
+// CreateWriter creates or truncates the CSV file at path and returns a new TypedCSVWriter writing to it.
+// A file whose name ends with ".gz" is compressed with gzip. The CSV dialect is set by options such as WithComma, like NewWriterTo.
+// With AppendMode, the records are appended to the file instead.
+// The writer owns the file, which is flushed and closed by Close.
+func CreateWriter[T any](path string, opts ...Option) (*TypedCSVWriter[T], error) {
+	codec := NewCodec[T](opts...)
+	if codec.options.appendMode {
+		return appendWriter(codec, path)
+	}
+	sink, closers, err := createFile(path)
+	if err != nil {
+		return nil, err
+	}
+	writer := codec.NewWriterTo(sink)
+	writer.closers = closers
+	return writer, nil
+}
+
+// Close closes the resources owned by the reader, such as the file opened by OpenReader.
+// It does nothing for readers created from a csv.Reader or an io.Reader, which are owned by the caller.
+// Calling Close more than once does nothing.
+func (r *TypedCSVReader[T]) Close() error {
+	closers := r.closers
+	r.closers = nil
+	return closeAll(closers)
+}
+
+// Close flushes the writer and closes the resources owned by the writer, such as the file created by CreateWriter.
+// It returns any error that has occurred during a previous write, the final flush or the closing of the resources,
+// so that `defer w.Close()` does not lose buffered records; the error should still be checked when exporting.
+// Writers created from a csv.Writer or an io.Writer are only flushed, as the underlying writer is owned by the caller.
+func (w *TypedCSVWriter[T]) Close() error {
+	w.Flush()
+	err := w.Error()
+	closers := w.closers
+	w.closers = nil
+	return errors.Join(err, closeAll(closers))
+}
+
+// closeAll closes the resources in order and returns their errors joined with errors.Join.
+func closeAll(closers []io.Closer) error {
+	var errs []error
+	for _, closer := range closers {
+		errs = append(errs, closer.Close())
+	}
+	return errors.Join(errs...)
+}
+
+// openFile opens the file at path, decompressed with gzip if its name ends with ".gz",
+// and returns the resources to close in order.
+func openFile(path string) (io.Reader, []io.Closer, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !strings.HasSuffix(path, ".gz") {
+		return file, []io.Closer{file}, nil
+	}
+	gzipReader, err := gzip.NewReader(file)
+	if err != nil {
+		file.Close()
+		return nil, nil, err
+	}
+	return gzipReader, []io.Closer{gzipReader, file}, nil
+}
+
+// createFile creates or truncates the file at path, compressed with gzip if its name ends with ".gz",
+// and returns the resources to close in order.
+func createFile(path string) (io.Writer, []io.Closer, error) {
+	file, err := os.Create(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !strings.HasSuffix(path, ".gz") {
+		return file, []io.Closer{file}, nil
+	}
+	gzipWriter := gzip.NewWriter(file)
+	return gzipWriter, []io.Closer{gzipWriter, file}, nil
+}
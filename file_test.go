@@ -0,0 +1,89 @@
+package typedcsv_test
+
+import (
+	"bytes"
+	"encoding/csv"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/hoshiumiarata/typedcsv"
+)
+
+func TestOpenReaderCreateWriter(t *testing.T) {
+	records := []Person{{Name: "John", Age: 30, PetNames: []string{"Rex"}, Status: PersonStatusActive}, {Name: "Jane", Age: 25, PetNames: []string{"Tom", "Kitty"}}}
+	for _, name := range []string{"people.csv", "people.csv.gz"} {
+		path := filepath.Join(t.TempDir(), name)
+		csvWriter, err := typedcsv.CreateWriter[Person](path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := csvWriter.WriteHeader(); err != nil {
+			t.Fatal(err)
+		}
+		for _, record := range records {
+			if err := csvWriter.WriteRecord(record); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := csvWriter.Close(); err != nil {
+			t.Fatal(err)
+		}
+		if err := csvWriter.Close(); err != nil {
+			t.Fatalf("Expected nil on second Close, got %v", err)
+		}
+
+		csvReader, err := typedcsv.OpenReader[Person](path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := csvReader.ReadHeader(); err != nil {
+			t.Fatal(err)
+		}
+		read, err := csvReader.ReadAll()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(read) != len(records) || !reflect.DeepEqual(*read[0], records[0]) || !reflect.DeepEqual(*read[1], records[1]) {
+			t.Fatalf("%s: Expected %v, got %v", name, records, read)
+		}
+		if err := csvReader.Close(); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestCreateWriterReset(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "people.csv.gz")
+	csvWriter, err := typedcsv.CreateWriter[Person](path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	output := bytes.Buffer{}
+	csvWriter.Reset(csv.NewWriter(&output))
+	if err := csvWriter.WriteHeader(); err != nil {
+		t.Fatal(err)
+	}
+	if err := csvWriter.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if output.Len() == 0 {
+		t.Fatal("Expected the header in the new output, got nothing")
+	}
+	// The gzip writer of the file writes its header when closed, so the file is still empty if it was not closed again.
+	stat, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stat.Size() != 0 {
+		t.Fatalf("Expected the file not to be closed by the reset writer, got %d bytes", stat.Size())
+	}
+}
+
+func TestOpenReaderError(t *testing.T) {
+	_, err := typedcsv.OpenReader[Person](filepath.Join(t.TempDir(), "missing.csv"))
+	if !os.IsNotExist(err) {
+		t.Fatalf("Expected a not exist error, got %v", err)
+	}
+}
@@ -0,0 +1,235 @@
+package typedcsv
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"io"
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// WriteGoFixture writes Go source of package pkg declaring a variable with the given name
+// that holds the records as a []T literal. It can be used to turn CSV test fixtures into compile-time data:
+//
+//	records, err := typedcsv.NewReader[Person](csv.NewReader(file)).ReadAll()
+//	...
+//	err = typedcsv.WriteGoFixture(out, records, "fixtures", "People")
+//
+// Only exported fields with non-zero values are written. Types declared in other packages are qualified
+// and imported. NaN and infinite floats are written as calls to math.NaN and math.Inf.
+// Maps, channels, functions and interfaces holding non-basic values are not supported.
+func WriteGoFixture[T any](w io.Writer, records []*T, pkg, name string) error {
+	g := fixtureGenerator{pkg: pkg, imports: make(map[string]string)}
+	var body bytes.Buffer
+	var zero [0]T
+	recordType := reflect.TypeOf(zero).Elem()
+	fmt.Fprintf(&body, "var %s = []%s{\n", name, g.typeExpr(recordType))
+	for _, record := range records {
+		if record == nil {
+			continue
+		}
+		value, err := g.value(reflect.ValueOf(record).Elem(), true)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(&body, "%s,\n", value)
+	}
+	body.WriteString("}\n")
+
+	var source bytes.Buffer
+	source.WriteString("// Code generated by typedcsv.WriteGoFixture. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&source, "package %s\n\n", pkg)
+	if len(g.imports) > 0 {
+		paths := make([]string, 0, len(g.imports))
+		for path := range g.imports {
+			paths = append(paths, path)
+		}
+		sort.Strings(paths)
+		source.WriteString("import (\n")
+		for _, path := range paths {
+			fmt.Fprintf(&source, "%s\n", strconv.Quote(path))
+		}
+		source.WriteString(")\n\n")
+	}
+	source.Write(body.Bytes())
+
+	formatted, err := format.Source(source.Bytes())
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(formatted)
+	return err
+}
+
+type fixtureGenerator struct {
+	pkg     string
+	imports map[string]string
+}
+
+// typeExpr returns the Go expression of the type t, qualified and imported if needed.
+func (g *fixtureGenerator) typeExpr(t reflect.Type) string {
+	if t.Name() != "" {
+		if t.PkgPath() == "" {
+			return t.Name()
+		}
+		qualified := t.String()
+		pkgName, _, _ := strings.Cut(qualified, ".")
+		if pkgName == g.pkg {
+			return t.Name()
+		}
+		g.imports[t.PkgPath()] = pkgName
+		return qualified
+	}
+	switch t.Kind() {
+	case reflect.Ptr:
+		return "*" + g.typeExpr(t.Elem())
+	case reflect.Slice:
+		return "[]" + g.typeExpr(t.Elem())
+	case reflect.Array:
+		return fmt.Sprintf("[%d]%s", t.Len(), g.typeExpr(t.Elem()))
+	case reflect.Map:
+		return fmt.Sprintf("map[%s]%s", g.typeExpr(t.Key()), g.typeExpr(t.Elem()))
+	default:
+		return t.String()
+	}
+}
+
+// value returns the Go expression of v. If elided is true, the type of composite literals is omitted.
+func (g *fixtureGenerator) value(v reflect.Value, elided bool) (string, error) {
+	t := v.Type()
+	if t.ConvertibleTo(timeType) && t.Kind() == reflect.Struct {
+		expr := g.timeExpr(v.Convert(timeType).Interface().(time.Time))
+		if t != timeType {
+			expr = fmt.Sprintf("%s(%s)", g.typeExpr(t), expr)
+		}
+		return expr, nil
+	}
+	switch t.Kind() {
+	case reflect.Bool, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64, reflect.Complex64, reflect.Complex128:
+		expr := g.basicLiteral(v)
+		if t.Name() != "" && t.PkgPath() != "" {
+			expr = fmt.Sprintf("%s(%s)", g.typeExpr(t), expr)
+		}
+		return expr, nil
+	case reflect.String:
+		expr := strconv.Quote(v.String())
+		if t.PkgPath() != "" {
+			expr = fmt.Sprintf("%s(%s)", g.typeExpr(t), expr)
+		}
+		return expr, nil
+	case reflect.Ptr:
+		if v.IsNil() {
+			return "nil", nil
+		}
+		elem, err := g.value(v.Elem(), false)
+		if err != nil {
+			return "", err
+		}
+		if t.Elem().Kind() == reflect.Struct && !t.Elem().ConvertibleTo(timeType) {
+			return "&" + elem, nil
+		}
+		elemType := g.typeExpr(t.Elem())
+		return fmt.Sprintf("func() *%s { v := %s(%s); return &v }()", elemType, elemType, elem), nil
+	case reflect.Slice, reflect.Array:
+		if t.Kind() == reflect.Slice && v.IsNil() {
+			return "nil", nil
+		}
+		items := make([]string, v.Len())
+		for i := range items {
+			item, err := g.value(v.Index(i), true)
+			if err != nil {
+				return "", err
+			}
+			items[i] = item
+		}
+		return g.composite(t, elided, strings.Join(items, ", ")), nil
+	case reflect.Struct:
+		var items []string
+		for i := 0; i < t.NumField(); i++ {
+			structField := t.Field(i)
+			if !structField.IsExported() || v.Field(i).IsZero() {
+				continue
+			}
+			item, err := g.value(v.Field(i), false)
+			if err != nil {
+				return "", err
+			}
+			items = append(items, fmt.Sprintf("%s: %s", structField.Name, item))
+		}
+		if len(items) == 0 {
+			return g.composite(t, elided, ""), nil
+		}
+		return g.composite(t, elided, "\n"+strings.Join(items, ",\n")+",\n"), nil
+	default:
+		return "", fmt.Errorf("typedcsv: unsupported fixture type %s", t)
+	}
+}
+
+func (g *fixtureGenerator) composite(t reflect.Type, elided bool, body string) string {
+	if elided {
+		return "{" + body + "}"
+	}
+	return g.typeExpr(t) + "{" + body + "}"
+}
+
+func (g *fixtureGenerator) timeExpr(t time.Time) string {
+	g.imports["time"] = "time"
+	location := "time.UTC"
+	if t.Location() != time.UTC {
+		name, offset := t.Zone()
+		location = fmt.Sprintf("time.FixedZone(%q, %d)", name, offset)
+	}
+	return fmt.Sprintf("time.Date(%d, time.%s, %d, %d, %d, %d, %d, %s)",
+		t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), location)
+}
+
+// basicLiteral returns the literal of a boolean or numeric value, ignoring any String method of its type.
+func (g *fixtureGenerator) basicLiteral(v reflect.Value) string {
+	switch v.Kind() {
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(v.Uint(), 10)
+	case reflect.Float32, reflect.Float64:
+		return g.floatExpr(v.Float(), v.Type().Bits())
+	default:
+		c, bits := v.Complex(), v.Type().Bits()/2
+		if isFinite(real(c)) && isFinite(imag(c)) {
+			return fmt.Sprintf("%v", c)
+		}
+		return fmt.Sprintf("complex(%s, %s)", g.floatExpr(real(c), bits), g.floatExpr(imag(c), bits))
+	}
+}
+
+// floatExpr returns the Go expression of a float of the given size, calling math.NaN or math.Inf for values
+// that have no literal.
+func (g *fixtureGenerator) floatExpr(f float64, bits int) string {
+	if isFinite(f) {
+		return strconv.FormatFloat(f, 'g', -1, bits)
+	}
+	g.imports["math"] = "math"
+	expr := "math.NaN()"
+	switch {
+	case math.IsInf(f, 1):
+		expr = "math.Inf(1)"
+	case math.IsInf(f, -1):
+		expr = "math.Inf(-1)"
+	}
+	if bits == 32 {
+		expr = "float32(" + expr + ")"
+	}
+	return expr
+}
+
+func isFinite(f float64) bool {
+	return !math.IsNaN(f) && !math.IsInf(f, 0)
+}
@@ -0,0 +1,101 @@
+package typedcsv_test
+
+import (
+	"bytes"
+	"encoding/csv"
+	"math"
+	"testing"
+
+	"github.com/hoshiumiarata/typedcsv"
+)
+
+func TestWriteGoFixture(t *testing.T) {
+	reader := bytes.Buffer{}
+	reader.WriteString("name,birthday,age,pet names,active,status,percentage,optional\n")
+	reader.WriteString("John,1970-06-17,55,Fluffy;Spot,true,active,12.35,NULL\n")
+	reader.WriteString("Mary,1971-07-18,66,Puffy,false,inactive,23.46,Hello\n")
+	csvReader := typedcsv.NewReader[Person](csv.NewReader(&reader))
+	err := csvReader.ReadHeader()
+	if err != nil {
+		t.Fatal(err)
+	}
+	records, err := csvReader.ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	source := bytes.Buffer{}
+	err = typedcsv.WriteGoFixture(&source, records, "fixtures", "People")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `// Code generated by typedcsv.WriteGoFixture. DO NOT EDIT.
+
+package fixtures
+
+import (
+	"github.com/hoshiumiarata/typedcsv_test"
+	"time"
+)
+
+var People = []typedcsv_test.Person{
+	{
+		Name:       "John",
+		Birthday:   time.Date(1970, time.June, 17, 0, 0, 0, 0, time.UTC),
+		Age:        55,
+		PetNames:   []string{"Fluffy", "Spot"},
+		Active:     true,
+		Status:     typedcsv_test.PersonStatus(1),
+		Percentage: 12.35,
+	},
+	{
+		Name:       "Mary",
+		Birthday:   time.Date(1971, time.July, 18, 0, 0, 0, 0, time.UTC),
+		Age:        66,
+		PetNames:   []string{"Puffy"},
+		Status:     typedcsv_test.PersonStatus(2),
+		Percentage: 23.46,
+		Optional:   func() *string { v := string("Hello"); return &v }(),
+	},
+}
+`
+	if source.String() != expected {
+		t.Fatalf("Expected %s, got %s", expected, source.String())
+	}
+}
+
+type FixtureFloatRecord struct {
+	A float64
+	B float32
+	C *float64
+	D complex64
+}
+
+func TestWriteGoFixtureNonFinite(t *testing.T) {
+	c := math.Inf(-1)
+	records := []*FixtureFloatRecord{{A: math.NaN(), B: float32(math.Inf(1)), C: &c, D: complex(float32(math.NaN()), 1)}}
+	source := bytes.Buffer{}
+	err := typedcsv.WriteGoFixture(&source, records, "typedcsv_test", "Floats")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `// Code generated by typedcsv.WriteGoFixture. DO NOT EDIT.
+
+package typedcsv_test
+
+import (
+	"math"
+)
+
+var Floats = []FixtureFloatRecord{
+	{
+		A: math.NaN(),
+		B: float32(math.Inf(1)),
+		C: func() *float64 { v := float64(math.Inf(-1)); return &v }(),
+		D: complex(float32(math.NaN()), 1),
+	},
+}
+`
+	if source.String() != expected {
+		t.Fatalf("Expected %s, got %s", expected, source.String())
+	}
+}
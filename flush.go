@@ -0,0 +1,33 @@
+package typedcsv
+
+import "time"
+
+// WithFlushEvery makes TypedCSVWriter flush after every given number of records, if rows is positive,
+// and after the first record written once the given interval has elapsed since the last flush, if interval is positive,
+// so that long-running exports reach the underlying writer without explicit Flush calls.
+// The interval is checked when a record is written; no background goroutine flushes an idle writer.
+// Close flushes the remaining records.
+func WithFlushEvery(rows int, interval time.Duration) Option {
+	return func(o *options) {
+		o.flushRows = rows
+		o.flushInterval = interval
+	}
+}
+
+// autoFlush flushes the writer if the number of records or the interval set by WithFlushEvery is reached.
+func (w *TypedCSVWriter[T]) autoFlush() error {
+	o := &w.codec.options
+	if o.flushRows <= 0 && o.flushInterval <= 0 {
+		return nil
+	}
+	w.unflushed++
+	now := time.Now()
+	if w.lastFlush.IsZero() {
+		w.lastFlush = now
+	}
+	if (o.flushRows > 0 && w.unflushed >= o.flushRows) || (o.flushInterval > 0 && now.Sub(w.lastFlush) >= o.flushInterval) {
+		w.Flush()
+		return w.Error()
+	}
+	return nil
+}
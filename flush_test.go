@@ -0,0 +1,57 @@
+package typedcsv_test
+
+import (
+	"bytes"
+	"encoding/csv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hoshiumiarata/typedcsv"
+)
+
+func TestFlushEveryRows(t *testing.T) {
+	var buffer bytes.Buffer
+	csvWriter := typedcsv.NewWriter[Person](csv.NewWriter(&buffer), typedcsv.WithFlushEvery(2, 0))
+	if err := csvWriter.WriteHeader(); err != nil {
+		t.Fatal(err)
+	}
+	for i, expected := range []int{0, 3, 3, 5} {
+		if err := csvWriter.WriteRecord(Person{Name: "John"}); err != nil {
+			t.Fatal(err)
+		}
+		if lines := strings.Count(buffer.String(), "\n"); lines != expected {
+			t.Fatalf("Expected %v lines after record %d, got %v", expected, i+1, lines)
+		}
+	}
+	csvWriter.WriteRecord(Person{Name: "John"})
+	if err := csvWriter.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if lines := strings.Count(buffer.String(), "\n"); lines != 6 {
+		t.Fatalf("Expected %v lines after Close, got %v", 6, lines)
+	}
+}
+
+func TestFlushEveryInterval(t *testing.T) {
+	var buffer bytes.Buffer
+	csvWriter := typedcsv.NewWriter[Person](csv.NewWriter(&buffer), typedcsv.WithFlushEvery(0, time.Nanosecond))
+	if err := csvWriter.WriteRecord(Person{Name: "John"}); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(time.Millisecond)
+	if err := csvWriter.WriteRecord(Person{Name: "John"}); err != nil {
+		t.Fatal(err)
+	}
+	if lines := strings.Count(buffer.String(), "\n"); lines != 2 {
+		t.Fatalf("Expected %v lines, got %v", 2, lines)
+	}
+}
+
+func TestCloseError(t *testing.T) {
+	csvWriter := typedcsv.NewWriter[Person](csv.NewWriter(&ErrorWriter{}))
+	csvWriter.WriteRecord(Person{Name: "John"})
+	if err := csvWriter.Close(); err == nil || err.Error() != "write error" {
+		t.Fatalf("Expected %v, got %v", "write error", err)
+	}
+}
@@ -0,0 +1,48 @@
+package typedcsv
+
+import (
+	"context"
+	"io"
+	"time"
+)
+
+// Follow returns an io.Reader that reads from r and, instead of returning io.EOF, waits for the given interval
+// and retries, like tail -f. It returns the error of ctx once ctx is done.
+//
+// It lets a TypedCSVReader consume a CSV file that is continuously appended to:
+//
+//	reader := typedcsv.NewReader[T](csv.NewReader(typedcsv.Follow(ctx, file, time.Second)))
+//
+// ReadRecord then blocks until the next record is written, and returns the error of ctx when it is done.
+// Since the underlying csv.Reader only sees complete lines, a record being written is not read until its line is complete.
+func Follow(ctx context.Context, r io.Reader, interval time.Duration) io.Reader {
+	return &followReader{ctx: ctx, reader: r, interval: interval}
+}
+
+type followReader struct {
+	ctx      context.Context
+	reader   io.Reader
+	interval time.Duration
+}
+
+func (r *followReader) Read(p []byte) (int, error) {
+	for {
+		if err := r.ctx.Err(); err != nil {
+			return 0, err
+		}
+		n, err := r.reader.Read(p)
+		if n > 0 || err != io.EOF {
+			if err == io.EOF {
+				err = nil
+			}
+			return n, err
+		}
+		timer := time.NewTimer(r.interval)
+		select {
+		case <-r.ctx.Done():
+			timer.Stop()
+			return 0, r.ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
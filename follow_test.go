@@ -0,0 +1,78 @@
+package typedcsv_test
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"io"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hoshiumiarata/typedcsv"
+)
+
+// GrowingBuffer is a buffer that can be appended to while it is read, returning io.EOF when it is drained.
+type GrowingBuffer struct {
+	mu   sync.Mutex
+	data []byte
+}
+
+func (b *GrowingBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.data = append(b.data, p...)
+	return len(p), nil
+}
+
+func (b *GrowingBuffer) Read(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if len(b.data) == 0 {
+		return 0, io.EOF
+	}
+	n := copy(p, b.data)
+	b.data = b.data[n:]
+	return n, nil
+}
+
+func TestFollow(t *testing.T) {
+	buffer := &GrowingBuffer{}
+	buffer.Write([]byte("person_status\nactive\n"))
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	csvReader := typedcsv.NewReader[MarshalTextTestRecord](csv.NewReader(typedcsv.Follow(ctx, buffer, time.Millisecond)))
+	err := csvReader.ReadHeader()
+	if err != nil {
+		t.Fatal(err)
+	}
+	record, err := csvReader.ReadRecord()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if record.PersonStatus != PersonStatusActive {
+		t.Fatalf("Expected %v, got %v", PersonStatusActive, record.PersonStatus)
+	}
+
+	go func() {
+		buffer.Write([]byte("inac"))
+		time.Sleep(10 * time.Millisecond)
+		buffer.Write([]byte("tive\n"))
+	}()
+	record, err = csvReader.ReadRecord()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if record.PersonStatus != PersonStatusInactive {
+		t.Fatalf("Expected %v, got %v", PersonStatusInactive, record.PersonStatus)
+	}
+
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		cancel()
+	}()
+	_, err = csvReader.ReadRecord()
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("Expected %v, got %v", context.Canceled, err)
+	}
+}
@@ -0,0 +1,225 @@
+package typedcsv
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"iter"
+	"mime"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// A Format is an export format of records, as chosen by NegotiateFormat for HTTP APIs.
+type Format string
+
+const (
+	// FormatCSV is comma-separated values with a header, written by TypedCSVWriter.
+	FormatCSV Format = "csv"
+	// FormatTSV is tab-separated values with a header, written by TypedCSVWriter.
+	FormatTSV Format = "tsv"
+	// FormatJSONL is one JSON object per line, keyed by the column names.
+	FormatJSONL Format = "jsonl"
+	// FormatXLSX is an Excel workbook with one sheet, whose first row is the header.
+	FormatXLSX Format = "xlsx"
+)
+
+var formatContentTypes = map[Format]string{
+	FormatCSV:   "text/csv; charset=utf-8",
+	FormatTSV:   "text/tab-separated-values; charset=utf-8",
+	FormatJSONL: "application/jsonl",
+	FormatXLSX:  "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+}
+
+// formatMediaTypes maps the media types accepted by NegotiateFormat to their formats.
+var formatMediaTypes = map[string]Format{
+	"text/csv":                  FormatCSV,
+	"application/csv":           FormatCSV,
+	"text/tab-separated-values": FormatTSV,
+	"application/jsonl":         FormatJSONL,
+	"application/x-ndjson":      FormatJSONL,
+	"application/x-jsonlines":   FormatJSONL,
+	"application/vnd.openxmlformats-officedocument.spreadsheetml.sheet": FormatXLSX,
+	"text/*": FormatCSV,
+	"*/*":    FormatCSV,
+}
+
+// ContentType returns the media type of the format, to be used as the Content-Type header of a response.
+func (f Format) ContentType() string {
+	return formatContentTypes[f]
+}
+
+// Extension returns the file name extension of the format, such as ".csv".
+func (f Format) Extension() string {
+	return "." + string(f)
+}
+
+// NegotiateFormat chooses the export format of a response from the value of a query parameter, such as "tsv",
+// which takes precedence if it names a format, or else from the Accept header of the request,
+// honoring the quality values of its media ranges. It returns FormatCSV if both are empty, and false
+// if the query parameter names an unknown format or the Accept header accepts none of the formats.
+func NegotiateFormat(accept, query string) (Format, bool) {
+	if query != "" {
+		format := Format(strings.ToLower(query))
+		_, ok := formatContentTypes[format]
+		return format, ok
+	}
+	if strings.TrimSpace(accept) == "" {
+		return FormatCSV, true
+	}
+	type mediaRange struct {
+		format  Format
+		quality float64
+	}
+	var ranges []mediaRange
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+		format, ok := formatMediaTypes[mediaType]
+		if !ok {
+			continue
+		}
+		quality := 1.0
+		if q, ok := params["q"]; ok {
+			quality, err = strconv.ParseFloat(q, 64)
+			if err != nil {
+				continue
+			}
+		}
+		if quality > 0 {
+			ranges = append(ranges, mediaRange{format, quality})
+		}
+	}
+	if len(ranges) == 0 {
+		return "", false
+	}
+	sort.SliceStable(ranges, func(i, j int) bool {
+		return ranges[i].quality > ranges[j].quality
+	})
+	return ranges[0].format, true
+}
+
+// Export writes the records to w in the given format, with the column names and the formatted values of the fields
+// given by the tags and options, as written by TypedCSVWriter, including the values generated for the "default_func" tag,
+// the order of the columns of positioned fields and the row hash column. In JSONL and XLSX, the values of number and boolean fields
+// are written as numbers and booleans when they can be parsed as such, and the null values of pointer fields as null and empty cells.
+// To export a slice, use slices.Values.
+func Export[T any](w io.Writer, format Format, records iter.Seq[T], opts ...Option) error {
+	codec := NewCodec[T](opts...)
+	switch format {
+	case FormatCSV, FormatTSV:
+		csvWriter := csv.NewWriter(w)
+		if format == FormatTSV {
+			csvWriter.Comma = '\t'
+		}
+		writer := codec.NewWriter(csvWriter)
+		if err := writer.WriteHeader(); err != nil {
+			return err
+		}
+		for record := range records {
+			if err := writer.WriteRecord(record); err != nil {
+				return err
+			}
+		}
+		return writer.Close()
+	case FormatJSONL:
+		return exportJSONL(w, codec, records)
+	case FormatXLSX:
+		return exportXLSX(w, codec, records)
+	}
+	return fmt.Errorf("typedcsv: unknown format %q", format)
+}
+
+// A cellKind is the kind of value of a column in JSONL and XLSX exports.
+type cellKind int
+
+const (
+	cellString cellKind = iota
+	cellNumber
+	cellBoolean
+	cellNull
+)
+
+// cellKinds returns the kind of each value of a row returned by fileRow, whose columns hold the given fields.
+func (c *Codec[T]) cellKinds(fields []int, values []string, kinds []cellKind) {
+	for i, value := range values {
+		kinds[i] = cellString
+		if fields[i] < 0 {
+			continue
+		}
+		f := &c.fields[fields[i]]
+		switch {
+		case f.pointer && f.hasNull && value == f.null:
+			kinds[i] = cellNull
+		case f.marshaler || f.slice || f.nested != nil || f.bytes != nil:
+		case jsonSchemaType(f.typ) == "boolean":
+			if _, err := strconv.ParseBool(value); err == nil {
+				kinds[i] = cellBoolean
+			}
+		case jsonSchemaType(f.typ) == "integer" || jsonSchemaType(f.typ) == "number":
+			if _, err := strconv.ParseFloat(value, 64); err == nil && json.Valid([]byte(value)) {
+				kinds[i] = cellNumber
+			}
+		}
+	}
+}
+
+func exportJSONL[T any](w io.Writer, codec *Codec[T], records iter.Seq[T]) error {
+	if codec.typeErr != nil {
+		return codec.typeErr
+	}
+	buffered := bufio.NewWriter(w)
+	header, fields := codec.fileHeader(), codec.fileFields()
+	kinds := make([]cellKind, len(header))
+	var line bytes.Buffer
+	encoder := json.NewEncoder(&line)
+	encoder.SetEscapeHTML(false)
+	writeString := func(s string) {
+		encoder.Encode(s)
+		line.Truncate(line.Len() - 1)
+	}
+	for record := range records {
+		values, err := codec.fileRow(record)
+		if err != nil {
+			return err
+		}
+		codec.cellKinds(fields, values, kinds)
+		line.Reset()
+		line.WriteByte('{')
+		first := true
+		for i, value := range values {
+			// The empty columns between positioned fields have no name, so they are not keys of the object.
+			if header[i] == "" && fields[i] < 0 {
+				continue
+			}
+			if !first {
+				line.WriteByte(',')
+			}
+			first = false
+			writeString(header[i])
+			line.WriteByte(':')
+			switch kinds[i] {
+			case cellNull:
+				line.WriteString("null")
+			case cellNumber:
+				line.WriteString(value)
+			case cellBoolean:
+				b, _ := strconv.ParseBool(value)
+				line.WriteString(strconv.FormatBool(b))
+			default:
+				writeString(value)
+			}
+		}
+		line.WriteString("}\n")
+		if _, err := buffered.Write(line.Bytes()); err != nil {
+			return err
+		}
+	}
+	return buffered.Flush()
+}
@@ -0,0 +1,63 @@
+package typedcsv
+
+import "strings"
+
+// scanFormat converts a fmt print format to the format scanning the values it prints with fmt.Sscanf.
+// Flags, widths and precisions are removed, as fmt.Sscanf does not support precisions and limits
+// the length of the input with widths. The literal text and the verbs are kept.
+// If the format is a single verb with a width, such as "%02x", the width is also returned,
+// so that values joined without a separator can be split.
+func scanFormat(format string) (string, int) {
+	var builder strings.Builder
+	verbs, width, literal := 0, 0, false
+	for i := 0; i < len(format); i++ {
+		if format[i] != '%' {
+			builder.WriteByte(format[i])
+			literal = true
+			continue
+		}
+		i++
+		for i < len(format) && strings.IndexByte("+-# 0", format[i]) >= 0 {
+			i++
+		}
+		w := 0
+		for ; i < len(format) && format[i] >= '0' && format[i] <= '9'; i++ {
+			w = w*10 + int(format[i]-'0')
+		}
+		if i < len(format) && format[i] == '.' {
+			i++
+			for i < len(format) && format[i] >= '0' && format[i] <= '9' {
+				i++
+			}
+		}
+		if i == len(format) {
+			builder.WriteByte('%')
+			break
+		}
+		builder.WriteByte('%')
+		builder.WriteByte(format[i])
+		if format[i] == '%' {
+			literal = true
+			continue
+		}
+		verbs++
+		width = w
+	}
+	if verbs != 1 || literal {
+		width = 0
+	}
+	return builder.String(), width
+}
+
+// splitWidth splits value into items of the given width. The last item may be shorter.
+func splitWidth(value string, width int) []string {
+	items := make([]string, 0, (len(value)+width-1)/width)
+	for len(value) > width {
+		items = append(items, value[:width])
+		value = value[width:]
+	}
+	if value != "" {
+		items = append(items, value)
+	}
+	return items
+}
@@ -0,0 +1,99 @@
+package typedcsv_test
+
+import (
+	"bytes"
+	"encoding/csv"
+	"reflect"
+	"testing"
+
+	"github.com/hoshiumiarata/typedcsv"
+)
+
+func TestFormatRoundTrip(t *testing.T) {
+	buf := bytes.Buffer{}
+	csvWriter := typedcsv.NewWriter[FormatTestRecord](csv.NewWriter(&buf))
+	records := []FormatTestRecord{{Percentage: 12.5, HexSlice: []uint8{0x0a, 0xff, 0x00}}, {Percentage: -1}}
+	err := csvWriter.WriteAll(records)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := "percentage,hex\n12.50,0aff00\n-1.00,\n"
+	if buf.String() != expected {
+		t.Fatalf("Expected %q, got %q", expected, buf.String())
+	}
+
+	csvReader := typedcsv.NewReader[FormatTestRecord](csv.NewReader(&buf))
+	err = csvReader.ReadHeader()
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := csvReader.ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	records[1].HexSlice = []uint8{}
+	for i := range records {
+		if !reflect.DeepEqual(*got[i], records[i]) {
+			t.Fatalf("Expected %v, got %v", records[i], *got[i])
+		}
+	}
+}
+
+type ParseTagTestRecord struct {
+	Price  float64 `csv:"price" format:"$%.2f"`
+	Octal  int     `csv:"octal" format:"%#o" parse:"0%o"`
+	Ratio  int     `csv:"ratio" format:"%d%%"`
+	Labels []int   `csv:"labels" format:"#%d" separator:";"`
+}
+
+func TestParseTag(t *testing.T) {
+	reader := bytes.NewBufferString("price,octal,ratio,labels\n$3.50,0755,42%,#1;#2\n")
+	csvReader := typedcsv.NewReader[ParseTagTestRecord](csv.NewReader(reader))
+	err := csvReader.ReadHeader()
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := csvReader.ReadRecord()
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := ParseTagTestRecord{Price: 3.5, Octal: 0755, Ratio: 42, Labels: []int{1, 2}}
+	if !reflect.DeepEqual(*got, expected) {
+		t.Fatalf("Expected %v, got %v", expected, *got)
+	}
+
+	reader = bytes.NewBufferString("price,octal,ratio,labels\n3.50,0755,42%,#1\n")
+	csvReader = typedcsv.NewReader[ParseTagTestRecord](csv.NewReader(reader))
+	_ = csvReader.ReadHeader()
+	_, err = csvReader.ReadRecord()
+	if err == nil {
+		t.Fatal("Expected an error")
+	}
+}
+
+func TestParseTagLeftover(t *testing.T) {
+	for _, row := range []string{"$3.50abc,0755,42%,#1", "$3.50,0755x,42%,#1", "$3.50,0755,42%%,#1", "$3.50,0755,42%,#1x"} {
+		reader := bytes.NewBufferString("price,octal,ratio,labels\n" + row + "\n")
+		csvReader := typedcsv.NewReader[ParseTagTestRecord](csv.NewReader(reader))
+		if err := csvReader.ReadHeader(); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := csvReader.ReadRecord(); err == nil {
+			t.Fatalf("Expected an error for %q, got nil", row)
+		}
+	}
+
+	reader := bytes.NewBufferString("price,octal,ratio,labels\n$3.50 ,0755,42% ,#1\n")
+	csvReader := typedcsv.NewReader[ParseTagTestRecord](csv.NewReader(reader))
+	if err := csvReader.ReadHeader(); err != nil {
+		t.Fatal(err)
+	}
+	got, err := csvReader.ReadRecord()
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := ParseTagTestRecord{Price: 3.5, Octal: 0755, Ratio: 42, Labels: []int{1}}
+	if !reflect.DeepEqual(*got, expected) {
+		t.Fatalf("Expected %v, got %v", expected, *got)
+	}
+}
@@ -0,0 +1,139 @@
+package typedcsv_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"crypto/sha256"
+	"encoding/csv"
+	"io"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/hoshiumiarata/typedcsv"
+)
+
+func TestNegotiateFormat(t *testing.T) {
+	tests := []struct {
+		accept, query string
+		expected      typedcsv.Format
+		ok            bool
+	}{
+		{"", "", typedcsv.FormatCSV, true},
+		{"", "TSV", typedcsv.FormatTSV, true},
+		{"text/csv", "xlsx", typedcsv.FormatXLSX, true},
+		{"", "pdf", "pdf", false},
+		{"application/x-ndjson", "", typedcsv.FormatJSONL, true},
+		{"text/csv;q=0.5, application/vnd.openxmlformats-officedocument.spreadsheetml.sheet", "", typedcsv.FormatXLSX, true},
+		{"text/html, */*;q=0.1", "", typedcsv.FormatCSV, true},
+		{"application/pdf, text/csv;q=0", "", "", false},
+	}
+	for _, test := range tests {
+		format, ok := typedcsv.NegotiateFormat(test.accept, test.query)
+		if format != test.expected || ok != test.ok {
+			t.Fatalf("%q, %q: Expected %v, %v, got %v, %v", test.accept, test.query, test.expected, test.ok, format, ok)
+		}
+	}
+	if contentType := typedcsv.FormatTSV.ContentType(); contentType != "text/tab-separated-values; charset=utf-8" {
+		t.Fatalf("Expected the TSV media type, got %v", contentType)
+	}
+}
+
+type ExportTestRecord struct {
+	Name   string   `csv:"name"`
+	Score  *float64 `csv:"score" null:""`
+	Active bool     `csv:"active"`
+	Code   Digits   `csv:"code"`
+}
+
+type Digits = typedcsv.Digits
+
+func exportTestRecords() []ExportTestRecord {
+	score := 1.5
+	return []ExportTestRecord{{Name: "a <b>", Score: &score, Active: true, Code: "007"}, {Name: "c\td", Code: "1"}}
+}
+
+func TestExport(t *testing.T) {
+	tests := map[typedcsv.Format]string{
+		typedcsv.FormatCSV: "name,score,active,code\na <b>,1.5,true,007\nc\td,,false,1\n",
+		typedcsv.FormatTSV: "name\tscore\tactive\tcode\na <b>\t1.5\ttrue\t007\n\"c\td\"\t\tfalse\t1\n",
+		typedcsv.FormatJSONL: `{"name":"a <b>","score":1.5,"active":true,"code":"007"}` + "\n" +
+			`{"name":"c\td","score":null,"active":false,"code":"1"}` + "\n",
+	}
+	for format, expected := range tests {
+		var buffer bytes.Buffer
+		if err := typedcsv.Export(&buffer, format, slices.Values(exportTestRecords())); err != nil {
+			t.Fatal(err)
+		}
+		if buffer.String() != expected {
+			t.Fatalf("%v: Expected %q, got %q", format, expected, buffer.String())
+		}
+	}
+}
+
+type ExportLayoutTestRecord struct {
+	Name string `csv:"name" index:"2"`
+	ID   string `csv:"id" index:"0" default_func:"export_id"`
+}
+
+func TestExportSameValues(t *testing.T) {
+	opts := []typedcsv.Option{
+		typedcsv.WithDefaultFunc("export_id", func() any { return "x" }),
+		typedcsv.WithRowHash("row_hash", sha256.New),
+	}
+	records := []ExportLayoutTestRecord{{Name: "John"}}
+	var csvBuffer, jsonlBuffer bytes.Buffer
+	if err := typedcsv.Export(&csvBuffer, typedcsv.FormatCSV, slices.Values(records), opts...); err != nil {
+		t.Fatal(err)
+	}
+	if err := typedcsv.Export(&jsonlBuffer, typedcsv.FormatJSONL, slices.Values(records), opts...); err != nil {
+		t.Fatal(err)
+	}
+	rows, err := csv.NewReader(&csvBuffer).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 2 || len(rows[1]) != 4 || rows[1][0] != "x" || rows[1][2] != "John" {
+		t.Fatalf("Expected the id, an empty column, the name and the hash, got %q", rows)
+	}
+	expected := `{"id":"x","name":"John","row_hash":"` + rows[1][3] + `"}` + "\n"
+	if jsonlBuffer.String() != expected {
+		t.Fatalf("Expected %q, got %q", expected, jsonlBuffer.String())
+	}
+}
+
+func TestExportXLSX(t *testing.T) {
+	var buffer bytes.Buffer
+	if err := typedcsv.Export(&buffer, typedcsv.FormatXLSX, slices.Values(exportTestRecords())); err != nil {
+		t.Fatal(err)
+	}
+	archive, err := zip.NewReader(bytes.NewReader(buffer.Bytes()), int64(buffer.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var sheet string
+	for _, file := range archive.File {
+		if file.Name == "xl/worksheets/sheet1.xml" {
+			reader, err := file.Open()
+			if err != nil {
+				t.Fatal(err)
+			}
+			data, _ := io.ReadAll(reader)
+			sheet = string(data)
+		}
+	}
+	for _, expected := range []string{
+		`<c r="A2" t="inlineStr"><is><t xml:space="preserve">a &lt;b&gt;</t></is></c>`,
+		`<c r="B2"><v>1.5</v></c>`,
+		`<c r="C2" t="b"><v>1</v></c>`,
+		`<c r="D2" t="inlineStr"><is><t xml:space="preserve">007</t></is></c>`,
+		`<row r="3"><c r="A3" t="inlineStr">`,
+	} {
+		if !strings.Contains(sheet, expected) {
+			t.Fatalf("Expected %s in %s", expected, sheet)
+		}
+	}
+	if len(archive.File) != 5 {
+		t.Fatalf("Expected 5 parts, got %v", len(archive.File))
+	}
+}
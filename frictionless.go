@@ -0,0 +1,314 @@
+package typedcsv
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+)
+
+// A TableSchema is a Frictionless Data Table Schema (https://specs.frictionlessdata.io/table-schema/).
+type TableSchema struct {
+	Fields        []TableSchemaField `json:"fields"`
+	MissingValues []string           `json:"missingValues,omitempty"`
+	PrimaryKey    any                `json:"primaryKey,omitempty"`
+}
+
+// A TableSchemaField describes a field of a TableSchema.
+type TableSchemaField struct {
+	Name        string                 `json:"name"`
+	Title       string                 `json:"title,omitempty"`
+	Description string                 `json:"description,omitempty"`
+	Unit        string                 `json:"unit,omitempty"`
+	Type        string                 `json:"type,omitempty"`
+	Format      string                 `json:"format,omitempty"`
+	Constraints *TableSchemaConstraint `json:"constraints,omitempty"`
+}
+
+// A TableSchemaConstraint holds the constraints of a TableSchemaField.
+type TableSchemaConstraint struct {
+	Required  bool     `json:"required,omitempty"`
+	Enum      []any    `json:"enum,omitempty"`
+	Pattern   string   `json:"pattern,omitempty"`
+	Minimum   *float64 `json:"minimum,omitempty"`
+	Maximum   *float64 `json:"maximum,omitempty"`
+	MinLength *float64 `json:"minLength,omitempty"`
+	MaxLength *float64 `json:"maxLength,omitempty"`
+}
+
+// TableSchemaOf returns a Frictionless Table Schema describing the CSV files written by TypedCSVWriter for T.
+// The missing values are the empty string and the values of the "null" tags.
+// The descriptions and units of the fields are the "desc" and "unit" tag values.
+func TableSchemaOf[T any](opts ...Option) ([]byte, error) {
+	codec := NewCodec[T](opts...)
+	if codec.typeErr != nil {
+		return nil, codec.typeErr
+	}
+	schema := TableSchema{MissingValues: []string{""}}
+	for i := range codec.fields {
+		field := &codec.fields[i]
+		tableField := TableSchemaField{Name: field.name, Description: field.description, Unit: field.unit}
+		tableField.Type, tableField.Format = field.tableSchemaType()
+		if field.enum != nil {
+			enum := make([]any, len(field.enum))
+			for i, value := range field.enum {
+				enum[i] = value
+			}
+			tableField.Constraints = &TableSchemaConstraint{Enum: enum}
+		}
+		if isUnsigned(field.typ) && tableField.Type == "integer" {
+			tableField.Constraints = &TableSchemaConstraint{Minimum: new(float64)}
+		}
+		if field.hasNull && !containsString(schema.MissingValues, field.null) {
+			schema.MissingValues = append(schema.MissingValues, field.null)
+		}
+		schema.Fields = append(schema.Fields, tableField)
+	}
+	return json.MarshalIndent(schema, "", "  ")
+}
+
+func (f *field) tableSchemaType() (string, string) {
+	switch {
+	case f.timeFormatted():
+		switch f.timeFormat {
+		case time.RFC3339:
+			return "datetime", ""
+		case time.DateOnly:
+			return "date", ""
+		case time.TimeOnly:
+			return "time", ""
+		}
+		format, ok := strftimeFormat(f.timeFormat)
+		if !ok {
+			return "string", ""
+		}
+		return tableSchemaTimeType(f.timeFormat), format
+	case f.time && f.marshaler:
+		return "datetime", "any"
+	case f.marshaler || f.hasFormat:
+		return "string", ""
+	case f.slice:
+		return "string", ""
+	}
+	switch jsonSchemaType(f.typ) {
+	case "integer":
+		return "integer", ""
+	case "number":
+		return "number", ""
+	case "boolean":
+		return "boolean", ""
+	default:
+		return "string", ""
+	}
+}
+
+func tableSchemaTimeType(layout string) string {
+	switch csvwTimeBase(layout) {
+	case "dateTime":
+		return "datetime"
+	case "time":
+		return "time"
+	default:
+		return "date"
+	}
+}
+
+// LoadTableSchema reads a Frictionless Table Schema from r and converts it to a compiled Schema
+// that can be used with DynamicReader and DynamicWriter.
+// Only the first missing value is used as the null value of the columns.
+func LoadTableSchema(r io.Reader) (*Schema, error) {
+	var tableSchema TableSchema
+	err := json.NewDecoder(r).Decode(&tableSchema)
+	if err != nil {
+		return nil, err
+	}
+	return tableSchema.Schema()
+}
+
+// Schema converts the Table Schema to a compiled Schema.
+func (s *TableSchema) Schema() (*Schema, error) {
+	missingValues := s.MissingValues
+	if missingValues == nil {
+		missingValues = []string{""}
+	}
+	var schema Schema
+	for _, tableField := range s.Fields {
+		column := SchemaColumn{Name: tableField.Name}
+		if len(missingValues) > 0 {
+			column.Null = &missingValues[0]
+		}
+		switch tableField.Type {
+		case "integer":
+			column.Type = SchemaInt
+		case "number":
+			column.Type = SchemaFloat
+		case "boolean":
+			column.Type = SchemaBool
+		case "date", "time", "datetime":
+			column.Type = SchemaTime
+			layout, err := goTimeLayout(tableField.Type, tableField.Format)
+			if err != nil {
+				return nil, fmt.Errorf("typedcsv: table schema field '%s': %w", tableField.Name, err)
+			}
+			column.Format = layout
+		default:
+			column.Type = SchemaString
+		}
+		if constraints := tableField.Constraints; constraints != nil {
+			column.Required = constraints.Required
+			column.Pattern = constraints.Pattern
+			for _, value := range constraints.Enum {
+				column.Enum = append(column.Enum, fmt.Sprint(value))
+			}
+			column.Min, column.Max = constraints.Minimum, constraints.Maximum
+			if column.Type == SchemaString {
+				column.Min, column.Max = constraints.MinLength, constraints.MaxLength
+			}
+		}
+		schema.Columns = append(schema.Columns, column)
+	}
+	err := schema.Compile()
+	if err != nil {
+		return nil, err
+	}
+	return &schema, nil
+}
+
+// Validate reads all the records of the CSV file, including its header, and checks them against the schema.
+// It does not stop at the first error: it returns all the errors joined with errors.Join, those of the header first,
+// such as missing required columns, then for each record its malformed row or each of its invalid values,
+// prefixed with the record number. It stops only on an error of the underlying reader other than a csv.ParseError.
+func (s *Schema) Validate(reader *csv.Reader) error {
+	header, err := reader.Read()
+	if err != nil {
+		return err
+	}
+	indices := make(map[string]int)
+	for i, column := range header {
+		if _, ok := indices[column]; !ok {
+			indices[column] = i
+		}
+	}
+	var errs []error
+	for _, column := range s.Columns {
+		if _, ok := indices[column.Name]; !ok && column.Required {
+			errs = append(errs, FieldParseError{Field: column.Name, NestedError: fmt.Errorf("%w: column is missing", ErrValidation)})
+		}
+	}
+	for n := 1; ; n++ {
+		values, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		var parseError *csv.ParseError
+		if errors.As(err, &parseError) {
+			errs = append(errs, fmt.Errorf("record %d: %w", n, err))
+			continue
+		}
+		if err != nil {
+			return errors.Join(append(errs, err)...)
+		}
+		for i := range s.Columns {
+			column := &s.Columns[i]
+			index, ok := indices[column.Name]
+			if !ok || index >= len(values) {
+				continue
+			}
+			if _, err := column.parse(values[index]); err != nil {
+				errs = append(errs, fmt.Errorf("record %d: %w", n, FieldParseError{Field: column.Name, NestedError: err}))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func goTimeLayout(fieldType, format string) (string, error) {
+	if format == "" || format == "default" || format == "any" {
+		switch fieldType {
+		case "date":
+			return time.DateOnly, nil
+		case "time":
+			return time.TimeOnly, nil
+		default:
+			return time.RFC3339, nil
+		}
+	}
+	return strptimeLayout(format)
+}
+
+// strftimeDirectives maps strftime directives to Go layout elements.
+var strftimeDirectives = map[byte]string{
+	'Y': "2006", 'y': "06", 'm': "01", 'd': "02", 'e': "_2", 'H': "15", 'I': "03", 'M': "04", 'S': "05",
+	'p': "PM", 'b': "Jan", 'B': "January", 'a': "Mon", 'A': "Monday", 'z': "-0700", 'Z': "MST", 'f': "000000", 'j': "002",
+}
+
+func strptimeLayout(format string) (string, error) {
+	var builder strings.Builder
+	for i := 0; i < len(format); i++ {
+		if format[i] != '%' {
+			builder.WriteByte(format[i])
+			continue
+		}
+		i++
+		if i == len(format) {
+			return "", fmt.Errorf("unterminated directive in format '%s'", format)
+		}
+		if format[i] == '%' {
+			builder.WriteByte('%')
+			continue
+		}
+		element, ok := strftimeDirectives[format[i]]
+		if !ok {
+			return "", fmt.Errorf("unsupported directive '%%%c' in format '%s'", format[i], format)
+		}
+		builder.WriteString(element)
+	}
+	return builder.String(), nil
+}
+
+func strftimeFormat(layout string) (string, bool) {
+	elements := make([]struct{ layout, directive string }, 0, len(strftimeDirectives))
+	for directive, element := range strftimeDirectives {
+		elements = append(elements, struct{ layout, directive string }{element, "%" + string(directive)})
+	}
+	// Longer elements first, so that "January" is not matched as "Jan".
+	sort.Slice(elements, func(i, j int) bool {
+		if len(elements[i].layout) != len(elements[j].layout) {
+			return len(elements[i].layout) > len(elements[j].layout)
+		}
+		return elements[i].layout < elements[j].layout
+	})
+	var builder strings.Builder
+next:
+	for len(layout) > 0 {
+		for _, element := range elements {
+			if strings.HasPrefix(layout, element.layout) {
+				builder.WriteString(element.directive)
+				layout = layout[len(element.layout):]
+				continue next
+			}
+		}
+		if layout[0] >= '0' && layout[0] <= '9' {
+			return "", false
+		}
+		if layout[0] == '%' {
+			builder.WriteByte('%')
+		}
+		builder.WriteByte(layout[0])
+		layout = layout[1:]
+	}
+	return builder.String(), true
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
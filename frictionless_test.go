@@ -0,0 +1,81 @@
+package typedcsv_test
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hoshiumiarata/typedcsv"
+)
+
+type TableSchemaTestRecord struct {
+	Name     string     `csv:"name"`
+	Level    string     `csv:"level" enum:"low|high"`
+	Age      uint8      `csv:"age"`
+	Birthday CustomTime `csv:"birthday" time_format:"02/01/2006"`
+	Optional *float64   `csv:"optional" null:"NULL"`
+}
+
+func TestTableSchemaOf(t *testing.T) {
+	data, err := typedcsv.TableSchemaOf[TableSchemaTestRecord]()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var schema map[string]any
+	err = json.Unmarshal(data, &schema)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := map[string]any{
+		"fields": []any{
+			map[string]any{"name": "name", "type": "string"},
+			map[string]any{"name": "level", "type": "string", "constraints": map[string]any{"enum": []any{"low", "high"}}},
+			map[string]any{"name": "age", "type": "integer", "constraints": map[string]any{"minimum": 0.0}},
+			map[string]any{"name": "birthday", "type": "date", "format": "%d/%m/%Y"},
+			map[string]any{"name": "optional", "type": "number"},
+		},
+		"missingValues": []any{"", "NULL"},
+	}
+	if !reflect.DeepEqual(schema, expected) {
+		t.Fatalf("Expected %v, got %v", expected, schema)
+	}
+}
+
+func TestLoadTableSchema(t *testing.T) {
+	schema, err := typedcsv.LoadTableSchema(strings.NewReader(`{
+		"fields": [
+			{"name": "name", "type": "string", "constraints": {"required": true, "maxLength": 4}},
+			{"name": "birthday", "type": "date", "format": "%d/%m/%Y"},
+			{"name": "age", "type": "integer", "constraints": {"minimum": 0}}
+		],
+		"missingValues": ["NA"]
+	}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = schema.Validate(csv.NewReader(strings.NewReader("name,birthday,age\nJohn,17/06/1970,55\nMary,NA,NA\n")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = schema.Validate(csv.NewReader(strings.NewReader("name,birthday,age\nJonathan,17/06/1970,55\nNA,1970-06-17,-1\nMary,18/07/1971,1\n")))
+	if !errors.Is(err, typedcsv.ErrValidation) {
+		t.Fatalf("Expected %v, got %v", typedcsv.ErrValidation, err)
+	}
+	expected := "record 1: typedcsv: error parsing field 'name': typedcsv: validation failed: length of value 'Jonathan' is greater than 4\n" +
+		"record 2: typedcsv: error parsing field 'name': typedcsv: validation failed: value is required\n" +
+		"record 2: typedcsv: error parsing field 'birthday': parsing time \"1970-06-17\" as \"02/01/2006\": cannot parse \"70-06-17\" as \"/\"\n" +
+		"record 2: typedcsv: error parsing field 'age': typedcsv: validation failed: value '-1' is less than 0"
+	if err.Error() != expected {
+		t.Fatalf("Expected %q, got %q", expected, err.Error())
+	}
+
+	err = schema.Validate(csv.NewReader(strings.NewReader("birthday\n17/06/1970\n17/06/1970,x\n")))
+	expected = "typedcsv: error parsing field 'name': typedcsv: validation failed: column is missing\n" +
+		"record 2: record on line 3: wrong number of fields"
+	if err == nil || err.Error() != expected {
+		t.Fatalf("Expected %q, got %v", expected, err)
+	}
+}
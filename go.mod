@@ -1,3 +1,5 @@
 module github.com/hoshiumiarata/typedcsv
 
-go 1.18
+go 1.23
+
+require golang.org/x/text v0.22.0
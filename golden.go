@@ -0,0 +1,134 @@
+package typedcsv
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+)
+
+// A GoldenMismatchError reports the first difference between the output of a GoldenWriter and the golden stream.
+type GoldenMismatchError struct {
+	// Row is the 1-based line of the difference, counting the header.
+	Row int
+	// Column is the 1-based index of the field of the difference in the row.
+	Column int
+	// Offset is the byte offset of the difference.
+	Offset int64
+	// Expected and Got are the lines of the golden stream and of the output, up to the end of the line or of the data.
+	Expected string
+	Got      string
+}
+
+// Error returns the error message.
+func (e *GoldenMismatchError) Error() string {
+	return fmt.Sprintf("typedcsv: output differs from golden at row %d, column %d (byte %d): expected %q, got %q",
+		e.Row, e.Column, e.Offset, e.Expected, e.Got)
+}
+
+// A GoldenWriter is an io.Writer that compares the data written to it with a golden stream, instead of writing it.
+// Wrapped by a csv.Writer, it guards exporters against changes of byte-stable output:
+//
+//	golden := typedcsv.NewGoldenWriter(expected)
+//	writer := typedcsv.NewWriter[T](csv.NewWriter(golden))
+//	// write the records and flush
+//	err := golden.Close()
+//
+// The first difference is returned by Write, so it is also reported by TypedCSVWriter.Error, and by Close.
+type GoldenWriter struct {
+	// Comma is the field delimiter used to compute the column of a difference. It is set to ',' by NewGoldenWriter.
+	Comma byte
+
+	golden   *bufio.Reader
+	line     []byte
+	row      int
+	column   int
+	offset   int64
+	inQuotes bool
+	err      error
+}
+
+// NewGoldenWriter returns a new GoldenWriter comparing the data written to it with golden.
+func NewGoldenWriter(golden io.Reader) *GoldenWriter {
+	return &GoldenWriter{
+		Comma:  ',',
+		golden: bufio.NewReader(golden),
+		row:    1,
+		column: 1,
+	}
+}
+
+// Write compares p with the next bytes of the golden stream.
+// It returns a *GoldenMismatchError at the first difference, and for all the following calls.
+func (g *GoldenWriter) Write(p []byte) (int, error) {
+	if g.err != nil {
+		return 0, g.err
+	}
+	for i, b := range p {
+		expected, err := g.golden.ReadByte()
+		if err == io.EOF || (err == nil && expected != b) {
+			if err == nil {
+				g.golden.UnreadByte()
+			}
+			g.err = g.mismatch(p[i:])
+			return i, g.err
+		}
+		if err != nil {
+			g.err = err
+			return i, err
+		}
+		g.advance(b)
+	}
+	return len(p), nil
+}
+
+// Close reports the first difference, including missing output at the end of the golden stream.
+func (g *GoldenWriter) Close() error {
+	if g.err != nil {
+		return g.err
+	}
+	if _, err := g.golden.Peek(1); err == nil {
+		g.err = g.mismatch(nil)
+	} else if err != io.EOF {
+		g.err = err
+	}
+	return g.err
+}
+
+// advance updates the position after a matching byte.
+func (g *GoldenWriter) advance(b byte) {
+	g.offset++
+	g.line = append(g.line, b)
+	switch {
+	case b == '"':
+		g.inQuotes = !g.inQuotes
+	case g.inQuotes:
+	case b == g.Comma:
+		g.column++
+	case b == '\n':
+		g.row++
+		g.column = 1
+		g.line = g.line[:0]
+	}
+}
+
+// mismatch returns the error for a difference at the current position, where rest is the remaining output.
+func (g *GoldenWriter) mismatch(rest []byte) error {
+	expected, _ := g.golden.ReadSlice('\n')
+	return &GoldenMismatchError{
+		Row:      g.row,
+		Column:   g.column,
+		Offset:   g.offset,
+		Expected: string(g.line) + string(cutLine(expected)),
+		Got:      string(g.line) + string(cutLine(rest)),
+	}
+}
+
+// cutLine returns the line at the start of data, without its line ending.
+func cutLine(data []byte) []byte {
+	for i, b := range data {
+		if b == '\n' {
+			return trimLineEnding(data[:i+1])
+		}
+	}
+	return data
+}
@@ -0,0 +1,66 @@
+package typedcsv_test
+
+import (
+	"encoding/csv"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/hoshiumiarata/typedcsv"
+)
+
+func writeStatuses(golden *typedcsv.GoldenWriter, statuses ...PersonStatus) error {
+	csvWriter := typedcsv.NewWriter[MarshalTextTestRecord](csv.NewWriter(golden))
+	err := csvWriter.WriteHeader()
+	if err != nil {
+		return err
+	}
+	for _, status := range statuses {
+		err = csvWriter.WriteRecord(MarshalTextTestRecord{PersonStatus: status})
+		if err != nil {
+			return err
+		}
+	}
+	csvWriter.Flush()
+	if err := csvWriter.Error(); err != nil {
+		return err
+	}
+	return golden.Close()
+}
+
+func TestGoldenWriter(t *testing.T) {
+	expected := "person_status\nactive\ninactive\n"
+	err := writeStatuses(typedcsv.NewGoldenWriter(strings.NewReader(expected)), PersonStatusActive, PersonStatusInactive)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = writeStatuses(typedcsv.NewGoldenWriter(strings.NewReader(expected)), PersonStatusActive, PersonStatusActive)
+	var mismatch *typedcsv.GoldenMismatchError
+	if !errors.As(err, &mismatch) {
+		t.Fatalf("Expected %T, got %v", mismatch, err)
+	}
+	expectedError := `typedcsv: output differs from golden at row 3, column 1 (byte 21): expected "inactive", got "active"`
+	if err.Error() != expectedError {
+		t.Fatalf("Expected %q, got %q", expectedError, err.Error())
+	}
+
+	err = writeStatuses(typedcsv.NewGoldenWriter(strings.NewReader(expected)), PersonStatusActive)
+	if !errors.As(err, &mismatch) || mismatch.Row != 3 || mismatch.Expected != "inactive" || mismatch.Got != "" {
+		t.Fatalf("Expected a mismatch on missing row 3, got %v", err)
+	}
+
+	err = writeStatuses(typedcsv.NewGoldenWriter(strings.NewReader(expected)), PersonStatusActive, PersonStatusInactive, PersonStatusActive)
+	if !errors.As(err, &mismatch) || mismatch.Row != 4 || mismatch.Expected != "" || mismatch.Got != "active" {
+		t.Fatalf("Expected a mismatch on extra row 4, got %v", err)
+	}
+}
+
+func TestGoldenWriterColumn(t *testing.T) {
+	golden := typedcsv.NewGoldenWriter(strings.NewReader("a,\"b,c\",d\n"))
+	_, err := golden.Write([]byte("a,\"b,c\",e\n"))
+	var mismatch *typedcsv.GoldenMismatchError
+	if !errors.As(err, &mismatch) || mismatch.Row != 1 || mismatch.Column != 3 || mismatch.Offset != 8 {
+		t.Fatalf("Expected a mismatch at row 1, column 3, byte 8, got %v", err)
+	}
+}
@@ -0,0 +1,70 @@
+package typedcsv
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// An includeIf is the predicate given by the "include_if" tag value: a method of the struct declaring the field
+// that takes no arguments and returns a bool. For a field of an embedded struct or of a struct with a "prefix" tag,
+// it is a method of that struct, not of the record.
+type includeIf struct {
+	name    string
+	index   int
+	pointer bool
+	err     error
+}
+
+// resolveIncludeIf looks up the predicates of the fields of the struct type t on the structs declaring them.
+func resolveIncludeIf(fields []field, t reflect.Type) {
+	for i := range fields {
+		f := &fields[i]
+		if f.includeIf == nil {
+			continue
+		}
+		holder := t
+		for _, index := range f.index[:len(f.index)-1] {
+			holder = indirectType(holder.Field(index).Type)
+		}
+		method, ok := holder.MethodByName(f.includeIf.name)
+		if !ok {
+			method, ok = reflect.PointerTo(holder).MethodByName(f.includeIf.name)
+			f.includeIf.pointer = ok
+		}
+		switch {
+		case !ok:
+			f.includeIf.err = fmt.Errorf("type %v has no method %s", holder, f.includeIf.name)
+		case method.Type.NumIn() != 1 || method.Type.NumOut() != 1 || method.Type.Out(0).Kind() != reflect.Bool:
+			f.includeIf.err = fmt.Errorf("method %s of type %v must have the signature func() bool", f.includeIf.name, holder)
+		default:
+			f.includeIf.index = method.Index
+		}
+	}
+}
+
+// included reports whether the field is written for the struct value v, which holds the field.
+// Fields without an "include_if" tag value are always included, as are the fields of nil embedded pointers,
+// which are written as null.
+func (f *field) included(v reflect.Value) (bool, error) {
+	if f.includeIf == nil {
+		return true, nil
+	}
+	if f.includeIf.err != nil {
+		return false, FieldFormatError{Field: f.name, NestedError: f.includeIf.err}
+	}
+	for _, index := range f.index[:len(f.index)-1] {
+		v = v.Field(index)
+		if v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				return true, nil
+			}
+			v = v.Elem()
+		}
+	}
+	if f.includeIf.pointer {
+		pointer := reflect.New(v.Type())
+		pointer.Elem().Set(v)
+		v = pointer
+	}
+	return v.Method(f.includeIf.index).Call(nil)[0].Bool(), nil
+}
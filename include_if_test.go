@@ -0,0 +1,122 @@
+package typedcsv_test
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/hoshiumiarata/typedcsv"
+)
+
+type IncludeIfRecord struct {
+	Name    string `csv:"name"`
+	Version int    `csv:"version"`
+	Region  string `csv:"region" include_if:"HasRegion" null:"NULL"`
+	Score   int    `csv:"score" include_if:"Scored"`
+}
+
+func (r IncludeIfRecord) HasRegion() bool {
+	return r.Version >= 2
+}
+
+func (r *IncludeIfRecord) Scored() bool {
+	return r.Score > 0
+}
+
+func TestIncludeIf(t *testing.T) {
+	buf := bytes.Buffer{}
+	csvWriter := typedcsv.NewWriter[IncludeIfRecord](csv.NewWriter(&buf))
+	err := csvWriter.WriteAll([]IncludeIfRecord{
+		{Name: "old", Version: 1, Region: "eu", Score: 3},
+		{Name: "new", Version: 2, Region: "us"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := "name,version,region,score\nold,1,NULL,3\nnew,2,us,\n"
+	if buf.String() != expected {
+		t.Fatalf("Expected %q, got %q", expected, buf.String())
+	}
+}
+
+type InvalidIncludeIfRecord struct {
+	Name   string `csv:"name"`
+	Region string `csv:"region" include_if:"Missing"`
+	Score  int    `csv:"score" include_if:"Count"`
+}
+
+func (r InvalidIncludeIfRecord) Count() int {
+	return 0
+}
+
+func TestIncludeIfInvalid(t *testing.T) {
+	codec := typedcsv.NewCodec[InvalidIncludeIfRecord]()
+	var tagError typedcsv.TagError
+	if !errors.As(codec.Err(), &tagError) || tagError.Tag != "include_if" {
+		t.Fatalf("Expected an include_if TagError, got %v", codec.Err())
+	}
+	_, err := codec.EncodeRow(InvalidIncludeIfRecord{Name: "a"})
+	var formatError typedcsv.FieldFormatError
+	if !errors.As(err, &formatError) || formatError.Field != "region" {
+		t.Fatalf("Expected a FieldFormatError for region, got %v", err)
+	}
+}
+
+type IncludeIfAddress struct {
+	Country string `csv:"country"`
+	State   string `csv:"state" include_if:"HasState"`
+}
+
+func (a *IncludeIfAddress) HasState() bool {
+	return a.Country == "US"
+}
+
+type IncludeIfMeta struct {
+	Internal bool   `csv:"internal"`
+	Note     string `csv:"note" include_if:"Public"`
+}
+
+func (m IncludeIfMeta) Public() bool {
+	return !m.Internal
+}
+
+type IncludeIfNestedRecord struct {
+	Name string `csv:"name"`
+	*IncludeIfMeta
+	Address IncludeIfAddress `prefix:"address_"`
+}
+
+func TestIncludeIfDeclaringStruct(t *testing.T) {
+	codec := typedcsv.NewCodec[IncludeIfNestedRecord]()
+	if err := codec.Err(); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		record   IncludeIfNestedRecord
+		expected []string
+	}{
+		{
+			IncludeIfNestedRecord{Name: "a", IncludeIfMeta: &IncludeIfMeta{Note: "x"}, Address: IncludeIfAddress{Country: "US", State: "CA"}},
+			[]string{"a", "false", "x", "US", "CA"},
+		},
+		{
+			IncludeIfNestedRecord{Name: "b", IncludeIfMeta: &IncludeIfMeta{Internal: true, Note: "y"}, Address: IncludeIfAddress{Country: "FR", State: "IDF"}},
+			[]string{"b", "true", "", "FR", ""},
+		},
+		{
+			IncludeIfNestedRecord{Name: "c"},
+			[]string{"c", "", "", "", ""},
+		},
+	}
+	for _, test := range tests {
+		row, err := codec.EncodeRow(test.record)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !slices.Equal(row, test.expected) {
+			t.Fatalf("Expected %q, got %q", test.expected, row)
+		}
+	}
+}
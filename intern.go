@@ -0,0 +1,63 @@
+package typedcsv
+
+import (
+	"reflect"
+	"strings"
+)
+
+// WithInterning makes a TypedCSVReader intern the values of string fields per column:
+// equal values read in the same column share one allocation, instead of each record holding its own copy.
+// It greatly reduces the memory used by the records of low-cardinality columns, such as statuses or country codes.
+//
+// maxValues bounds the number of distinct values kept per column, so that high-cardinality columns
+// do not grow the tables without limit. Values beyond the limit are not interned. If maxValues is 0, there is no limit.
+// The tables are kept until the next call to ReadHeader or Reset.
+func WithInterning(maxValues int) Option {
+	return func(o *options) {
+		o.intern = true
+		o.internLimit = maxValues
+	}
+}
+
+// An interner holds the interned values of each field.
+type interner struct {
+	limit  int
+	fields []map[string]string
+}
+
+// newInterner returns a new interner for the given number of fields, or nil if interning is disabled.
+func newInterner(o *options, fields int) *interner {
+	if !o.intern {
+		return nil
+	}
+	return &interner{limit: o.internLimit, fields: make([]map[string]string, fields)}
+}
+
+// internField replaces the value of a string field or a string pointer field with its interned value.
+func (in *interner) internField(i int, fieldValue reflect.Value) {
+	if fieldValue.Kind() == reflect.Ptr {
+		if fieldValue.IsNil() {
+			return
+		}
+		fieldValue = fieldValue.Elem()
+	}
+	if fieldValue.Kind() != reflect.String {
+		return
+	}
+	value := fieldValue.String()
+	values := in.fields[i]
+	if values == nil {
+		values = make(map[string]string)
+		in.fields[i] = values
+	}
+	if interned, ok := values[value]; ok {
+		fieldValue.SetString(interned)
+		return
+	}
+	if in.limit == 0 || len(values) < in.limit {
+		// The value may share the memory of the whole row, which the table would otherwise keep.
+		interned := strings.Clone(value)
+		values[interned] = interned
+		fieldValue.SetString(interned)
+	}
+}
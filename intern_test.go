@@ -0,0 +1,61 @@
+package typedcsv_test
+
+import (
+	"bytes"
+	"encoding/csv"
+	"testing"
+	"unsafe"
+
+	"github.com/hoshiumiarata/typedcsv"
+)
+
+type InternTestRecord struct {
+	Status  string  `csv:"status"`
+	Country *string `csv:"country"`
+	ID      string  `csv:"id"`
+}
+
+func TestWithInterning(t *testing.T) {
+	reader := bytes.Buffer{}
+	reader.WriteString("status,country,id\nactive,JP,1\nactive,JP,2\nactive,US,3\n")
+	csvReader := typedcsv.NewReader[InternTestRecord](csv.NewReader(&reader), typedcsv.WithInterning(2))
+	err := csvReader.ReadHeader()
+	if err != nil {
+		t.Fatal(err)
+	}
+	records, err := csvReader.ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if records[2].Status != "active" || *records[2].Country != "US" || records[2].ID != "3" {
+		t.Fatalf("Expected the values of the last row, got %+v", records[2])
+	}
+	if unsafe.StringData(records[0].Status) != unsafe.StringData(records[2].Status) {
+		t.Fatal("Expected the status values to share one allocation")
+	}
+	if unsafe.StringData(*records[0].Country) != unsafe.StringData(*records[1].Country) {
+		t.Fatal("Expected the country values to share one allocation")
+	}
+	if unsafe.StringData(records[0].Status) == unsafe.StringData(records[0].ID) {
+		t.Fatal("Expected different values not to be shared")
+	}
+}
+
+func TestWithInterningCopiesValues(t *testing.T) {
+	reader := bytes.Buffer{}
+	reader.WriteString("status,country,id\nactive subscription,United States of America,1\n")
+	csvReader := typedcsv.NewReader[InternTestRecord](csv.NewReader(&reader), typedcsv.WithInterning(0))
+	err := csvReader.ReadHeader()
+	if err != nil {
+		t.Fatal(err)
+	}
+	record, err := csvReader.ReadRecord()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The values read by csv.Reader share the memory of the row, so the interned values would follow each other.
+	end := unsafe.Add(unsafe.Pointer(unsafe.StringData(record.Status)), len(record.Status))
+	if end == unsafe.Pointer(unsafe.StringData(*record.Country)) {
+		t.Fatal("Expected the interned values not to share the memory of the row")
+	}
+}
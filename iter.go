@@ -0,0 +1,34 @@
+package typedcsv
+
+import (
+	"io"
+	"iter"
+)
+
+// All returns an iterator over the remaining records, to be used in a range loop:
+//
+//	for record, err := range reader.All() {
+//		if err != nil {
+//			return err
+//		}
+//		...
+//	}
+//
+// It yields each record with a nil error. On an error other than io.EOF, it yields a nil record with the error and stops;
+// the iteration ends without error at the end of the input. Like ReadRecord, it requires ReadHeader to be called first,
+// unless the fields are mapped by position. When the loop ends, including on break, the resources owned by the reader,
+// such as the file opened by OpenReader, are closed.
+func (r *TypedCSVReader[T]) All() iter.Seq2[*T, error] {
+	return func(yield func(*T, error) bool) {
+		defer r.Close()
+		for {
+			record, err := r.ReadRecord()
+			if err == io.EOF {
+				return
+			}
+			if !yield(record, err) || err != nil {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package typedcsv_test
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hoshiumiarata/typedcsv"
+)
+
+func TestAll(t *testing.T) {
+	csvReader := typedcsv.NewReader[AppendTestRecord](csv.NewReader(bytes.NewBufferString("id,name\n1,John\n2,Jane\nx,Jim\n3,Joe\n")))
+	if err := csvReader.ReadHeader(); err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	var lastErr error
+	for record, err := range csvReader.All() {
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		names = append(names, record.Name)
+	}
+	var fieldParseError typedcsv.FieldParseError
+	if len(names) != 2 || !errors.As(lastErr, &fieldParseError) {
+		t.Fatalf("Expected John and Jane and a FieldParseError, got %v and %v", names, lastErr)
+	}
+}
+
+func TestAllBreakClosesFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "records.csv")
+	records := make([]AppendTestRecord, 1000)
+	for i := range records {
+		records[i] = AppendTestRecord{i, "John"}
+	}
+	if err := appendRecords(t, path, records...); err != nil {
+		t.Fatal(err)
+	}
+	csvReader, err := typedcsv.OpenReader[AppendTestRecord](path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := csvReader.ReadHeader(); err != nil {
+		t.Fatal(err)
+	}
+	for record, err := range csvReader.All() {
+		if err != nil || record.Name != "John" {
+			t.Fatalf("Expected John, got %v and %v", record, err)
+		}
+		break
+	}
+	_, err = csvReader.ReadAll()
+	if !errors.Is(err, os.ErrClosed) {
+		t.Fatalf("Expected %v, got %v", os.ErrClosed, err)
+	}
+}
@@ -0,0 +1,117 @@
+package typedcsv
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"time"
+)
+
+const enumTag = "enum"
+
+// JSONSchema returns a JSON Schema (draft 2020-12) describing the rows written by TypedCSVWriter for T,
+// as a JSON object with one property per column:
+//
+//   - integers, floats and booleans are described as "integer", "number" and "boolean". Unsigned integers have a minimum of 0.
+//   - time.Time fields are strings with the "date-time" format, or the "date" format for the "2006-01-02" layout.
+//     Other layouts are given in the non-standard "x-time-format" keyword.
+//   - slices are arrays of their item type.
+//   - fields implementing encoding.TextMarshaler or having a "format" tag are strings.
+//   - the "enum" tag value, a list of values separated by "|", is used as the enum of the property.
+//   - pointer fields with a "null" tag also accept null.
+//   - the "desc" tag value is used as the description of the property, and the "unit" tag value is given in the non-standard "x-unit" keyword.
+//
+// All columns are required, as they are always written.
+func JSONSchema[T any](opts ...Option) ([]byte, error) {
+	codec := NewCodec[T](opts...)
+	if codec.typeErr != nil {
+		return nil, codec.typeErr
+	}
+	properties := make(map[string]any, len(codec.fields))
+	for i := range codec.fields {
+		field := &codec.fields[i]
+		property := field.jsonSchema()
+		if field.pointer && field.hasNull {
+			property["type"] = []any{property["type"], "null"}
+		}
+		properties[field.name] = property
+	}
+	var zero [0]T
+	schema := map[string]any{
+		"$schema":              "https://json-schema.org/draft/2020-12/schema",
+		"title":                reflect.TypeOf(zero).Elem().Name(),
+		"type":                 "object",
+		"properties":           properties,
+		"required":             codec.header,
+		"additionalProperties": false,
+	}
+	return json.MarshalIndent(schema, "", "  ")
+}
+
+func (f *field) jsonSchema() map[string]any {
+	property := make(map[string]any)
+	if f.description != "" {
+		property["description"] = f.description
+	}
+	if f.unit != "" {
+		property["x-unit"] = f.unit
+	}
+	if f.enum != nil {
+		property["enum"] = f.enum
+	}
+	switch {
+	case f.timeFormatted():
+		property["type"] = "string"
+		switch f.timeFormat {
+		case time.RFC3339, time.RFC3339Nano:
+			property["format"] = "date-time"
+		case time.DateOnly:
+			property["format"] = "date"
+		default:
+			property["x-time-format"] = f.timeFormat
+		}
+	case f.time && f.marshaler:
+		property["type"] = "string"
+		property["format"] = "date-time"
+	case f.marshaler || (f.hasFormat && !f.slice):
+		property["type"] = "string"
+	case f.slice:
+		property["type"] = "array"
+		property["items"] = map[string]any{"type": jsonSchemaType(f.sliceItemType)}
+	default:
+		property["type"] = jsonSchemaType(f.typ)
+		if isUnsigned(f.typ) {
+			property["minimum"] = 0
+		}
+	}
+	return property
+}
+
+func jsonSchemaType(t reflect.Type) string {
+	if t.Implements(textMarshalerType) {
+		return "string"
+	}
+	switch t.Kind() {
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return "integer"
+	case reflect.Float32, reflect.Float64:
+		return "number"
+	default:
+		return "string"
+	}
+}
+
+func isUnsigned(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return true
+	}
+	return false
+}
+
+func parseEnum(value string) []string {
+	return strings.Split(value, "|")
+}
@@ -0,0 +1,61 @@
+package typedcsv_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/hoshiumiarata/typedcsv"
+)
+
+type JSONSchemaTestRecord struct {
+	Name     string   `csv:"name"`
+	Level    string   `csv:"level" enum:"low|high"`
+	Age      uint8    `csv:"age"`
+	Tags     []int    `csv:"tags" separator:";"`
+	Optional *float64 `csv:"optional" null:"NULL"`
+}
+
+func TestJSONSchema(t *testing.T) {
+	data, err := typedcsv.JSONSchema[JSONSchemaTestRecord]()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var schema map[string]any
+	err = json.Unmarshal(data, &schema)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := map[string]any{
+		"$schema": "https://json-schema.org/draft/2020-12/schema",
+		"title":   "JSONSchemaTestRecord",
+		"type":    "object",
+		"properties": map[string]any{
+			"name":     map[string]any{"type": "string"},
+			"level":    map[string]any{"type": "string", "enum": []any{"low", "high"}},
+			"age":      map[string]any{"type": "integer", "minimum": 0.0},
+			"tags":     map[string]any{"type": "array", "items": map[string]any{"type": "integer"}},
+			"optional": map[string]any{"type": []any{"number", "null"}},
+		},
+		"required":             []any{"name", "level", "age", "tags", "optional"},
+		"additionalProperties": false,
+	}
+	if !reflect.DeepEqual(schema, expected) {
+		t.Fatalf("Expected %v, got %v", expected, schema)
+	}
+
+	data, err = typedcsv.JSONSchema[Person]()
+	if err != nil {
+		t.Fatal(err)
+	}
+	schema = nil
+	err = json.Unmarshal(data, &schema)
+	if err != nil {
+		t.Fatal(err)
+	}
+	birthday := schema["properties"].(map[string]any)["birthday"]
+	expectedBirthday := map[string]any{"type": "string", "format": "date"}
+	if !reflect.DeepEqual(birthday, expectedBirthday) {
+		t.Fatalf("Expected %v, got %v", expectedBirthday, birthday)
+	}
+}
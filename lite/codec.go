@@ -0,0 +1,144 @@
+// Package lite reads and writes CSV records with explicitly registered codecs instead of reflection.
+//
+// It is meant for targets such as TinyGo and WebAssembly, where reflection support and binary size are constraints.
+// It does not import the typedcsv package, so the reflection-based field plans and their dependencies are not linked,
+// nor fmt and encoding/csv, which rely on reflection: values are converted with strconv only, and the rows are read
+// and written through the RowReader and RowWriter interfaces. They are implemented by csv.Reader and csv.Writer,
+// which link reflection; the package provides no other implementation, so on constrained targets the caller supplies one.
+// The columns of a record type are registered by hand or by generated code:
+//
+//	var codec = lite.NewCodec(
+//		lite.String("name", func(p *Person) *string { return &p.Name }),
+//		lite.Int("age", func(p *Person) *int { return &p.Age }),
+//		lite.Time("birthday", "2006-01-02", func(p *Person) *time.Time { return &p.Birthday }),
+//	)
+package lite
+
+import (
+	"errors"
+	"strconv"
+	"time"
+)
+
+// ErrHeaderNotRead is returned when ReadRecord is called before ReadHeader.
+var ErrHeaderNotRead = errors.New("typedcsv: header not read")
+
+// FieldParseError is returned when a field cannot be parsed.
+type FieldParseError struct {
+	// Field is the name of the field that could not be parsed.
+	Field string
+	// NestedError is the error returned by the column decoder.
+	NestedError error
+}
+
+// Error returns the error message.
+func (e FieldParseError) Error() string {
+	return "typedcsv: error parsing field '" + e.Field + "': " + e.NestedError.Error()
+}
+
+// Unwrap returns the nested error.
+func (e FieldParseError) Unwrap() error {
+	return e.NestedError
+}
+
+// FieldFormatError is returned when a field cannot be formatted.
+type FieldFormatError struct {
+	Field       string
+	NestedError error
+}
+
+// Error returns the error message.
+func (e FieldFormatError) Error() string {
+	return "typedcsv: error formatting field '" + e.Field + "': " + e.NestedError.Error()
+}
+
+// Unwrap returns the nested error.
+func (e FieldFormatError) Unwrap() error {
+	return e.NestedError
+}
+
+// A Column maps a CSV column to a field of T.
+type Column[T any] struct {
+	// Name is the column name.
+	Name string
+	// Decode parses the CSV value into the record.
+	Decode func(record *T, value string) error
+	// Encode formats the field of the record as a CSV value.
+	Encode func(record *T) (string, error)
+}
+
+// A Codec holds the columns of T, in the order used by Writer.
+type Codec[T any] struct {
+	columns []Column[T]
+}
+
+// NewCodec returns a new Codec with the given columns.
+func NewCodec[T any](columns ...Column[T]) *Codec[T] {
+	return &Codec[T]{columns: columns}
+}
+
+// Header returns the column names, in the order used by Writer.
+func (c *Codec[T]) Header() []string {
+	header := make([]string, len(c.columns))
+	for i, column := range c.columns {
+		header[i] = column.Name
+	}
+	return header
+}
+
+// String returns a Column of a string field.
+func String[T any](name string, field func(*T) *string) Column[T] {
+	return Column[T]{
+		Name:   name,
+		Decode: func(record *T, value string) error { *field(record) = value; return nil },
+		Encode: func(record *T) (string, error) { return *field(record), nil },
+	}
+}
+
+// Int returns a Column of an int field.
+func Int[T any](name string, field func(*T) *int) Column[T] {
+	return Column[T]{
+		Name: name,
+		Decode: func(record *T, value string) (err error) {
+			*field(record), err = strconv.Atoi(value)
+			return err
+		},
+		Encode: func(record *T) (string, error) { return strconv.Itoa(*field(record)), nil },
+	}
+}
+
+// Float64 returns a Column of a float64 field.
+func Float64[T any](name string, field func(*T) *float64) Column[T] {
+	return Column[T]{
+		Name: name,
+		Decode: func(record *T, value string) (err error) {
+			*field(record), err = strconv.ParseFloat(value, 64)
+			return err
+		},
+		Encode: func(record *T) (string, error) { return strconv.FormatFloat(*field(record), 'g', -1, 64), nil },
+	}
+}
+
+// Bool returns a Column of a bool field.
+func Bool[T any](name string, field func(*T) *bool) Column[T] {
+	return Column[T]{
+		Name: name,
+		Decode: func(record *T, value string) (err error) {
+			*field(record), err = strconv.ParseBool(value)
+			return err
+		},
+		Encode: func(record *T) (string, error) { return strconv.FormatBool(*field(record)), nil },
+	}
+}
+
+// Time returns a Column of a time.Time field with the given layout.
+func Time[T any](name, layout string, field func(*T) *time.Time) Column[T] {
+	return Column[T]{
+		Name: name,
+		Decode: func(record *T, value string) (err error) {
+			*field(record), err = time.Parse(layout, value)
+			return err
+		},
+		Encode: func(record *T) (string, error) { return field(record).Format(layout), nil },
+	}
+}
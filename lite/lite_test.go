@@ -0,0 +1,104 @@
+package lite_test
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"go/build"
+	"testing"
+	"time"
+
+	"github.com/hoshiumiarata/typedcsv/lite"
+)
+
+type Person struct {
+	Name     string
+	Age      int
+	Score    float64
+	Active   bool
+	Birthday time.Time
+}
+
+var codec = lite.NewCodec(
+	lite.String("name", func(p *Person) *string { return &p.Name }),
+	lite.Int("age", func(p *Person) *int { return &p.Age }),
+	lite.Float64("score", func(p *Person) *float64 { return &p.Score }),
+	lite.Bool("active", func(p *Person) *bool { return &p.Active }),
+	lite.Time("birthday", "2006-01-02", func(p *Person) *time.Time { return &p.Birthday }),
+)
+
+func TestRoundTrip(t *testing.T) {
+	writer := bytes.Buffer{}
+	csvWriter := codec.NewWriter(csv.NewWriter(&writer))
+	err := csvWriter.WriteHeader()
+	if err != nil {
+		t.Fatal(err)
+	}
+	person := Person{Name: "John Smith", Age: 55, Score: 1.5, Active: true, Birthday: time.Date(1970, 6, 17, 0, 0, 0, 0, time.UTC)}
+	err = csvWriter.WriteRecord(person)
+	if err != nil {
+		t.Fatal(err)
+	}
+	csvWriter.Flush()
+	expected := "name,age,score,active,birthday\nJohn Smith,55,1.5,true,1970-06-17\n"
+	if writer.String() != expected {
+		t.Fatalf("Expected %q, got %q", expected, writer.String())
+	}
+
+	csvReader := codec.NewReader(csv.NewReader(&writer))
+	_, err = csvReader.ReadRecord()
+	if err != lite.ErrHeaderNotRead {
+		t.Fatalf("Expected %v, got %v", lite.ErrHeaderNotRead, err)
+	}
+	err = csvReader.ReadHeader()
+	if err != nil {
+		t.Fatal(err)
+	}
+	records, err := csvReader.ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 1 || *records[0] != person {
+		t.Fatalf("Expected %v, got %v", person, records)
+	}
+}
+
+func TestParseError(t *testing.T) {
+	reader := bytes.Buffer{}
+	reader.WriteString("age,name\nabc,John\n")
+	csvReader := codec.NewReader(csv.NewReader(&reader))
+	err := csvReader.ReadHeader()
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = csvReader.ReadRecord()
+	var fieldParseError lite.FieldParseError
+	if !errors.As(err, &fieldParseError) || fieldParseError.Field != "age" {
+		t.Fatalf("Expected %T for age, got %v", fieldParseError, err)
+	}
+}
+
+func TestNoReflect(t *testing.T) {
+	pkg, err := build.ImportDir(".", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	seen := make(map[string]bool)
+	imports := pkg.Imports
+	for len(imports) > 0 {
+		imported := imports[0]
+		imports = imports[1:]
+		if seen[imported] {
+			continue
+		}
+		seen[imported] = true
+		if imported == "reflect" || imported == "fmt" || imported == "github.com/hoshiumiarata/typedcsv" {
+			t.Fatalf("Expected no import of %q", imported)
+		}
+		dependency, err := build.Import(imported, "", 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		imports = append(imports, dependency.Imports...)
+	}
+}
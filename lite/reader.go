@@ -0,0 +1,86 @@
+package lite
+
+import "io"
+
+// A RowReader reads the rows of a CSV file. It is implemented by *csv.Reader.
+type RowReader interface {
+	// Read reads one row. It returns io.EOF when there are no more rows.
+	Read() ([]string, error)
+}
+
+// A Reader reads records of T from a CSV file with a Codec.
+type Reader[T any] struct {
+	Reader RowReader
+	Header map[string]int
+
+	codec   *Codec[T]
+	columns []int
+}
+
+// NewReader returns a new Reader that wraps the given RowReader, such as a csv.Reader.
+func (c *Codec[T]) NewReader(reader RowReader) *Reader[T] {
+	return &Reader[T]{Reader: reader, codec: c}
+}
+
+// ReadHeader reads the CSV header from the underlying reader and matches it with the column names of the Codec.
+// It returns io.EOF if there is no header.
+func (r *Reader[T]) ReadHeader() error {
+	header, err := r.Reader.Read()
+	if err != nil {
+		return err
+	}
+	r.Header = make(map[string]int, len(header))
+	for i, name := range header {
+		if _, ok := r.Header[name]; !ok {
+			r.Header[name] = i
+		}
+	}
+	r.columns = make([]int, len(r.codec.columns))
+	for i, column := range r.codec.columns {
+		index, ok := r.Header[column.Name]
+		if !ok {
+			index = -1
+		}
+		r.columns[i] = index
+	}
+	return nil
+}
+
+// ReadRecord reads the next record from the underlying reader. Columns missing in the header or the row are left unset.
+// It returns ErrHeaderNotRead if ReadHeader was not called, io.EOF if there are no more records,
+// and a FieldParseError if a field cannot be parsed.
+func (r *Reader[T]) ReadRecord() (*T, error) {
+	if r.Header == nil {
+		return nil, ErrHeaderNotRead
+	}
+	values, err := r.Reader.Read()
+	if err != nil {
+		return nil, err
+	}
+	record := new(T)
+	for i, column := range r.codec.columns {
+		index := r.columns[i]
+		if index < 0 || index >= len(values) {
+			continue
+		}
+		err := column.Decode(record, values[index])
+		if err != nil {
+			return record, FieldParseError{Field: column.Name, NestedError: err}
+		}
+	}
+	return record, nil
+}
+
+// ReadAll reads all the remaining records from the underlying reader.
+func (r *Reader[T]) ReadAll() (records []*T, err error) {
+	for {
+		record, err := r.ReadRecord()
+		if err == io.EOF {
+			return records, nil
+		}
+		if err != nil {
+			return records, err
+		}
+		records = append(records, record)
+	}
+}
@@ -0,0 +1,52 @@
+package lite
+
+// A RowWriter writes the rows of a CSV file. It is implemented by *csv.Writer.
+type RowWriter interface {
+	// Write writes one row.
+	Write(row []string) error
+	// Flush writes any buffered data to the underlying io.Writer.
+	Flush()
+	// Error reports any error that has occurred during a previous Write or Flush.
+	Error() error
+}
+
+// A Writer writes records of T to a CSV file with a Codec.
+type Writer[T any] struct {
+	Writer RowWriter
+
+	codec *Codec[T]
+}
+
+// NewWriter returns a new Writer that wraps the given RowWriter, such as a csv.Writer.
+func (c *Codec[T]) NewWriter(writer RowWriter) *Writer[T] {
+	return &Writer[T]{Writer: writer, codec: c}
+}
+
+// WriteHeader writes the column names of the Codec to the underlying writer.
+func (w *Writer[T]) WriteHeader() error {
+	return w.Writer.Write(w.codec.Header())
+}
+
+// WriteRecord writes the record to the underlying writer.
+// It returns a FieldFormatError if a field cannot be formatted.
+func (w *Writer[T]) WriteRecord(record T) error {
+	values := make([]string, len(w.codec.columns))
+	for i, column := range w.codec.columns {
+		value, err := column.Encode(&record)
+		if err != nil {
+			return FieldFormatError{Field: column.Name, NestedError: err}
+		}
+		values[i] = value
+	}
+	return w.Writer.Write(values)
+}
+
+// Flush writes any buffered data to the underlying RowWriter.
+func (w *Writer[T]) Flush() {
+	w.Writer.Flush()
+}
+
+// Error reports any error that has occurred during a previous WriteHeader, WriteRecord or Flush.
+func (w *Writer[T]) Error() error {
+	return w.Writer.Error()
+}
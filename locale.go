@@ -0,0 +1,214 @@
+package typedcsv
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/language"
+)
+
+const localeTag = "locale"
+
+// WithLocale sets the locale, a BCP 47 language tag such as "fr-FR" or "de", of the number and time fields,
+// which selects their decimal separator, their grouping separator and their month names.
+// The "locale" tag value sets the locale of one field instead.
+//
+// The conventions are a fixed table, not CLDR data: the supported locales are English, French, German, Swiss German,
+// Spanish, Italian, Portuguese and Dutch. Other tags are matched to the closest of them, such as French for "fr-CA",
+// and tags without a close match, such as "ja", are an error. Month names are the full names and the abbreviations
+// of the table, such as "janvier" and "janv" for French; other spellings, such as "Sept" in English, do not parse.
+// The period following an abbreviation, as in "janv.", is not part of the name and must be in the time layout, as in "02 Jan. 2006".
+func WithLocale(tag string) Option {
+	return func(o *options) {
+		o.locale = tag
+	}
+}
+
+// A locale holds the conventions of a language used to parse and format numbers and month names.
+type locale struct {
+	decimal     string
+	groups      []string
+	months      [12]string
+	shortMonths [12]string
+}
+
+// locales are the supported locales, in the order of localeTags.
+var locales = []*locale{
+	{
+		decimal: ".", groups: []string{","},
+		months:      [12]string{"January", "February", "March", "April", "May", "June", "July", "August", "September", "October", "November", "December"},
+		shortMonths: [12]string{"Jan", "Feb", "Mar", "Apr", "May", "Jun", "Jul", "Aug", "Sep", "Oct", "Nov", "Dec"},
+	},
+	{
+		decimal: ",", groups: []string{"\u202f", "\u00a0", " "},
+		months:      [12]string{"janvier", "février", "mars", "avril", "mai", "juin", "juillet", "août", "septembre", "octobre", "novembre", "décembre"},
+		shortMonths: [12]string{"janv", "févr", "mars", "avr", "mai", "juin", "juil", "août", "sept", "oct", "nov", "déc"},
+	},
+	{
+		decimal: ",", groups: []string{".", "\u00a0", " "},
+		months:      [12]string{"Januar", "Februar", "März", "April", "Mai", "Juni", "Juli", "August", "September", "Oktober", "November", "Dezember"},
+		shortMonths: [12]string{"Jan", "Feb", "Mär", "Apr", "Mai", "Jun", "Jul", "Aug", "Sep", "Okt", "Nov", "Dez"},
+	},
+	{
+		decimal: ".", groups: []string{"\u2019", "'"},
+		months:      [12]string{"Januar", "Februar", "März", "April", "Mai", "Juni", "Juli", "August", "September", "Oktober", "November", "Dezember"},
+		shortMonths: [12]string{"Jan", "Feb", "Mär", "Apr", "Mai", "Jun", "Jul", "Aug", "Sep", "Okt", "Nov", "Dez"},
+	},
+	{
+		decimal: ",", groups: []string{".", "\u00a0", " "},
+		months:      [12]string{"enero", "febrero", "marzo", "abril", "mayo", "junio", "julio", "agosto", "septiembre", "octubre", "noviembre", "diciembre"},
+		shortMonths: [12]string{"ene", "feb", "mar", "abr", "may", "jun", "jul", "ago", "sept", "oct", "nov", "dic"},
+	},
+	{
+		decimal: ",", groups: []string{".", "\u00a0", " "},
+		months:      [12]string{"gennaio", "febbraio", "marzo", "aprile", "maggio", "giugno", "luglio", "agosto", "settembre", "ottobre", "novembre", "dicembre"},
+		shortMonths: [12]string{"gen", "feb", "mar", "apr", "mag", "giu", "lug", "ago", "set", "ott", "nov", "dic"},
+	},
+	{
+		decimal: ",", groups: []string{".", "\u00a0", " "},
+		months:      [12]string{"janeiro", "fevereiro", "março", "abril", "maio", "junho", "julho", "agosto", "setembro", "outubro", "novembro", "dezembro"},
+		shortMonths: [12]string{"jan", "fev", "mar", "abr", "mai", "jun", "jul", "ago", "set", "out", "nov", "dez"},
+	},
+	{
+		decimal: ",", groups: []string{".", "\u00a0", " "},
+		months:      [12]string{"januari", "februari", "maart", "april", "mei", "juni", "juli", "augustus", "september", "oktober", "november", "december"},
+		shortMonths: [12]string{"jan", "feb", "mrt", "apr", "mei", "jun", "jul", "aug", "sep", "okt", "nov", "dec"},
+	},
+}
+
+var (
+	localeTags = []language.Tag{
+		language.English,
+		language.French,
+		language.German,
+		language.MustParse("de-CH"),
+		language.Spanish,
+		language.Italian,
+		language.Portuguese,
+		language.Dutch,
+	}
+	localeMatcher = language.NewMatcher(localeTags)
+)
+
+// localeOf returns the supported locale closest to a BCP 47 language tag, such as French for "fr-CA".
+func localeOf(name string) (*locale, error) {
+	tag, err := language.Parse(name)
+	if err != nil {
+		return nil, fmt.Errorf("invalid locale %q: %w", name, err)
+	}
+	_, index, confidence := localeMatcher.Match(tag)
+	if confidence == language.No {
+		return nil, fmt.Errorf("unsupported locale %q", name)
+	}
+	return locales[index], nil
+}
+
+// localizable reports whether the values of the field depend on its locale: numbers, slices of numbers,
+// and times parsed and formatted with a Go layout.
+func (f *field) localizable() bool {
+	if f.converter != nil {
+		return false
+	}
+	if f.timeFormatted() {
+		return f.timeCodec == nil
+	}
+	if f.marshaler || f.unmarshaler {
+		return false
+	}
+	if f.slice {
+		return isNumber(f.sliceItemType)
+	}
+	return isNumber(f.typ)
+}
+
+func isNumber(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
+
+// parseNumber removes the grouping separators of a number and replaces its decimal separator with a dot.
+func (l *locale) parseNumber(value string) string {
+	for _, group := range l.groups {
+		if group != l.decimal {
+			value = strings.ReplaceAll(value, group, "")
+		}
+	}
+	if l.decimal != "." {
+		value = strings.Replace(value, l.decimal, ".", 1)
+	}
+	return value
+}
+
+// formatNumber replaces the decimal point of a formatted number with the decimal separator.
+// Numbers are formatted without grouping separators.
+func (l *locale) formatNumber(value string) string {
+	if l.decimal == "." {
+		return value
+	}
+	return strings.Replace(value, ".", l.decimal, 1)
+}
+
+// monthNames returns the month names of the locale and of English used by a time layout,
+// or false if the layout has no month name.
+func (l *locale) monthNames(layout string) (localized, english *[12]string, ok bool) {
+	switch {
+	case strings.Contains(layout, "January"):
+		return &l.months, &locales[0].months, true
+	case strings.Contains(layout, "Jan"):
+		return &l.shortMonths, &locales[0].shortMonths, true
+	}
+	return nil, nil, false
+}
+
+// parseTime replaces the localized month names of a time value with the English names expected by time.Parse.
+func (l *locale) parseTime(value, layout string) string {
+	localized, english, ok := l.monthNames(layout)
+	if !ok {
+		return value
+	}
+	return replaceWords(value, localized, english)
+}
+
+// formatTime replaces the English month names of a formatted time with the localized names.
+func (l *locale) formatTime(value, layout string) string {
+	localized, english, ok := l.monthNames(layout)
+	if !ok {
+		return value
+	}
+	return replaceWords(value, english, localized)
+}
+
+// replaceWords replaces the words of s, the runs of letters, equal to one of the from names, ignoring case,
+// with the to name of the same index.
+func replaceWords(s string, from, to *[12]string) string {
+	var builder strings.Builder
+	for len(s) > 0 {
+		end := strings.IndexFunc(s, func(r rune) bool { return !unicode.IsLetter(r) })
+		if end < 0 {
+			end = len(s)
+		}
+		word := s[:end]
+		for i, name := range from {
+			if strings.EqualFold(word, name) {
+				word = to[i]
+				break
+			}
+		}
+		builder.WriteString(word)
+		s = s[end:]
+		start := strings.IndexFunc(s, unicode.IsLetter)
+		if start < 0 {
+			start = len(s)
+		}
+		builder.WriteString(s[:start])
+		s = s[start:]
+	}
+	return builder.String()
+}
@@ -0,0 +1,104 @@
+package typedcsv_test
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/hoshiumiarata/typedcsv"
+)
+
+type LocaleTestRecord struct {
+	Price    float64   `csv:"price" locale:"fr-FR"`
+	Quantity int       `csv:"quantity" locale:"de-DE"`
+	Amount   float64   `csv:"amount"`
+	Date     time.Time `csv:"date" time_format:"2 January 2006" locale:"fr"`
+	Short    time.Time `csv:"short" time_format:"02 Jan. 2006" locale:"de"`
+	Rates    []float64 `csv:"rates" separator:";" locale:"it"`
+}
+
+func TestLocale(t *testing.T) {
+	input := "price,quantity,amount,date,short,rates\n" +
+		"\"1\u202f234,5\",1.234.567,1'234.25,3 février 2024,05 Mär. 2024,\"0,5;1,25\"\n"
+	csvReader := typedcsv.NewReader[LocaleTestRecord](csv.NewReader(bytes.NewBufferString(input)), typedcsv.WithLocale("de-CH"))
+	if err := csvReader.ReadHeader(); err != nil {
+		t.Fatal(err)
+	}
+	record, err := csvReader.ReadRecord()
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := LocaleTestRecord{
+		Price:    1234.5,
+		Quantity: 1234567,
+		Amount:   1234.25,
+		Date:     time.Date(2024, time.February, 3, 0, 0, 0, 0, time.UTC),
+		Short:    time.Date(2024, time.March, 5, 0, 0, 0, 0, time.UTC),
+		Rates:    []float64{0.5, 1.25},
+	}
+	if !reflect.DeepEqual(*record, expected) {
+		t.Fatalf("Expected %v, got %v", expected, record)
+	}
+
+	var buffer bytes.Buffer
+	csvWriter := typedcsv.NewWriter[LocaleTestRecord](csv.NewWriter(&buffer), typedcsv.WithLocale("de-CH"))
+	if err := csvWriter.WriteRecord(*record); err != nil {
+		t.Fatal(err)
+	}
+	csvWriter.Flush()
+	if expected := "\"1234,5\",1234567,1234.25,3 février 2024,05 Mär. 2024,\"0,5;1,25\"\n"; buffer.String() != expected {
+		t.Fatalf("Expected %q, got %q", expected, buffer.String())
+	}
+}
+
+type InvalidLocaleTestRecord struct {
+	Name   string  `csv:"name" locale:"fr"`
+	Amount float64 `csv:"amount" locale:"not a locale"`
+}
+
+func TestLocaleTagErrors(t *testing.T) {
+	err := typedcsv.NewCodec[InvalidLocaleTestRecord]().Err()
+	var tagErr typedcsv.TagError
+	if !errors.As(err, &tagErr) || tagErr.Field != "Name" || tagErr.Tag != "locale" {
+		t.Fatalf("Expected a locale TagError on Name, got %v", err)
+	}
+	csvReader := typedcsv.NewReader[InvalidLocaleTestRecord](csv.NewReader(bytes.NewBufferString("name,amount\na,1\n")))
+	if err := csvReader.ReadHeader(); err != nil {
+		t.Fatal(err)
+	}
+	_, err = csvReader.ReadRecord()
+	var parseErr typedcsv.FieldParseError
+	if !errors.As(err, &parseErr) || parseErr.Field != "amount" {
+		t.Fatalf("Expected a FieldParseError on amount, got %v", err)
+	}
+}
+
+type LocaleLimitsTestRecord struct {
+	Date time.Time `csv:"date" time_format:"02 Jan 2006"`
+}
+
+func TestLocaleLimits(t *testing.T) {
+	tests := []struct {
+		locale string
+		value  string
+		ok     bool
+	}{
+		{"fr-CA", "03 janv 2024", true},
+		{"fr", "03 janv. 2024", false},
+		{"ja", "03 Jan 2024", false},
+	}
+	for _, test := range tests {
+		input := "date\n" + test.value + "\n"
+		csvReader := typedcsv.NewReader[LocaleLimitsTestRecord](csv.NewReader(bytes.NewBufferString(input)), typedcsv.WithLocale(test.locale))
+		if err := csvReader.ReadHeader(); err != nil {
+			t.Fatal(err)
+		}
+		_, err := csvReader.ReadRecord()
+		if (err == nil) != test.ok {
+			t.Fatalf("%s %q: Expected success %v, got %v", test.locale, test.value, test.ok, err)
+		}
+	}
+}
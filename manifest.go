@@ -0,0 +1,104 @@
+package typedcsv
+
+import (
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"hash"
+	"io"
+)
+
+// A Manifest describes a delivered CSV file, so that its integrity can be checked by the receiver.
+type Manifest struct {
+	// File is the name of the file. It is optional.
+	File string `json:"file,omitempty"`
+	// Algorithm is the name of the digest algorithm.
+	Algorithm string `json:"algorithm"`
+	// Digest is the hex-encoded digest of the whole file.
+	Digest string `json:"digest"`
+	// Bytes is the size of the file.
+	Bytes int64 `json:"bytes"`
+	// Rows is the number of records in the file, excluding the header.
+	Rows int `json:"rows"`
+}
+
+// manifestAlgorithm is the digest algorithm of the manifests written by ManifestWriter.
+const manifestAlgorithm = "sha256"
+
+// A ManifestWriter is a TypedCSVWriter that computes the digest, the size and the number of records
+// of the file while writing, and writes them as a JSON Manifest on Close.
+type ManifestWriter[T any] struct {
+	*TypedCSVWriter[T]
+	// File is the name of the file recorded in the manifest. It is optional.
+	File string
+
+	manifest io.Writer
+	digest   hash.Hash
+	counter  countingWriter
+	rows     int
+	closed   bool
+}
+
+// NewManifestWriter returns a new ManifestWriter writing CSV to w and the JSON manifest to manifest on Close.
+// The digest algorithm is SHA-256.
+func NewManifestWriter[T any](w io.Writer, manifest io.Writer, opts ...Option) *ManifestWriter[T] {
+	mw := &ManifestWriter[T]{
+		manifest: manifest,
+		digest:   sha256.New(),
+	}
+	mw.counter.writer = io.MultiWriter(w, mw.digest)
+	mw.TypedCSVWriter = NewWriter[T](csv.NewWriter(&mw.counter), opts...)
+	return mw
+}
+
+// WriteRecord writes the CSV record and counts it in the manifest.
+// It returns the same errors as TypedCSVWriter.WriteRecord.
+func (w *ManifestWriter[T]) WriteRecord(record T) error {
+	err := w.TypedCSVWriter.WriteRecord(record)
+	if err == nil {
+		w.rows++
+	}
+	return err
+}
+
+// Manifest flushes the buffered data and returns the manifest of the data written so far.
+func (w *ManifestWriter[T]) Manifest() Manifest {
+	w.Flush()
+	return Manifest{
+		File:      w.File,
+		Algorithm: manifestAlgorithm,
+		Digest:    hex.EncodeToString(w.digest.Sum(nil)),
+		Bytes:     w.counter.n,
+		Rows:      w.rows,
+	}
+}
+
+// Close flushes the buffered data and writes the manifest.
+// It returns any error that occurred while writing the CSV data or the manifest.
+// Calling Close more than once does nothing.
+func (w *ManifestWriter[T]) Close() error {
+	if w.closed {
+		return nil
+	}
+	w.closed = true
+	manifest := w.Manifest()
+	if err := w.Error(); err != nil {
+		return err
+	}
+	encoder := json.NewEncoder(w.manifest)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(manifest)
+}
+
+// A countingWriter counts the bytes written to the underlying writer.
+type countingWriter struct {
+	writer io.Writer
+	n      int64
+}
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	n, err := w.writer.Write(p)
+	w.n += int64(n)
+	return n, err
+}
@@ -0,0 +1,74 @@
+package typedcsv_test
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"testing"
+
+	"github.com/hoshiumiarata/typedcsv"
+)
+
+func TestManifestWriter(t *testing.T) {
+	data := bytes.Buffer{}
+	manifest := bytes.Buffer{}
+	csvWriter := typedcsv.NewManifestWriter[MarshalTextTestRecord](&data, &manifest)
+	csvWriter.File = "statuses.csv"
+	err := csvWriter.WriteHeader()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, status := range []PersonStatus{PersonStatusActive, PersonStatusInactive, 100} {
+		_ = csvWriter.WriteRecord(MarshalTextTestRecord{PersonStatus: status})
+	}
+	err = csvWriter.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectedData := "person_status\nactive\ninactive\n"
+	if data.String() != expectedData {
+		t.Fatalf("Expected %q, got %q", expectedData, data.String())
+	}
+
+	var got typedcsv.Manifest
+	err = json.Unmarshal(manifest.Bytes(), &got)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sum := sha256.Sum256([]byte(expectedData))
+	expected := typedcsv.Manifest{
+		File:      "statuses.csv",
+		Algorithm: "sha256",
+		Digest:    hex.EncodeToString(sum[:]),
+		Bytes:     int64(len(expectedData)),
+		Rows:      2,
+	}
+	if got != expected {
+		t.Fatalf("Expected %+v, got %+v", expected, got)
+	}
+
+	err = csvWriter.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Count(manifest.Bytes(), []byte("digest")) != 1 {
+		t.Fatalf("Expected the manifest to be written once, got %q", manifest.String())
+	}
+}
+
+func TestManifestWriterError(t *testing.T) {
+	manifest := bytes.Buffer{}
+	csvWriter := typedcsv.NewManifestWriter[Person](&ErrorWriter{}, &manifest)
+	err := csvWriter.WriteHeader()
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = csvWriter.Close()
+	if err == nil {
+		t.Fatal("Expected error, got nil")
+	}
+	if manifest.Len() != 0 {
+		t.Fatalf("Expected no manifest, got %q", manifest.String())
+	}
+}
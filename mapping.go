@@ -0,0 +1,101 @@
+package typedcsv
+
+import "sort"
+
+// A Mapping describes how the header read by a TypedCSVReader was bound to the struct fields.
+type Mapping struct {
+	// Fields lists the mapped struct fields in declaration order.
+	Fields []FieldBinding
+	// UnboundColumns lists the header columns that are not bound to any field, in header order.
+	UnboundColumns []ColumnRef
+}
+
+// A FieldBinding describes the binding of a struct field to a header column.
+type FieldBinding struct {
+	// Field is the Go name of the field. Fields of inlined structs are given as a dotted path.
+	Field string
+	// Column is the column name of the field.
+	Column string
+	// Index is the index of the column in the header, or -1 if the column is missing.
+	Index int
+}
+
+// Bound reports whether the field is bound to a header column.
+func (b FieldBinding) Bound() bool {
+	return b.Index >= 0
+}
+
+// A ColumnRef identifies a header column.
+type ColumnRef struct {
+	Name  string
+	Index int
+}
+
+// UnboundFields returns the fields that are not bound to any header column.
+func (m Mapping) UnboundFields() []FieldBinding {
+	var unbound []FieldBinding
+	for _, binding := range m.Fields {
+		if !binding.Bound() {
+			unbound = append(unbound, binding)
+		}
+	}
+	return unbound
+}
+
+// ReorderedFields returns the bound fields whose columns are not in the same relative order in the header as the fields
+// in the struct, in declaration order. Unbound fields and unbound columns are ignored, so that only a permutation
+// of the columns is reported. It returns nil if the columns are in declaration order.
+func (m Mapping) ReorderedFields() []FieldBinding {
+	var bound []FieldBinding
+	for _, binding := range m.Fields {
+		if binding.Bound() {
+			bound = append(bound, binding)
+		}
+	}
+	sorted := append([]FieldBinding(nil), bound...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Index < sorted[j].Index
+	})
+	var reordered []FieldBinding
+	for i := range bound {
+		if bound[i].Index != sorted[i].Index {
+			reordered = append(reordered, bound[i])
+		}
+	}
+	return reordered
+}
+
+// Mapping returns how the header was bound to the struct fields.
+// It returns ErrHeaderNotRead if ReadHeader was not called.
+func (r *TypedCSVReader[T]) Mapping() (Mapping, error) {
+	if r.Header == nil {
+		return Mapping{}, ErrHeaderNotRead
+	}
+	if r.columns == nil {
+		r.bindColumns()
+	}
+	var mapping Mapping
+	bound := make(map[int]bool)
+	for i := range r.codec.fields {
+		field := &r.codec.fields[i]
+		mapping.Fields = append(mapping.Fields, FieldBinding{Field: field.path, Column: field.name, Index: r.columns[i]})
+		bound[r.columns[i]] = true
+	}
+	if r.headerFields != nil {
+		for i, column := range r.headerFields {
+			if !bound[i] {
+				mapping.UnboundColumns = append(mapping.UnboundColumns, ColumnRef{Name: column, Index: i})
+			}
+		}
+		return mapping, nil
+	}
+	for column, index := range r.Header {
+		if !bound[index] {
+			mapping.UnboundColumns = append(mapping.UnboundColumns, ColumnRef{Name: column, Index: index})
+		}
+	}
+	sort.Slice(mapping.UnboundColumns, func(i, j int) bool {
+		return mapping.UnboundColumns[i].Index < mapping.UnboundColumns[j].Index
+	})
+	return mapping, nil
+}
@@ -0,0 +1,83 @@
+package typedcsv_test
+
+import (
+	"bytes"
+	"encoding/csv"
+	"reflect"
+	"testing"
+
+	"github.com/hoshiumiarata/typedcsv"
+)
+
+func TestMapping(t *testing.T) {
+	reader := bytes.Buffer{}
+	reader.WriteString("optional_time,extra,optional_string\n")
+	csvReader := typedcsv.NewReader[OptionalTestRecord](csv.NewReader(&reader))
+	_, err := csvReader.Mapping()
+	if err != typedcsv.ErrHeaderNotRead {
+		t.Fatalf("Expected %v, got %v", typedcsv.ErrHeaderNotRead, err)
+	}
+	err = csvReader.ReadHeader()
+	if err != nil {
+		t.Fatal(err)
+	}
+	mapping, err := csvReader.Mapping()
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := typedcsv.Mapping{
+		Fields: []typedcsv.FieldBinding{
+			{Field: "OptionalStringWithoutTag", Column: "optional_string", Index: 2},
+			{Field: "OptionalStringWithEmptyTag", Column: "optional_string_with_empty_tag", Index: -1},
+			{Field: "OptionalTime", Column: "optional_time", Index: 0},
+		},
+		UnboundColumns: []typedcsv.ColumnRef{{Name: "extra", Index: 1}},
+	}
+	if !reflect.DeepEqual(mapping, expected) {
+		t.Fatalf("Expected %v, got %v", expected, mapping)
+	}
+	unbound := mapping.UnboundFields()
+	if len(unbound) != 1 || unbound[0].Field != "OptionalStringWithEmptyTag" {
+		t.Fatalf("Expected %v, got %v", expected.Fields[1:2], unbound)
+	}
+}
+
+func TestColumnOrderCheck(t *testing.T) {
+	reader := bytes.Buffer{}
+	reader.WriteString("age,extra,name,active\n")
+	csvReader := typedcsv.NewReader[Person](csv.NewReader(&reader), typedcsv.WithColumnOrderCheck())
+	err := csvReader.ReadHeader()
+	if err != nil {
+		t.Fatal(err)
+	}
+	mapping, err := csvReader.Mapping()
+	if err != nil {
+		t.Fatal(err)
+	}
+	reordered := mapping.ReorderedFields()
+	if len(reordered) != 2 || reordered[0].Column != "name" || reordered[1].Column != "age" {
+		t.Fatalf("Expected name and age to be reordered, got %v", reordered)
+	}
+	var warnings []string
+	for _, warning := range csvReader.Warnings() {
+		if warning.Kind == typedcsv.WarningReorderedColumn {
+			warnings = append(warnings, warning.String())
+		}
+	}
+	expected := []string{"typedcsv: reordered column 'name'", "typedcsv: reordered column 'age'"}
+	if !reflect.DeepEqual(warnings, expected) {
+		t.Fatalf("Expected %v, got %v", expected, warnings)
+	}
+
+	reader.Reset()
+	reader.WriteString("name,extra,age,active\n")
+	csvReader = typedcsv.NewReader[Person](csv.NewReader(&reader), typedcsv.WithColumnOrderCheck())
+	err = csvReader.ReadHeader()
+	if err != nil {
+		t.Fatal(err)
+	}
+	mapping, _ = csvReader.Mapping()
+	if mapping.ReorderedFields() != nil {
+		t.Fatalf("Expected nil, got %v", mapping.ReorderedFields())
+	}
+}
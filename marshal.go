@@ -0,0 +1,37 @@
+package typedcsv
+
+import (
+	"bytes"
+	"encoding/csv"
+	"io"
+)
+
+// Unmarshal parses the CSV data, including its header, and returns its records.
+// Empty data, without a header, has no records.
+// It returns the same errors as TypedCSVReader.ReadHeader and TypedCSVReader.ReadAll,
+// with the records parsed before the error.
+func Unmarshal[T any](data []byte, opts ...Option) ([]T, error) {
+	reader := NewReader[T](csv.NewReader(skipBOM(bytes.NewReader(data))), opts...)
+	if err := reader.ReadHeader(); err == io.EOF {
+		return []T{}, nil
+	} else if err != nil {
+		return nil, err
+	}
+	records, err := reader.ReadAll()
+	values := make([]T, len(records))
+	for i, record := range records {
+		values[i] = *record
+	}
+	return values, err
+}
+
+// Marshal returns the CSV encoding of the records, with a header even if there are no records.
+// It returns the same errors as TypedCSVWriter.WriteAll.
+func Marshal[T any](records []T, opts ...Option) ([]byte, error) {
+	var buffer bytes.Buffer
+	err := NewWriter[T](csv.NewWriter(&buffer), opts...).WriteAll(records)
+	if err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
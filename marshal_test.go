@@ -0,0 +1,52 @@
+package typedcsv_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/hoshiumiarata/typedcsv"
+)
+
+func TestMarshalUnmarshal(t *testing.T) {
+	records := []MarshalTextTestRecord{{PersonStatus: PersonStatusActive}, {PersonStatus: PersonStatusInactive}}
+	data, err := typedcsv.Marshal(records)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := "person_status\nactive\ninactive\n"
+	if string(data) != expected {
+		t.Fatalf("Expected %q, got %q", expected, data)
+	}
+	got, err := typedcsv.Unmarshal[MarshalTextTestRecord](data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, records) {
+		t.Fatalf("Expected %v, got %v", records, got)
+	}
+}
+
+func TestMarshalUnmarshalErrors(t *testing.T) {
+	_, err := typedcsv.Marshal([]MarshalTextTestRecord{{PersonStatus: 100}})
+	var fieldFormatError typedcsv.FieldFormatError
+	if !errors.As(err, &fieldFormatError) {
+		t.Fatalf("Expected a FieldFormatError, got %v", err)
+	}
+
+	got, err := typedcsv.Unmarshal[MarshalTextTestRecord]([]byte("person_status\nactive\nbad\n"))
+	var fieldParseError typedcsv.FieldParseError
+	if !errors.As(err, &fieldParseError) {
+		t.Fatalf("Expected a FieldParseError, got %v", err)
+	}
+	if len(got) != 1 || got[0].PersonStatus != PersonStatusActive {
+		t.Fatalf("Expected the record before the error, got %v", got)
+	}
+
+	for _, data := range []string{"", "person_status\n"} {
+		got, err = typedcsv.Unmarshal[MarshalTextTestRecord]([]byte(data))
+		if err != nil || got == nil || len(got) != 0 {
+			t.Fatalf("Expected no records for %q, got %v, %v", data, got, err)
+		}
+	}
+}
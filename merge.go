@@ -0,0 +1,98 @@
+package typedcsv
+
+import (
+	"encoding/csv"
+	"io"
+	"iter"
+)
+
+// Merge merges base and updates, both CSV with a header, by key, and returns an iterator over the merged records:
+//
+//	for record, err := range typedcsv.Merge(base, updates, key, nil) {
+//		if err != nil {
+//			return err
+//		}
+//		...
+//	}
+//
+// It yields the records of base in order, where the first record whose key is also in updates is replaced by
+// resolve(old, new), followed by the records of updates whose key is not in base, in order.
+// If resolve is nil, the record of updates wins. Records with the same key in updates are resolved in order.
+//
+// Only updates is kept in memory, when the iteration starts; base is streamed, so it should be the larger input.
+// On an error while reading base or updates, it yields the zero value with the error and stops.
+func Merge[T any](base, updates io.Reader, key func(T) string, resolve func(old, new T) T, opts ...Option) iter.Seq2[T, error] {
+	if resolve == nil {
+		resolve = func(_, new T) T { return new }
+	}
+	codec := NewCodec[T](opts...)
+	read := func(source io.Reader, fn func(T) bool) error {
+		reader := codec.NewReader(csv.NewReader(skipBOM(source)))
+		err := reader.ReadHeader()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		for {
+			record, err := reader.ReadRecord()
+			if err == io.EOF {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+			if !fn(*record) {
+				return nil
+			}
+		}
+	}
+	return func(yield func(T, error) bool) {
+		var zero T
+		var order []string
+		pending := make(map[string][]T)
+		err := read(updates, func(record T) bool {
+			k := key(record)
+			if _, ok := pending[k]; !ok {
+				order = append(order, k)
+			}
+			pending[k] = append(pending[k], record)
+			return true
+		})
+		if err != nil {
+			yield(zero, err)
+			return
+		}
+		stopped := false
+		err = read(base, func(record T) bool {
+			k := key(record)
+			for _, update := range pending[k] {
+				record = resolve(record, update)
+			}
+			delete(pending, k)
+			stopped = !yield(record, nil)
+			return !stopped
+		})
+		if stopped {
+			return
+		}
+		if err != nil {
+			yield(zero, err)
+			return
+		}
+		for _, k := range order {
+			records, ok := pending[k]
+			if !ok {
+				continue
+			}
+			record := records[0]
+			for _, update := range records[1:] {
+				record = resolve(record, update)
+			}
+			if !yield(record, nil) {
+				return
+			}
+		}
+	}
+}
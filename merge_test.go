@@ -0,0 +1,78 @@
+package typedcsv_test
+
+import (
+	"iter"
+	"strings"
+	"testing"
+
+	"github.com/hoshiumiarata/typedcsv"
+)
+
+type MergeTestRecord struct {
+	ID    string `csv:"id"`
+	Count int    `csv:"count"`
+}
+
+func TestMerge(t *testing.T) {
+	base := strings.NewReader("id,count\na,1\nb,2\nc,3\n")
+	updates := strings.NewReader("count,id\n20,b\n4,d\n5,d\n")
+	key := func(r MergeTestRecord) string { return r.ID }
+	sum := func(old, new MergeTestRecord) MergeTestRecord {
+		return MergeTestRecord{ID: old.ID, Count: old.Count + new.Count}
+	}
+	merged, err := collectMerge(typedcsv.Merge(base, updates, key, sum))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []MergeTestRecord{{"a", 1}, {"b", 22}, {"c", 3}, {"d", 9}}
+	if len(merged) != len(expected) {
+		t.Fatalf("Expected %v, got %v", expected, merged)
+	}
+	for i := range expected {
+		if merged[i] != expected[i] {
+			t.Fatalf("Expected %v, got %v", expected, merged)
+		}
+	}
+
+	merged, err = collectMerge(typedcsv.Merge(strings.NewReader(""), strings.NewReader("id,count\na,1\na,2\n"), key, nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(merged) != 1 || merged[0] != (MergeTestRecord{"a", 2}) {
+		t.Fatalf("Expected %v, got %v", []MergeTestRecord{{"a", 2}}, merged)
+	}
+
+	_, err = collectMerge(typedcsv.Merge(strings.NewReader("id,count\na,x\n"), strings.NewReader(""), key, nil))
+	if err == nil {
+		t.Fatal("Expected error, got nil")
+	}
+}
+
+func TestMergeStreamsBase(t *testing.T) {
+	base := strings.NewReader("id,count\na,1\nb,2\nc,x\n")
+	updates := strings.NewReader("id,count\nb,20\n")
+	var merged []MergeTestRecord
+	for record, err := range typedcsv.Merge(base, updates, func(r MergeTestRecord) string { return r.ID }, nil) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		merged = append(merged, record)
+		if len(merged) == 2 {
+			break
+		}
+	}
+	if len(merged) != 2 || merged[0] != (MergeTestRecord{"a", 1}) || merged[1] != (MergeTestRecord{"b", 20}) {
+		t.Fatalf("Expected %v, got %v", []MergeTestRecord{{"a", 1}, {"b", 20}}, merged)
+	}
+}
+
+func collectMerge(seq iter.Seq2[MergeTestRecord, error]) ([]MergeTestRecord, error) {
+	var merged []MergeTestRecord
+	for record, err := range seq {
+		if err != nil {
+			return nil, err
+		}
+		merged = append(merged, record)
+	}
+	return merged, nil
+}
@@ -0,0 +1,60 @@
+package typedcsv
+
+// A MetricsEventKind identifies the kind of a MetricsEvent.
+type MetricsEventKind int
+
+const (
+	// MetricsRecordDecoded is emitted when ReadRecord decodes a record.
+	MetricsRecordDecoded MetricsEventKind = iota
+	// MetricsRecordFailed is emitted when a record cannot be read or written. Err is set to the error.
+	MetricsRecordFailed
+	// MetricsBytesRead is emitted after each read from the underlying reader. Bytes is set to the number of bytes consumed.
+	MetricsBytesRead
+	// MetricsRecordEncoded is emitted when WriteRecord encodes a record.
+	MetricsRecordEncoded
+	// MetricsFlush is emitted when Flush is called. Err is set to any error reported by the underlying writer.
+	MetricsFlush
+)
+
+// String returns the name of the event kind.
+func (k MetricsEventKind) String() string {
+	switch k {
+	case MetricsRecordDecoded:
+		return "record_decoded"
+	case MetricsRecordFailed:
+		return "record_failed"
+	case MetricsBytesRead:
+		return "bytes_read"
+	case MetricsRecordEncoded:
+		return "record_encoded"
+	case MetricsFlush:
+		return "flush"
+	default:
+		return "unknown"
+	}
+}
+
+// A MetricsEvent describes something that happened while reading or writing.
+type MetricsEvent struct {
+	Kind  MetricsEventKind
+	Bytes int64
+	Err   error
+}
+
+// A MetricsHook receives MetricsEvents. It can be used to update counters of any metrics library.
+// The hook is called synchronously, so it should return quickly.
+type MetricsHook func(event MetricsEvent)
+
+// WithMetrics sets the hook that receives MetricsEvents.
+func WithMetrics(hook MetricsHook) Option {
+	return func(o *options) {
+		o.metrics = hook
+	}
+}
+
+func (o *options) emit(event MetricsEvent) {
+	if o.metrics == nil {
+		return
+	}
+	o.metrics(event)
+}
@@ -0,0 +1,77 @@
+package typedcsv_test
+
+import (
+	"bytes"
+	"encoding/csv"
+	"io"
+	"reflect"
+	"testing"
+
+	"github.com/hoshiumiarata/typedcsv"
+)
+
+func TestWithMetricsReader(t *testing.T) {
+	reader := bytes.Buffer{}
+	reader.WriteString("person_status\n")
+	reader.WriteString("active\n")
+	reader.WriteString("abcdef\n")
+	var events []typedcsv.MetricsEvent
+	hook := func(event typedcsv.MetricsEvent) {
+		events = append(events, event)
+	}
+	csvReader := typedcsv.NewReader[MarshalTextTestRecord](csv.NewReader(&reader), typedcsv.WithMetrics(hook))
+	err := csvReader.ReadHeader()
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = csvReader.ReadRecord()
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = csvReader.ReadRecord()
+	if err == nil {
+		t.Fatal("Expected error, got nil")
+	}
+	_, err = csvReader.ReadRecord()
+	if err != io.EOF {
+		t.Fatalf("Expected %v, got %v", io.EOF, err)
+	}
+	var kinds []typedcsv.MetricsEventKind
+	var bytesRead int64
+	for _, event := range events {
+		if event.Kind == typedcsv.MetricsBytesRead {
+			bytesRead += event.Bytes
+			continue
+		}
+		kinds = append(kinds, event.Kind)
+	}
+	expected := []typedcsv.MetricsEventKind{typedcsv.MetricsRecordDecoded, typedcsv.MetricsRecordFailed}
+	if !reflect.DeepEqual(kinds, expected) {
+		t.Fatalf("Expected %v, got %v", expected, kinds)
+	}
+	if bytesRead != 28 {
+		t.Fatalf("Expected %v, got %v", 28, bytesRead)
+	}
+}
+
+func TestWithMetricsWriter(t *testing.T) {
+	writer := bytes.Buffer{}
+	var kinds []typedcsv.MetricsEventKind
+	hook := func(event typedcsv.MetricsEvent) {
+		kinds = append(kinds, event.Kind)
+	}
+	csvWriter := typedcsv.NewWriter[MarshalTextTestRecord](csv.NewWriter(&writer), typedcsv.WithMetrics(hook))
+	err := csvWriter.WriteRecord(MarshalTextTestRecord{PersonStatus: PersonStatusActive})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = csvWriter.WriteRecord(MarshalTextTestRecord{PersonStatus: 100})
+	if err == nil {
+		t.Fatal("Expected error, got nil")
+	}
+	csvWriter.Flush()
+	expected := []typedcsv.MetricsEventKind{typedcsv.MetricsRecordEncoded, typedcsv.MetricsRecordFailed, typedcsv.MetricsFlush}
+	if !reflect.DeepEqual(kinds, expected) {
+		t.Fatalf("Expected %v, got %v", expected, kinds)
+	}
+}
@@ -0,0 +1,57 @@
+package typedcsv
+
+import (
+	"bytes"
+	"os"
+)
+
+// A MappedFile is a read-only view of a whole file in memory, memory-mapped on the platforms that support it.
+// Reading a mapped file avoids read system calls, as the pages are loaded by the kernel on first access,
+// which matters for very large local files.
+//
+// It is not zero-copy: a csv.Reader or a Tokenizer reading from Reader still copies the bytes into its own buffer,
+// and the decoded fields are new strings. On platforms without memory mapping, the whole file is read into memory.
+//
+// The bytes must not be used after Close.
+type MappedFile struct {
+	data  []byte
+	unmap func([]byte) error
+}
+
+// OpenMapped opens the file at path as a MappedFile.
+// On platforms without memory mapping, the file is read into memory instead.
+func OpenMapped(path string) (*MappedFile, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	stat, err := file.Stat()
+	if err != nil {
+		return nil, err
+	}
+	if stat.Size() == 0 {
+		return &MappedFile{}, nil
+	}
+	return mapFile(file, stat.Size())
+}
+
+// Bytes returns the contents of the file.
+func (f *MappedFile) Bytes() []byte {
+	return f.data
+}
+
+// Reader returns a new reader of the contents of the file, to be wrapped by a csv.Reader.
+func (f *MappedFile) Reader() *bytes.Reader {
+	return bytes.NewReader(f.data)
+}
+
+// Close unmaps the file. Calling Close more than once does nothing.
+func (f *MappedFile) Close() error {
+	data := f.data
+	f.data = nil
+	if data == nil || f.unmap == nil {
+		return nil
+	}
+	return f.unmap(data)
+}
@@ -0,0 +1,17 @@
+//go:build !(linux || darwin || freebsd || netbsd || openbsd || dragonfly)
+
+package typedcsv
+
+import (
+	"io"
+	"os"
+)
+
+func mapFile(file *os.File, size int64) (*MappedFile, error) {
+	data := make([]byte, size)
+	_, err := io.ReadFull(file, data)
+	if err != nil {
+		return nil, err
+	}
+	return &MappedFile{data: data}, nil
+}
@@ -0,0 +1,60 @@
+package typedcsv_test
+
+import (
+	"encoding/csv"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hoshiumiarata/typedcsv"
+)
+
+func TestOpenMapped(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "statuses.csv")
+	err := os.WriteFile(path, []byte("person_status\nactive\ninactive\n"), 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	file, err := typedcsv.OpenMapped(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	csvReader := typedcsv.NewReader[MarshalTextTestRecord](csv.NewReader(file.Reader()))
+	err = csvReader.ReadHeader()
+	if err != nil {
+		t.Fatal(err)
+	}
+	records, err := csvReader.ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 2 || records[1].PersonStatus != PersonStatusInactive {
+		t.Fatalf("Expected 2 records, got %v", records)
+	}
+	err = file.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if file.Bytes() != nil {
+		t.Fatalf("Expected nil, got %q", file.Bytes())
+	}
+
+	empty := filepath.Join(t.TempDir(), "empty.csv")
+	err = os.WriteFile(empty, nil, 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	file, err = typedcsv.OpenMapped(empty)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(file.Bytes()) != 0 {
+		t.Fatalf("Expected empty, got %q", file.Bytes())
+	}
+
+	_, err = typedcsv.OpenMapped(filepath.Join(t.TempDir(), "missing.csv"))
+	if !os.IsNotExist(err) {
+		t.Fatalf("Expected not exist error, got %v", err)
+	}
+}
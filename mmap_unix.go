@@ -0,0 +1,20 @@
+//go:build linux || darwin || freebsd || netbsd || openbsd || dragonfly
+
+package typedcsv
+
+import (
+	"fmt"
+	"os"
+	"syscall"
+)
+
+func mapFile(file *os.File, size int64) (*MappedFile, error) {
+	if int64(int(size)) != size {
+		return nil, fmt.Errorf("typedcsv: file %s is too large to be mapped", file.Name())
+	}
+	data, err := syscall.Mmap(int(file.Fd()), 0, int(size), syscall.PROT_READ, syscall.MAP_SHARED)
+	if err != nil {
+		return nil, &os.PathError{Op: "mmap", Path: file.Name(), Err: err}
+	}
+	return &MappedFile{data: data, unmap: syscall.Munmap}, nil
+}
@@ -0,0 +1,111 @@
+package typedcsv
+
+import (
+	"reflect"
+	"strings"
+	"unicode"
+)
+
+// A NameMapper maps struct fields to CSV column names.
+// It is used by both TypedCSVReader and TypedCSVWriter.
+type NameMapper interface {
+	// ColumnName returns the column name of the exported struct field.
+	// An empty result means that the field is not mapped to any column.
+	ColumnName(field reflect.StructField) string
+	// Canonical returns the canonical form of a column name.
+	// A header column matches a field when their canonical forms are equal.
+	Canonical(column string) string
+}
+
+// WithNameMapper sets the NameMapper. By default, TagNameMapper is used.
+// It overrides any previous WithTagKey option.
+func WithNameMapper(mapper NameMapper) Option {
+	return func(o *options) {
+		o.nameMapper = mapper
+	}
+}
+
+// TagNameMapper maps fields with a name tag to the tag value and matches columns exactly.
+//
+// TagKey is the key of the name tag. If it is empty, the "csv" tag is used as is.
+// Otherwise, the tag is interpreted like the "json" tag of encoding/json:
+// the name is the part before the first comma, and "-" means that the field is not mapped.
+type TagNameMapper struct {
+	TagKey string
+}
+
+// ColumnName returns the name tag value of the field.
+func (m TagNameMapper) ColumnName(field reflect.StructField) string {
+	return tagName(field, m.TagKey)
+}
+
+// Canonical returns the column unchanged.
+func (TagNameMapper) Canonical(column string) string {
+	return column
+}
+
+// SnakeCaseNameMapper maps fields to the name tag value if present, or to the snake_case form of the field name.
+// Columns are matched case-insensitively, treating spaces and hyphens as underscores.
+//
+// TagKey is the key of the name tag and is interpreted as in TagNameMapper.
+type SnakeCaseNameMapper struct {
+	TagKey string
+}
+
+// ColumnName returns the name tag value of the field, or the snake_case form of its name.
+func (m SnakeCaseNameMapper) ColumnName(field reflect.StructField) string {
+	if m.TagKey != "" && field.Tag.Get(m.TagKey) == "-" {
+		return ""
+	}
+	if name := tagName(field, m.TagKey); name != "" {
+		return name
+	}
+	return toSnakeCase(field.Name)
+}
+
+// Canonical returns the lower case form of the column with spaces and hyphens replaced by underscores.
+func (SnakeCaseNameMapper) Canonical(column string) string {
+	return strings.Map(func(r rune) rune {
+		if r == ' ' || r == '-' {
+			return '_'
+		}
+		return unicode.ToLower(r)
+	}, strings.TrimSpace(column))
+}
+
+// WithTagKey makes the default NameMapper read column names from the given struct tag instead of "csv",
+// for example to reuse existing "db" or "json" tags. It is equivalent to WithNameMapper(TagNameMapper{TagKey: key}).
+// Other tags, such as "null" or "time_format", are not affected.
+func WithTagKey(key string) Option {
+	return WithNameMapper(TagNameMapper{TagKey: key})
+}
+
+func tagName(field reflect.StructField, key string) string {
+	if key == "" {
+		name := field.Tag.Get(csvTag)
+		if hasRequiredOption(name) {
+			name, _, _ = strings.Cut(name, ",")
+		}
+		return name
+	}
+	name, _, _ := strings.Cut(field.Tag.Get(key), ",")
+	if name == "-" {
+		return ""
+	}
+	return name
+}
+
+func toSnakeCase(name string) string {
+	runes := []rune(name)
+	var builder strings.Builder
+	for i, r := range runes {
+		if unicode.IsUpper(r) {
+			if i > 0 && (unicode.IsLower(runes[i-1]) || (i+1 < len(runes) && unicode.IsLower(runes[i+1]) && unicode.IsUpper(runes[i-1]))) {
+				builder.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		builder.WriteRune(r)
+	}
+	return builder.String()
+}
@@ -0,0 +1,88 @@
+package typedcsv_test
+
+import (
+	"bytes"
+	"encoding/csv"
+	"reflect"
+	"testing"
+
+	"github.com/hoshiumiarata/typedcsv"
+)
+
+type NameMapperTestRecord struct {
+	FirstName  string `csv:"Given Name"`
+	LastName   string
+	HTTPStatus int
+}
+
+func TestSnakeCaseNameMapper(t *testing.T) {
+	writer := bytes.Buffer{}
+	csvWriter := typedcsv.NewWriter[NameMapperTestRecord](csv.NewWriter(&writer), typedcsv.WithNameMapper(typedcsv.SnakeCaseNameMapper{}))
+	err := csvWriter.WriteHeader()
+	if err != nil {
+		t.Fatal(err)
+	}
+	csvWriter.Flush()
+	expected := "Given Name,last_name,http_status\n"
+	if writer.String() != expected {
+		t.Fatalf("Expected %q, got %q", expected, writer.String())
+	}
+
+	reader := bytes.Buffer{}
+	reader.WriteString("HTTP-Status,given name,Last Name\n")
+	reader.WriteString("200,John,Smith\n")
+	csvReader := typedcsv.NewReader[NameMapperTestRecord](csv.NewReader(&reader), typedcsv.WithNameMapper(typedcsv.SnakeCaseNameMapper{}))
+	err = csvReader.ReadHeader()
+	if err != nil {
+		t.Fatal(err)
+	}
+	record, err := csvReader.ReadRecord()
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectedRecord := &NameMapperTestRecord{FirstName: "John", LastName: "Smith", HTTPStatus: 200}
+	if !reflect.DeepEqual(record, expectedRecord) {
+		t.Fatalf("Expected %v, got %v", expectedRecord, record)
+	}
+}
+
+type TagKeyTestRecord struct {
+	ID      int    `db:"id" json:"id"`
+	Name    string `db:"full_name" json:"name,omitempty"`
+	Ignored string `json:"-"`
+}
+
+func TestWithTagKey(t *testing.T) {
+	writer := bytes.Buffer{}
+	csvWriter := typedcsv.NewWriter[TagKeyTestRecord](csv.NewWriter(&writer), typedcsv.WithTagKey("json"))
+	err := csvWriter.WriteHeader()
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = csvWriter.WriteRecord(TagKeyTestRecord{ID: 1, Name: "John", Ignored: "x"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	csvWriter.Flush()
+	expected := "id,name\n1,John\n"
+	if writer.String() != expected {
+		t.Fatalf("Expected %q, got %q", expected, writer.String())
+	}
+
+	reader := bytes.Buffer{}
+	reader.WriteString("full_name,id\n")
+	reader.WriteString("Mary,2\n")
+	csvReader := typedcsv.NewReader[TagKeyTestRecord](csv.NewReader(&reader), typedcsv.WithTagKey("db"))
+	err = csvReader.ReadHeader()
+	if err != nil {
+		t.Fatal(err)
+	}
+	record, err := csvReader.ReadRecord()
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectedRecord := &TagKeyTestRecord{ID: 2, Name: "Mary"}
+	if !reflect.DeepEqual(record, expectedRecord) {
+		t.Fatalf("Expected %v, got %v", expectedRecord, record)
+	}
+}
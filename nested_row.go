@@ -0,0 +1,71 @@
+package typedcsv
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// decodeNested parses a cell holding a CSV row into the fields of a struct field tagged with `encoding:"csv"`.
+// The values are mapped to the fields of the struct in order. An empty cell leaves the struct zero.
+func (f *field) decodeNested(state *decodeState, fieldValue reflect.Value, value string) error {
+	if value == "" {
+		return nil
+	}
+	reader := csv.NewReader(strings.NewReader(value))
+	reader.Comma = f.nestedComma
+	values, err := reader.Read()
+	if err != nil {
+		return FieldParseError{Field: f.name, NestedError: err}
+	}
+	if len(values) != len(f.nested) {
+		return FieldParseError{Field: f.name, NestedError: fmt.Errorf("expected %d values, got %d", len(f.nested), len(values))}
+	}
+	holder := state.holder
+	state.holder = fieldValue
+	defer func() { state.holder = holder }()
+	for i := range f.nested {
+		nested := &f.nested[i]
+		err := nested.decode(state, nested.valueForDecode(fieldValue), values[i])
+		if err != nil {
+			return FieldParseError{Field: f.name, NestedError: err}
+		}
+	}
+	return nil
+}
+
+// encodeNested formats the fields of a struct field tagged with `encoding:"csv"` as a CSV row in one cell.
+func (f *field) encodeNested(fieldValue reflect.Value, validate bool) (string, error) {
+	values := make([]string, len(f.nested))
+	for i := range f.nested {
+		nested := &f.nested[i]
+		included, err := nested.included(fieldValue)
+		if err != nil {
+			return "", FieldFormatError{Field: f.name, NestedError: err}
+		}
+		nestedValue, ok := nested.valueForEncode(fieldValue)
+		if !ok || !included {
+			values[i] = nested.null
+			continue
+		}
+		value, err := nested.encodeWith(fieldValue, nestedValue, validate)
+		if err != nil {
+			return "", FieldFormatError{Field: f.name, NestedError: err}
+		}
+		values[i] = value
+	}
+	var buffer bytes.Buffer
+	writer := csv.NewWriter(&buffer)
+	writer.Comma = f.nestedComma
+	err := writer.Write(values)
+	if err == nil {
+		writer.Flush()
+		err = writer.Error()
+	}
+	if err != nil {
+		return "", FieldFormatError{Field: f.name, NestedError: err}
+	}
+	return strings.TrimSuffix(buffer.String(), "\n"), nil
+}
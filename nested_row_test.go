@@ -0,0 +1,71 @@
+package typedcsv_test
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/hoshiumiarata/typedcsv"
+)
+
+type NestedRowAddress struct {
+	Street string `csv:"street"`
+	Number int    `csv:"number"`
+}
+
+type NestedRowTestRecord struct {
+	Name    string           `csv:"name"`
+	Address NestedRowAddress `csv:"address" encoding:"csv"`
+	Backup  NestedRowAddress `csv:"backup" encoding:"csv" separator:"|"`
+}
+
+func TestNestedRow(t *testing.T) {
+	writer := bytes.Buffer{}
+	csvWriter := typedcsv.NewWriter[NestedRowTestRecord](csv.NewWriter(&writer))
+	record := NestedRowTestRecord{
+		Name:    "John",
+		Address: NestedRowAddress{Street: `Main"1",North`, Number: 5},
+		Backup:  NestedRowAddress{Street: "Side", Number: 7},
+	}
+	err := csvWriter.WriteHeader()
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = csvWriter.WriteRecord(record)
+	if err != nil {
+		t.Fatal(err)
+	}
+	csvWriter.Flush()
+	expected := "name,address,backup\nJohn,\"\"\"Main\"\"\"\"1\"\"\"\",North\"\",5\",Side|7\n"
+	if writer.String() != expected {
+		t.Fatalf("Expected %q, got %q", expected, writer.String())
+	}
+
+	csvReader := typedcsv.NewReader[NestedRowTestRecord](csv.NewReader(&writer))
+	err = csvReader.ReadHeader()
+	if err != nil {
+		t.Fatal(err)
+	}
+	read, err := csvReader.ReadRecord()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(*read, record) {
+		t.Fatalf("Expected %v, got %v", record, *read)
+	}
+
+	reader := bytes.Buffer{}
+	reader.WriteString("address\n\"a,b,c\"\n")
+	csvReader = typedcsv.NewReader[NestedRowTestRecord](csv.NewReader(&reader))
+	err = csvReader.ReadHeader()
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = csvReader.ReadRecord()
+	var fieldParseError typedcsv.FieldParseError
+	if !errors.As(err, &fieldParseError) || fieldParseError.Field != "address" {
+		t.Fatalf("Expected %T for address, got %v", fieldParseError, err)
+	}
+}
@@ -0,0 +1,209 @@
+package typedcsv
+
+import (
+	"bufio"
+	"encoding/csv"
+	"errors"
+	"io"
+)
+
+var (
+	// ErrMixedLineEndings is returned by a Normalizer in NormalizeStrict mode when a line ending differs from the first one.
+	ErrMixedLineEndings = errors.New("mixed line endings")
+	// ErrTrailingBlankLines is returned by a Normalizer in NormalizeStrict mode when the input ends with blank lines.
+	ErrTrailingBlankLines = errors.New("trailing blank lines")
+	// ErrControlCharacter is returned by a Normalizer in NormalizeStrict mode for a control character other than tab, CR and LF.
+	ErrControlCharacter = errors.New("control character")
+)
+
+// A NormalizeMode chooses what a Normalizer does with the issues it finds.
+type NormalizeMode int
+
+const (
+	// NormalizeReport passes the input through unchanged and only reports the issues.
+	NormalizeReport NormalizeMode = iota
+	// NormalizeSilently removes the byte order mark, the trailing blank lines and the control characters,
+	// and converts all the line endings to \n.
+	NormalizeSilently
+	// NormalizeStrict passes the input through and fails with a *csv.ParseError at the first issue,
+	// other than a byte order mark.
+	NormalizeStrict
+)
+
+// An InputReport describes the byte order mark, the line endings and the stray characters found by a Normalizer.
+type InputReport struct {
+	// BOM reports whether the input starts with a UTF-8 byte order mark.
+	BOM bool
+	// CRLF, LF and CR count the lines ending with \r\n, \n and a bare \r.
+	CRLF, LF, CR int
+	// TrailingBlankLines counts the blank lines at the end of the input.
+	TrailingBlankLines int
+	// ControlCharacters counts the control characters other than tab, CR and LF, such as NUL or DEL.
+	ControlCharacters int
+}
+
+// MixedLineEndings reports whether the input has more than one kind of line ending.
+func (r InputReport) MixedLineEndings() bool {
+	kinds := 0
+	for _, n := range []int{r.CRLF, r.LF, r.CR} {
+		if n > 0 {
+			kinds++
+		}
+	}
+	return kinds > 1
+}
+
+// A Normalizer is an io.Reader that detects mixed line endings, trailing blank lines and stray control characters
+// in the CSV input it reads from, which are otherwise invisible once parsed, and reports, removes or rejects them
+// depending on its mode:
+//
+//	normalizer := typedcsv.NewNormalizer(file, typedcsv.NormalizeSilently)
+//	reader := typedcsv.NewReader[T](csv.NewReader(normalizer))
+//
+// Line breaks in fields quoted with double quotes are values, not line endings: they are neither counted,
+// converted nor rejected.
+// The report is complete once the input is read to the end.
+type Normalizer struct {
+	reader  *bufio.Reader
+	mode    NormalizeMode
+	report  InputReport
+	ending  string
+	started bool
+	line    int
+	column  int
+	blank   []byte
+	blanks  int
+	quoted  bool
+	pending []byte
+	err     error
+}
+
+// NewNormalizer returns a new Normalizer reading from r in the given mode.
+func NewNormalizer(r io.Reader, mode NormalizeMode) *Normalizer {
+	return &Normalizer{reader: bufio.NewReader(r), mode: mode, line: 1}
+}
+
+// Report returns the issues found in the input read so far.
+func (n *Normalizer) Report() InputReport {
+	return n.report
+}
+
+// Read reads the normalized input into p.
+func (n *Normalizer) Read(p []byte) (int, error) {
+	for len(n.pending) < len(p) && n.err == nil {
+		n.err = n.next()
+	}
+	count := copy(p, n.pending)
+	n.pending = n.pending[count:]
+	if count > 0 {
+		return count, nil
+	}
+	return 0, n.err
+}
+
+// next processes the next character of the input.
+func (n *Normalizer) next() error {
+	if !n.started {
+		n.started = true
+		if prefix, err := n.reader.Peek(len(bom)); err == nil && string(prefix) == bom {
+			n.report.BOM = true
+			_, _ = n.reader.Discard(len(bom))
+			if n.mode != NormalizeSilently {
+				n.pending = append(n.pending, bom...)
+			}
+		}
+	}
+	c, err := n.reader.ReadByte()
+	if err == io.EOF {
+		n.report.TrailingBlankLines = n.blanks
+		if len(n.blank) > 0 {
+			if n.mode == NormalizeStrict {
+				return &csv.ParseError{StartLine: n.line, Line: n.line, Column: 1, Err: ErrTrailingBlankLines}
+			}
+			if n.mode == NormalizeReport {
+				n.pending = append(n.pending, n.blank...)
+			}
+		}
+		return io.EOF
+	}
+	if err != nil {
+		return err
+	}
+	switch {
+	case (c == '\r' || c == '\n') && n.quoted:
+		// A line break in a quoted field is part of its value, not a line ending.
+		n.flushBlank()
+		n.pending = append(n.pending, c)
+		if next, err := n.reader.Peek(1); c == '\r' && err == nil && next[0] == '\n' {
+			_, _ = n.reader.ReadByte()
+			n.pending = append(n.pending, '\n')
+		}
+		n.line++
+		n.column = 0
+		return nil
+	case c == '\r' || c == '\n':
+		ending := string(c)
+		if next, err := n.reader.Peek(1); c == '\r' && err == nil && next[0] == '\n' {
+			_, _ = n.reader.ReadByte()
+			ending = "\r\n"
+		}
+		return n.endLine(ending)
+	case c < 0x20 && c != '\t' || c == 0x7F:
+		n.column++
+		n.report.ControlCharacters++
+		switch n.mode {
+		case NormalizeStrict:
+			return &csv.ParseError{StartLine: n.line, Line: n.line, Column: n.column, Err: ErrControlCharacter}
+		case NormalizeSilently:
+			return nil
+		}
+	default:
+		if c == '"' {
+			// Doubled quotes in a quoted field toggle twice.
+			n.quoted = !n.quoted
+		}
+		if c&0xC0 != 0x80 {
+			n.column++
+		}
+	}
+	n.flushBlank()
+	n.pending = append(n.pending, c)
+	return nil
+}
+
+// endLine counts a line ending, and holds it if the line is blank, until a line with content shows it is not trailing.
+func (n *Normalizer) endLine(ending string) error {
+	switch ending {
+	case "\r\n":
+		n.report.CRLF++
+	case "\n":
+		n.report.LF++
+	default:
+		n.report.CR++
+	}
+	if n.ending == "" {
+		n.ending = ending
+	} else if ending != n.ending && n.mode == NormalizeStrict {
+		return &csv.ParseError{StartLine: n.line, Line: n.line, Column: n.column + 1, Err: ErrMixedLineEndings}
+	}
+	if n.mode == NormalizeSilently {
+		ending = "\n"
+	}
+	if n.column == 0 {
+		n.blank = append(n.blank, ending...)
+		n.blanks++
+	} else {
+		n.flushBlank()
+		n.pending = append(n.pending, ending...)
+	}
+	n.line++
+	n.column = 0
+	return nil
+}
+
+// flushBlank writes the held blank lines, which are followed by content.
+func (n *Normalizer) flushBlank() {
+	n.pending = append(n.pending, n.blank...)
+	n.blank = n.blank[:0]
+	n.blanks = 0
+}
@@ -0,0 +1,94 @@
+package typedcsv_test
+
+import (
+	"encoding/csv"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/hoshiumiarata/typedcsv"
+)
+
+func TestNormalizer(t *testing.T) {
+	input := "\ufeffa,b\r\n1,x\x00y\n\n2,3\r\n\r\n\n"
+	tests := []struct {
+		mode     typedcsv.NormalizeMode
+		expected string
+	}{
+		{typedcsv.NormalizeReport, input},
+		{typedcsv.NormalizeSilently, "a,b\n1,xy\n\n2,3\n"},
+	}
+	for _, test := range tests {
+		normalizer := typedcsv.NewNormalizer(strings.NewReader(input), test.mode)
+		data, err := io.ReadAll(normalizer)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(data) != test.expected {
+			t.Fatalf("Expected %q, got %q", test.expected, data)
+		}
+		expected := typedcsv.InputReport{BOM: true, CRLF: 3, LF: 3, TrailingBlankLines: 2, ControlCharacters: 1}
+		if report := normalizer.Report(); report != expected || !report.MixedLineEndings() {
+			t.Fatalf("Expected %+v, got %+v", expected, report)
+		}
+	}
+}
+
+func TestNormalizerQuotedLineBreaks(t *testing.T) {
+	input := "a,b\n1,\"x\r\n\"\"y\"\"\n\n\"\n2,3\n"
+	for _, mode := range []typedcsv.NormalizeMode{typedcsv.NormalizeReport, typedcsv.NormalizeSilently, typedcsv.NormalizeStrict} {
+		normalizer := typedcsv.NewNormalizer(strings.NewReader(input), mode)
+		data, err := io.ReadAll(normalizer)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(data) != input {
+			t.Fatalf("Expected %q, got %q", input, data)
+		}
+		if report := normalizer.Report(); report != (typedcsv.InputReport{LF: 3}) {
+			t.Fatalf("Expected %+v, got %+v", typedcsv.InputReport{LF: 3}, report)
+		}
+	}
+}
+
+func TestNormalizerWithReader(t *testing.T) {
+	normalizer := typedcsv.NewNormalizer(strings.NewReader("name,age\rAlice,30\r\r"), typedcsv.NormalizeSilently)
+	csvReader := typedcsv.NewReader[Person](csv.NewReader(normalizer))
+	err := csvReader.ReadHeader()
+	if err != nil {
+		t.Fatal(err)
+	}
+	records, err := csvReader.ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 1 || records[0].Name != "Alice" || records[0].Age != 30 {
+		t.Fatalf("Expected Alice, got %v", records)
+	}
+	if report := normalizer.Report(); report.CR != 3 || report.TrailingBlankLines != 1 {
+		t.Fatalf("Expected 3 CR and 1 trailing blank line, got %+v", report)
+	}
+}
+
+func TestNormalizerStrict(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected csv.ParseError
+	}{
+		{"a,b\r\n1,2\n", csv.ParseError{StartLine: 2, Line: 2, Column: 4, Err: typedcsv.ErrMixedLineEndings}},
+		{"a,b\n1,\x7f\n", csv.ParseError{StartLine: 2, Line: 2, Column: 3, Err: typedcsv.ErrControlCharacter}},
+		{"a,b\n1,2\n\n", csv.ParseError{StartLine: 4, Line: 4, Column: 1, Err: typedcsv.ErrTrailingBlankLines}},
+	}
+	for _, test := range tests {
+		_, err := io.ReadAll(typedcsv.NewNormalizer(strings.NewReader(test.input), typedcsv.NormalizeStrict))
+		var parseError *csv.ParseError
+		if !errors.As(err, &parseError) || *parseError != test.expected {
+			t.Fatalf("Expected %v for %q, got %v", &test.expected, test.input, err)
+		}
+	}
+	data, err := io.ReadAll(typedcsv.NewNormalizer(strings.NewReader("\ufeffa,b\r\n1,2"), typedcsv.NormalizeStrict))
+	if err != nil || string(data) != "\ufeffa,b\r\n1,2" {
+		t.Fatalf("Expected the input unchanged, got %q, %v", data, err)
+	}
+}
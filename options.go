@@ -0,0 +1,71 @@
+package typedcsv
+
+import (
+	"log/slog"
+	"reflect"
+	"time"
+)
+
+// An Option configures a TypedCSVReader or a TypedCSVWriter.
+type Option func(*options)
+
+type options struct {
+	logger       *slog.Logger
+	metrics      MetricsHook
+	report       *Report
+	translations map[string]*translation
+	rateLimiter  RateLimiter
+	dedupe       *dedupeOption
+	nameMapper   NameMapper
+	csvutil      bool
+	fieldTags    []fieldTagOverride
+	rowHash      *rowHashOption
+	intern       bool
+	internLimit  int
+	bom          bool
+	quoting      QuotePolicy
+	timeZone     *time.Location
+	locale       string
+	fieldOrder   bool
+	resolver     Resolver
+	columns      []string
+	noHeader     bool
+	appendMode   bool
+	dialect      dialect
+
+	tagDefaults  []tagDefault
+	trimSpace    bool
+	strictHeader bool
+	converters   map[reflect.Type]*converter
+	defaultFuncs map[string]func() any
+
+	valueCache      bool
+	valueCacheLimit int
+
+	flushRows     int
+	flushInterval time.Duration
+
+	warningHandler   func(Warning)
+	warningLimit     int
+	columnOrderCheck bool
+	roundTripAudit   bool
+}
+
+func newOptions(opts []Option) options {
+	o := options{
+		nameMapper:   TagNameMapper{},
+		warningLimit: defaultWarningLimit,
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// WithLogger sets the logger used to report warnings, such as unknown columns, missing columns or coerced values.
+// By default, nothing is logged.
+func WithLogger(logger *slog.Logger) Option {
+	return func(o *options) {
+		o.logger = logger
+	}
+}
@@ -0,0 +1,37 @@
+package typedcsv_test
+
+import (
+	"bytes"
+	"encoding/csv"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/hoshiumiarata/typedcsv"
+)
+
+func TestWithLogger(t *testing.T) {
+	reader := bytes.Buffer{}
+	reader.WriteString("name,age,extra\n")
+	reader.WriteString("John,,x\n")
+	logs := bytes.Buffer{}
+	logger := slog.New(slog.NewTextHandler(&logs, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	csvReader := typedcsv.NewReader[Person](csv.NewReader(&reader), typedcsv.WithLogger(logger))
+	err := csvReader.ReadHeader()
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = csvReader.ReadRecord()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, expected := range []string{
+		`msg="typedcsv: unknown column" column=extra index=2`,
+		`msg="typedcsv: missing column" column=birthday`,
+		`msg="typedcsv: coerced value to zero value" row=1 column=age value=""`,
+	} {
+		if !strings.Contains(logs.String(), expected) {
+			t.Fatalf("Expected %q in %q", expected, logs.String())
+		}
+	}
+}
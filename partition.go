@@ -0,0 +1,78 @@
+package typedcsv
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"hash/fnv"
+)
+
+// A PartitionedWriter writes each record to one of several csv.Writers chosen by a partition function,
+// so that large exports can be produced pre-partitioned for parallel loading.
+// All the partitions share the same Codec and header.
+type PartitionedWriter[T any] struct {
+	writers   []*TypedCSVWriter[T]
+	partition func(T) int
+}
+
+// NewPartitionedWriter returns a new PartitionedWriter that writes each record to writers[partition(record)].
+// Use PartitionByKey to partition the records by the hash of a key.
+func NewPartitionedWriter[T any](writers []*csv.Writer, partition func(T) int, opts ...Option) *PartitionedWriter[T] {
+	codec := NewCodec[T](opts...)
+	w := &PartitionedWriter[T]{
+		writers:   make([]*TypedCSVWriter[T], len(writers)),
+		partition: partition,
+	}
+	for i, writer := range writers {
+		w.writers[i] = codec.NewWriter(writer)
+	}
+	return w
+}
+
+// PartitionByKey returns a partition function for n partitions that hashes the key of each record with FNV-1a,
+// so that records with the same key are always written to the same partition.
+func PartitionByKey[T any](key func(T) string, n int) func(T) int {
+	return func(record T) int {
+		hash := fnv.New32a()
+		hash.Write([]byte(key(record)))
+		return int(hash.Sum32() % uint32(n))
+	}
+}
+
+// WriteHeader writes the CSV header to all the partitions, so that a partition without records is still a valid CSV file.
+func (w *PartitionedWriter[T]) WriteHeader() error {
+	for _, writer := range w.writers {
+		if err := writer.WriteHeader(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WriteRecord writes the record to its partition.
+// It returns an error if the partition function returns an index out of range,
+// and otherwise the same errors as TypedCSVWriter.WriteRecord.
+func (w *PartitionedWriter[T]) WriteRecord(record T) error {
+	index := w.partition(record)
+	if index < 0 || index >= len(w.writers) {
+		return fmt.Errorf("typedcsv: partition %d out of range [0, %d)", index, len(w.writers))
+	}
+	return w.writers[index].WriteRecord(record)
+}
+
+// Flush writes any buffered data of all the partitions to their csv.Writers.
+// To check if an error occurred during the Flush, call Error.
+func (w *PartitionedWriter[T]) Flush() {
+	for _, writer := range w.writers {
+		writer.Flush()
+	}
+}
+
+// Error reports the errors that have occurred in the partitions, joined with errors.Join.
+func (w *PartitionedWriter[T]) Error() error {
+	var errs []error
+	for _, writer := range w.writers {
+		errs = append(errs, writer.Error())
+	}
+	return errors.Join(errs...)
+}
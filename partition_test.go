@@ -0,0 +1,73 @@
+package typedcsv_test
+
+import (
+	"bytes"
+	"encoding/csv"
+	"strings"
+	"testing"
+
+	"github.com/hoshiumiarata/typedcsv"
+)
+
+type PartitionTestRecord struct {
+	Key   string `csv:"key"`
+	Value int    `csv:"value"`
+}
+
+func TestPartitionedWriter(t *testing.T) {
+	buffers := make([]bytes.Buffer, 3)
+	writers := make([]*csv.Writer, len(buffers))
+	for i := range buffers {
+		writers[i] = csv.NewWriter(&buffers[i])
+	}
+	partition := typedcsv.PartitionByKey(func(r PartitionTestRecord) string { return r.Key }, len(writers))
+	csvWriter := typedcsv.NewPartitionedWriter(writers, partition)
+	err := csvWriter.WriteHeader()
+	if err != nil {
+		t.Fatal(err)
+	}
+	keys := []string{"a", "b", "c", "d", "a", "e", "b"}
+	for i, key := range keys {
+		err := csvWriter.WriteRecord(PartitionTestRecord{Key: key, Value: i})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	csvWriter.Flush()
+	if err := csvWriter.Error(); err != nil {
+		t.Fatal(err)
+	}
+
+	rows := 0
+	for _, key := range keys {
+		expected := partition(PartitionTestRecord{Key: key})
+		if !strings.Contains(buffers[expected].String(), "\n"+key+",") {
+			t.Fatalf("Expected key %s in partition %d, got %q", key, expected, buffers[expected].String())
+		}
+	}
+	for i := range buffers {
+		lines := strings.Split(strings.TrimSuffix(buffers[i].String(), "\n"), "\n")
+		if lines[0] != "key,value" {
+			t.Fatalf("Expected a header in partition %d, got %q", i, buffers[i].String())
+		}
+		rows += len(lines) - 1
+	}
+	if rows != len(keys) {
+		t.Fatalf("Expected %d rows, got %d", len(keys), rows)
+	}
+}
+
+func TestPartitionedWriterOutOfRange(t *testing.T) {
+	csvWriter := typedcsv.NewPartitionedWriter([]*csv.Writer{csv.NewWriter(&bytes.Buffer{})}, func(PartitionTestRecord) int { return 1 })
+	err := csvWriter.WriteRecord(PartitionTestRecord{})
+	if err == nil {
+		t.Fatal("Expected an error")
+	}
+
+	csvWriter = typedcsv.NewPartitionedWriter([]*csv.Writer{csv.NewWriter(&ErrorWriter{})}, func(PartitionTestRecord) int { return 0 })
+	_ = csvWriter.WriteHeader()
+	csvWriter.Flush()
+	if err := csvWriter.Error(); err == nil || err.Error() != "write error" {
+		t.Fatalf("Expected write error, got %v", err)
+	}
+}
@@ -0,0 +1,49 @@
+package typedcsv
+
+import "io"
+
+// Stats are the counts reported by Pipe, Transform, Join and Pipeline.Run.
+type Stats struct {
+	// Read is the number of records read.
+	Read int
+	// Written is the number of records written.
+	Written int
+	// Skipped is the number of records filtered out by the function, or filtered out or invalid in Pipeline.Run.
+	Skipped int
+}
+
+// Pipe streams the records of r through fn into w with Transform.
+//
+// It reads the header of r if ReadHeader was not called yet, and writes the header of w.
+// An empty r, without even a header, is not an error: Pipe writes only the header of w and returns zero counts.
+// For each record read, fn returns the record to write and whether to write it; a record is skipped if it returns false
+// or a nil record.
+// Pipe flushes w when done. It stops at the first error returned by r, fn or w, and returns it with the counts so far.
+func Pipe[A, B any](r *TypedCSVReader[A], w *TypedCSVWriter[B], fn func(*A) (*B, bool, error)) (Stats, error) {
+	empty := false
+	if r.Header == nil {
+		err := r.ReadHeader()
+		if err == io.EOF {
+			empty = true
+		} else if err != nil {
+			return Stats{}, err
+		}
+	}
+	err := w.WriteHeader()
+	if err != nil {
+		return Stats{}, err
+	}
+	var stats Stats
+	if !empty {
+		skipNil := func(a *A) (*B, bool, error) {
+			b, ok, err := fn(a)
+			return b, ok && b != nil, err
+		}
+		stats, err = Transform[*A, *B](r, RecordWriterFunc[*B](func(b *B) error { return w.WriteRecord(*b) }), skipNil)
+		if err != nil {
+			return stats, err
+		}
+	}
+	w.Flush()
+	return stats, w.Error()
+}
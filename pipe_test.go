@@ -0,0 +1,96 @@
+package typedcsv_test
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/hoshiumiarata/typedcsv"
+)
+
+type PipeTestRecord struct {
+	Name  string `csv:"name"`
+	Count int    `csv:"count"`
+}
+
+type PipeTestOutput struct {
+	Name   string `csv:"name"`
+	Double int    `csv:"double"`
+}
+
+func TestPipe(t *testing.T) {
+	csvReader := typedcsv.NewReader[PipeTestRecord](csv.NewReader(strings.NewReader("name,count\na,1\nb,0\nc,3\n")))
+	writer := bytes.Buffer{}
+	csvWriter := typedcsv.NewWriter[PipeTestOutput](csv.NewWriter(&writer))
+	stats, err := typedcsv.Pipe(csvReader, csvWriter, func(r *PipeTestRecord) (*PipeTestOutput, bool, error) {
+		return &PipeTestOutput{Name: r.Name, Double: r.Count * 2}, r.Count > 0, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := typedcsv.Stats{Read: 3, Written: 2, Skipped: 1}
+	if stats != expected {
+		t.Fatalf("Expected %+v, got %+v", expected, stats)
+	}
+	if writer.String() != "name,double\na,2\nc,6\n" {
+		t.Fatalf("Expected %q, got %q", "name,double\na,2\nc,6\n", writer.String())
+	}
+
+	failure := errors.New("failure")
+	csvReader = typedcsv.NewReader[PipeTestRecord](csv.NewReader(strings.NewReader("name,count\na,1\nb,2\n")))
+	stats, err = typedcsv.Pipe(csvReader, csvWriter, func(r *PipeTestRecord) (*PipeTestOutput, bool, error) {
+		if r.Name == "b" {
+			return nil, false, failure
+		}
+		return &PipeTestOutput{Name: r.Name}, true, nil
+	})
+	if err != failure {
+		t.Fatalf("Expected %v, got %v", failure, err)
+	}
+	expected = typedcsv.Stats{Read: 2, Written: 1}
+	if stats != expected {
+		t.Fatalf("Expected %+v, got %+v", expected, stats)
+	}
+}
+
+func TestPipeEmpty(t *testing.T) {
+	csvReader := typedcsv.NewReader[PipeTestRecord](csv.NewReader(strings.NewReader("")))
+	writer := bytes.Buffer{}
+	csvWriter := typedcsv.NewWriter[PipeTestOutput](csv.NewWriter(&writer))
+	stats, err := typedcsv.Pipe(csvReader, csvWriter, func(r *PipeTestRecord) (*PipeTestOutput, bool, error) {
+		return &PipeTestOutput{Name: r.Name}, true, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats != (typedcsv.Stats{}) {
+		t.Fatalf("Expected %+v, got %+v", typedcsv.Stats{}, stats)
+	}
+	if writer.String() != "name,double\n" {
+		t.Fatalf("Expected %q, got %q", "name,double\n", writer.String())
+	}
+}
+
+func TestPipeNilRecord(t *testing.T) {
+	csvReader := typedcsv.NewReader[PipeTestRecord](csv.NewReader(strings.NewReader("name,count\na,1\nb,2\n")))
+	writer := bytes.Buffer{}
+	csvWriter := typedcsv.NewWriter[PipeTestOutput](csv.NewWriter(&writer))
+	stats, err := typedcsv.Pipe(csvReader, csvWriter, func(r *PipeTestRecord) (*PipeTestOutput, bool, error) {
+		if r.Name == "a" {
+			return nil, true, nil
+		}
+		return &PipeTestOutput{Name: r.Name, Double: r.Count * 2}, true, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := typedcsv.Stats{Read: 2, Written: 1, Skipped: 1}
+	if stats != expected {
+		t.Fatalf("Expected %+v, got %+v", expected, stats)
+	}
+	if writer.String() != "name,double\nb,4\n" {
+		t.Fatalf("Expected %q, got %q", "name,double\nb,4\n", writer.String())
+	}
+}
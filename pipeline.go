@@ -0,0 +1,519 @@
+package typedcsv
+
+import (
+	"bufio"
+	"bytes"
+	"cmp"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// A Pipeline is a declarative ingestion job: it reads the records of a source file with a schema,
+// which parses and validates them, transforms them, and writes them to one or more sinks.
+// It can be loaded from JSON or YAML with ParsePipeline, so that jobs can be assembled without Go code.
+type Pipeline struct {
+	Source PipelineSource `json:"source" yaml:"source"`
+	// Schema describes and validates the columns of the source.
+	Schema Schema `json:"schema" yaml:"schema"`
+	// Transforms are applied to each record in order.
+	Transforms []PipelineTransform `json:"transforms,omitempty" yaml:"transforms,omitempty"`
+	Sinks      []PipelineSink      `json:"sinks" yaml:"sinks"`
+	// SkipInvalid skips the records that cannot be read or are invalid instead of stopping at the first of them.
+	SkipInvalid bool `json:"skip_invalid,omitempty" yaml:"skip_invalid,omitempty"`
+
+	transforms []func(record map[string]any) bool
+	output     Schema
+}
+
+// A PipelineSource is the CSV file read by a Pipeline.
+type PipelineSource struct {
+	// Path is the path of the file. A file whose name ends with ".gz" is decompressed with gzip.
+	Path string `json:"path" yaml:"path"`
+	// Comma is the field delimiter, "," by default.
+	Comma string `json:"comma,omitempty" yaml:"comma,omitempty"`
+	// Comment is the comment character, if not empty.
+	Comment string `json:"comment,omitempty" yaml:"comment,omitempty"`
+	// LazyQuotes allows quotes in unquoted fields and non-doubled quotes in quoted fields.
+	LazyQuotes bool `json:"lazy_quotes,omitempty" yaml:"lazy_quotes,omitempty"`
+}
+
+// A PipelineTransform is one step of a Pipeline. Exactly one of its fields must be set.
+type PipelineTransform struct {
+	// Select keeps only the given columns, in this order.
+	Select []string `json:"select,omitempty" yaml:"select,omitempty"`
+	// Rename renames columns, from their old names to their new names.
+	Rename map[string]string `json:"rename,omitempty" yaml:"rename,omitempty"`
+	// Set sets columns to constant values, parsed with the type of their column.
+	// Columns that do not exist are added as string columns.
+	Set map[string]string `json:"set,omitempty" yaml:"set,omitempty"`
+	// Filter keeps only the records satisfying all the conditions.
+	Filter []PipelineCondition `json:"filter,omitempty" yaml:"filter,omitempty"`
+}
+
+// A PipelineCondition compares the value of a column with a constant value.
+type PipelineCondition struct {
+	Column string `json:"column" yaml:"column"`
+	// Op is one of "==", "!=", "<", "<=", ">" and ">=". An empty op means "==".
+	// Only "==" and "!=" are allowed for SchemaBool columns. Null values only satisfy "!=".
+	Op string `json:"op,omitempty" yaml:"op,omitempty"`
+	// Value is the constant value, parsed with the type of the column.
+	Value string `json:"value" yaml:"value"`
+}
+
+// A PipelineSink is a file written by a Pipeline.
+type PipelineSink struct {
+	// Path is the path of the file, which is created or truncated. A file whose name ends with ".gz" is compressed with gzip.
+	Path string `json:"path" yaml:"path"`
+	// Format is FormatCSV, FormatTSV or FormatJSONL.
+	// An empty format is chosen from the extension of the path, and defaults to FormatCSV.
+	Format Format `json:"format,omitempty" yaml:"format,omitempty"`
+}
+
+// LoadPipeline reads a JSON pipeline from r.
+func LoadPipeline(r io.Reader) (*Pipeline, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePipeline(data, json.Unmarshal)
+}
+
+// ParsePipeline parses a pipeline from data with the given unmarshal function,
+// for example json.Unmarshal or Unmarshal of gopkg.in/yaml.v3, and compiles it.
+func ParsePipeline(data []byte, unmarshal func([]byte, any) error) (*Pipeline, error) {
+	var pipeline Pipeline
+	err := unmarshal(data, &pipeline)
+	if err != nil {
+		return nil, err
+	}
+	err = pipeline.Compile()
+	if err != nil {
+		return nil, err
+	}
+	return &pipeline, nil
+}
+
+// Compile checks the pipeline and compiles its schema and transforms.
+// It must be called on pipelines that are not created by LoadPipeline or ParsePipeline before Run.
+func (p *Pipeline) Compile() error {
+	if p.Source.Path == "" {
+		return errors.New("typedcsv: pipeline source has no path")
+	}
+	if _, err := p.Source.rune("comma", p.Source.Comma); err != nil {
+		return err
+	}
+	if _, err := p.Source.rune("comment", p.Source.Comment); err != nil {
+		return err
+	}
+	err := p.Schema.Compile()
+	if err != nil {
+		return err
+	}
+	if len(p.Sinks) == 0 {
+		return errors.New("typedcsv: pipeline has no sinks")
+	}
+	for i := range p.Sinks {
+		sink := &p.Sinks[i]
+		if sink.Path == "" {
+			return fmt.Errorf("typedcsv: pipeline sink %d has no path", i)
+		}
+		if sink.Format == "" {
+			sink.Format = sink.defaultFormat()
+		}
+		switch sink.Format {
+		case FormatCSV, FormatTSV, FormatJSONL:
+		default:
+			return fmt.Errorf("typedcsv: pipeline sink %d has unsupported format %q", i, sink.Format)
+		}
+	}
+	columns := append([]SchemaColumn(nil), p.Schema.Columns...)
+	p.transforms = nil
+	for i, transform := range p.Transforms {
+		fn, err := transform.compile(&columns)
+		if err != nil {
+			return fmt.Errorf("typedcsv: pipeline transform %d: %w", i, err)
+		}
+		p.transforms = append(p.transforms, fn)
+	}
+	p.output = Schema{Columns: columns}
+	return nil
+}
+
+// Run reads the source, and writes its records transformed to all the sinks.
+// It returns the number of records read, written to the sinks and skipped, either filtered out or invalid.
+//
+// It stops at the first error, or when ctx is done. With SkipInvalid, the records that cannot be read or are invalid are skipped,
+// and their errors are returned joined with errors.Join after all the records have been read, like ReadAllLenient.
+// The sinks are flushed and closed in all cases.
+func (p *Pipeline) Run(ctx context.Context) (stats Stats, err error) {
+	source, closers, err := openFile(p.Source.Path)
+	if err != nil {
+		return stats, err
+	}
+	defer func() {
+		err = errors.Join(err, closeAll(closers))
+	}()
+	csvReader := csv.NewReader(skipBOM(source))
+	csvReader.Comma, _ = p.Source.rune("comma", p.Source.Comma)
+	csvReader.Comment, _ = p.Source.rune("comment", p.Source.Comment)
+	csvReader.LazyQuotes = p.Source.LazyQuotes
+	csvReader.FieldsPerRecord = -1
+	reader := NewDynamicReader(csvReader, &p.Schema)
+	err = reader.ReadHeader()
+	if err != nil {
+		return stats, err
+	}
+
+	var writers []RecordWriter[map[string]any]
+	for i := range p.Sinks {
+		writer, closeSink, err := p.Sinks[i].open(&p.output)
+		if err != nil {
+			return stats, err
+		}
+		defer func() {
+			err = errors.Join(err, closeSink())
+		}()
+		writers = append(writers, writer)
+	}
+	writer := MultiWriter(writers...)
+
+	var errs []error
+	for {
+		if err := ctx.Err(); err != nil {
+			return stats, err
+		}
+		record, err := reader.ReadRecord()
+		if errors.Is(err, io.EOF) {
+			return stats, errors.Join(errs...)
+		}
+		if err != nil {
+			var fieldParseError FieldParseError
+			var parseError *csv.ParseError
+			if !p.SkipInvalid || !(errors.As(err, &fieldParseError) || errors.As(err, &parseError)) {
+				return stats, err
+			}
+			stats.Read++
+			stats.Skipped++
+			errs = append(errs, err)
+			continue
+		}
+		stats.Read++
+		if !p.transform(record) {
+			stats.Skipped++
+			continue
+		}
+		err = writer.WriteRecord(record)
+		if err != nil {
+			return stats, err
+		}
+		stats.Written++
+	}
+}
+
+// transform applies the transforms to the record, and reports whether it is kept.
+func (p *Pipeline) transform(record map[string]any) bool {
+	for _, fn := range p.transforms {
+		if !fn(record) {
+			return false
+		}
+	}
+	return true
+}
+
+// rune returns the single character of a dialect option, or 0 if it is empty.
+func (s *PipelineSource) rune(name, value string) (rune, error) {
+	if value == "" {
+		if name == "comma" {
+			return ',', nil
+		}
+		return 0, nil
+	}
+	r, size := utf8.DecodeRuneInString(value)
+	if size != len(value) || r == utf8.RuneError {
+		return 0, fmt.Errorf("typedcsv: pipeline source %s %q is not a single character", name, value)
+	}
+	return r, nil
+}
+
+// compile returns the function applying the transform to a record,
+// and updates the columns to the columns of the transformed records.
+func (t *PipelineTransform) compile(columns *[]SchemaColumn) (func(record map[string]any) bool, error) {
+	set := 0
+	for _, ok := range []bool{t.Select != nil, t.Rename != nil, t.Set != nil, t.Filter != nil} {
+		if ok {
+			set++
+		}
+	}
+	if set != 1 {
+		return nil, errors.New("exactly one of select, rename, set and filter must be set")
+	}
+	switch {
+	case t.Select != nil:
+		return compileSelect(t.Select, columns)
+	case t.Rename != nil:
+		return compileRename(t.Rename, columns)
+	case t.Set != nil:
+		return compileSet(t.Set, columns)
+	}
+	return compileFilter(t.Filter, *columns)
+}
+
+func findColumn(columns []SchemaColumn, name string) (*SchemaColumn, error) {
+	for i := range columns {
+		if columns[i].Name == name {
+			return &columns[i], nil
+		}
+	}
+	return nil, fmt.Errorf("unknown column '%s'", name)
+}
+
+func compileSelect(names []string, columns *[]SchemaColumn) (func(record map[string]any) bool, error) {
+	selected := make([]SchemaColumn, 0, len(names))
+	for _, name := range names {
+		column, err := findColumn(*columns, name)
+		if err != nil {
+			return nil, err
+		}
+		selected = append(selected, *column)
+	}
+	*columns = selected
+	return func(record map[string]any) bool {
+		for name := range record {
+			if _, err := findColumn(selected, name); err != nil {
+				delete(record, name)
+			}
+		}
+		return true
+	}, nil
+}
+
+func compileRename(names map[string]string, columns *[]SchemaColumn) (func(record map[string]any) bool, error) {
+	// The columns are looked up by their original names, so that renames such as swaps do not depend on the map order.
+	renamed := append([]SchemaColumn(nil), *columns...)
+	for from, to := range names {
+		i := slices.IndexFunc(*columns, func(column SchemaColumn) bool { return column.Name == from })
+		if i < 0 {
+			return nil, fmt.Errorf("unknown column '%s'", from)
+		}
+		renamed[i].Name = to
+	}
+	schema := Schema{Columns: renamed}
+	if err := schema.Compile(); err != nil {
+		return nil, err
+	}
+	*columns = renamed
+	return func(record map[string]any) bool {
+		values := make(map[string]any, len(names))
+		for from := range names {
+			if value, ok := record[from]; ok {
+				values[from] = value
+				delete(record, from)
+			}
+		}
+		for from, value := range values {
+			record[names[from]] = value
+		}
+		return true
+	}, nil
+}
+
+func compileSet(values map[string]string, columns *[]SchemaColumn) (func(record map[string]any) bool, error) {
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	parsed := make(map[string]any, len(values))
+	for _, name := range names {
+		column, err := findColumn(*columns, name)
+		if err != nil {
+			*columns = append(*columns, SchemaColumn{Name: name, Type: SchemaString})
+			column = &(*columns)[len(*columns)-1]
+		}
+		value, err := column.parse(values[name])
+		if err != nil {
+			return nil, fmt.Errorf("column '%s': %w", name, err)
+		}
+		parsed[name] = value
+	}
+	return func(record map[string]any) bool {
+		for name, value := range parsed {
+			record[name] = value
+		}
+		return true
+	}, nil
+}
+
+func compileFilter(conditions []PipelineCondition, columns []SchemaColumn) (func(record map[string]any) bool, error) {
+	tests := make([]func(record map[string]any) bool, len(conditions))
+	for i, condition := range conditions {
+		column, err := findColumn(columns, condition.Column)
+		if err != nil {
+			return nil, err
+		}
+		// The constant is parsed with the type of the column, but without its validations.
+		constant := SchemaColumn{Name: column.Name, Type: column.Type, Format: column.Format, location: column.location}
+		value, err := constant.parse(condition.Value)
+		if err != nil {
+			return nil, fmt.Errorf("column '%s': %w", column.Name, err)
+		}
+		var accept func(int) bool
+		switch condition.Op {
+		case "", "==":
+			accept = func(c int) bool { return c == 0 }
+		case "!=":
+			accept = func(c int) bool { return c != 0 }
+		case "<":
+			accept = func(c int) bool { return c < 0 }
+		case "<=":
+			accept = func(c int) bool { return c <= 0 }
+		case ">":
+			accept = func(c int) bool { return c > 0 }
+		case ">=":
+			accept = func(c int) bool { return c >= 0 }
+		default:
+			return nil, fmt.Errorf("column '%s': unknown op '%s'", column.Name, condition.Op)
+		}
+		if column.Type == SchemaBool && condition.Op != "" && condition.Op != "==" && condition.Op != "!=" {
+			return nil, fmt.Errorf("column '%s': op '%s' is not allowed for booleans", column.Name, condition.Op)
+		}
+		name, op := column.Name, condition.Op
+		tests[i] = func(record map[string]any) bool {
+			actual := record[name]
+			if actual == nil {
+				return op == "!="
+			}
+			return accept(compareValues(actual, value))
+		}
+	}
+	return func(record map[string]any) bool {
+		for _, test := range tests {
+			if !test(record) {
+				return false
+			}
+		}
+		return true
+	}, nil
+}
+
+// compareValues compares two values of the same column type. Booleans are only compared for equality.
+func compareValues(a, b any) int {
+	switch a := a.(type) {
+	case string:
+		return strings.Compare(a, b.(string))
+	case int64:
+		return cmp.Compare(a, b.(int64))
+	case uint64:
+		return cmp.Compare(a, b.(uint64))
+	case float64:
+		return cmp.Compare(a, b.(float64))
+	case time.Duration:
+		return cmp.Compare(a, b.(time.Duration))
+	case time.Time:
+		return a.Compare(b.(time.Time))
+	case bool:
+		if a == b.(bool) {
+			return 0
+		}
+	}
+	return 1
+}
+
+// defaultFormat returns the format of the sink chosen from the extension of its path.
+func (s *PipelineSink) defaultFormat() Format {
+	extension := filepath.Ext(strings.TrimSuffix(s.Path, ".gz"))
+	switch extension {
+	case FormatTSV.Extension():
+		return FormatTSV
+	case FormatJSONL.Extension(), ".ndjson":
+		return FormatJSONL
+	}
+	return FormatCSV
+}
+
+// open creates the file of the sink and returns a writer of the records of the schema,
+// and the function flushing the writer and closing the file.
+func (s *PipelineSink) open(schema *Schema) (RecordWriter[map[string]any], func() error, error) {
+	sink, closers, err := createFile(s.Path)
+	if err != nil {
+		return nil, nil, err
+	}
+	if s.Format == FormatJSONL {
+		writer := &jsonlRecordWriter{writer: bufio.NewWriter(sink), schema: schema}
+		return writer, func() error {
+			return errors.Join(writer.writer.Flush(), closeAll(closers))
+		}, nil
+	}
+	csvWriter := csv.NewWriter(sink)
+	if s.Format == FormatTSV {
+		csvWriter.Comma = '\t'
+	}
+	writer := NewDynamicWriter(csvWriter, schema)
+	closeSink := func() error {
+		writer.Flush()
+		return errors.Join(writer.Error(), closeAll(closers))
+	}
+	err = writer.WriteHeader()
+	if err != nil {
+		return nil, nil, errors.Join(err, closeSink())
+	}
+	return writer, closeSink, nil
+}
+
+// A jsonlRecordWriter writes records described by a Schema as JSON objects, one per line, with the keys in column order.
+// Numbers and booleans are written as JSON numbers and booleans, null values as null, and the other values as their formatted strings.
+type jsonlRecordWriter struct {
+	writer *bufio.Writer
+	schema *Schema
+	line   bytes.Buffer
+}
+
+func (w *jsonlRecordWriter) WriteRecord(record map[string]any) error {
+	w.line.Reset()
+	encoder := json.NewEncoder(&w.line)
+	encoder.SetEscapeHTML(false)
+	encode := func(value any) error {
+		err := encoder.Encode(value)
+		if err == nil {
+			w.line.Truncate(w.line.Len() - 1)
+		}
+		return err
+	}
+	w.line.WriteByte('{')
+	for i := range w.schema.Columns {
+		column := &w.schema.Columns[i]
+		if i > 0 {
+			w.line.WriteByte(',')
+		}
+		encode(column.Name)
+		w.line.WriteByte(':')
+		value := record[column.Name]
+		text, err := column.format(value)
+		if err == nil {
+			switch value.(type) {
+			case nil:
+				w.line.WriteString("null")
+			case int64, uint64, float64, bool:
+				err = encode(value)
+			default:
+				err = encode(text)
+			}
+		}
+		if err != nil {
+			return FieldFormatError{Field: column.Name, NestedError: err}
+		}
+	}
+	w.line.WriteString("}\n")
+	_, err := w.writer.Write(w.line.Bytes())
+	return err
+}
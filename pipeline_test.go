@@ -0,0 +1,142 @@
+package typedcsv_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hoshiumiarata/typedcsv"
+)
+
+func TestPipeline(t *testing.T) {
+	dir := t.TempDir()
+	input := "# exported\nid;name;age;status\n1;Alice;30;active\n2;Bob;x;active\n3;Carol;17;active\n4;Dave;45;inactive\n5;Eve <e>;52;active\n"
+	if err := os.WriteFile(filepath.Join(dir, "in.csv"), []byte(input), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	spec := `{
+		"source": {"path": "` + filepath.Join(dir, "in.csv") + `", "comma": ";", "comment": "#"},
+		"schema": {"columns": [
+			{"name": "id", "type": "int", "required": true},
+			{"name": "name"},
+			{"name": "age", "type": "int", "min": 0},
+			{"name": "status", "enum": ["active", "inactive"]}
+		]},
+		"skip_invalid": true,
+		"transforms": [
+			{"filter": [{"column": "age", "op": ">=", "value": "18"}, {"column": "status", "value": "active"}]},
+			{"select": ["id", "name", "age"]},
+			{"rename": {"name": "full_name"}},
+			{"set": {"source": "crm"}}
+		],
+		"sinks": [
+			{"path": "` + filepath.Join(dir, "out.tsv") + `"},
+			{"path": "` + filepath.Join(dir, "out.jsonl.gz") + `"},
+			{"path": "` + filepath.Join(dir, "out.txt") + `", "format": "jsonl"}
+		]
+	}`
+	pipeline, err := typedcsv.ParsePipeline([]byte(spec), json.Unmarshal)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stats, err := pipeline.Run(context.Background())
+	expected := "typedcsv: error parsing field 'age': strconv.ParseInt: parsing \"x\": invalid syntax"
+	if err == nil || err.Error() != expected {
+		t.Fatalf("Expected %v, got %v", expected, err)
+	}
+	if stats != (typedcsv.Stats{Read: 5, Written: 2, Skipped: 3}) {
+		t.Fatalf("Expected 5 read, 2 written and 3 skipped, got %+v", stats)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, "out.tsv"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected = "id\tfull_name\tage\tsource\n1\tAlice\t30\tcrm\n5\tEve <e>\t52\tcrm\n"
+	if string(data) != expected {
+		t.Fatalf("Expected %q, got %q", expected, data)
+	}
+	data, err = os.ReadFile(filepath.Join(dir, "out.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected = `{"id":1,"full_name":"Alice","age":30,"source":"crm"}` + "\n" + `{"id":5,"full_name":"Eve <e>","age":52,"source":"crm"}` + "\n"
+	if string(data) != expected {
+		t.Fatalf("Expected %q, got %q", expected, data)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "out.jsonl.gz")); err != nil {
+		t.Fatal(err)
+	}
+
+	pipeline.SkipInvalid = false
+	stats, err = pipeline.Run(context.Background())
+	var fieldParseError typedcsv.FieldParseError
+	if !errors.As(err, &fieldParseError) || stats.Read != 1 || stats.Written != 1 {
+		t.Fatalf("Expected a FieldParseError after 1 record, got %+v and %v", stats, err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := pipeline.Run(ctx); !errors.Is(err, context.Canceled) {
+		t.Fatalf("Expected %v, got %v", context.Canceled, err)
+	}
+}
+
+func TestPipelineCompile(t *testing.T) {
+	tests := []struct {
+		spec     string
+		expected string
+	}{
+		{`{"source": {}, "sinks": [{"path": "out.csv"}]}`, "typedcsv: pipeline source has no path"},
+		{`{"source": {"path": "in.csv", "comma": ";;"}, "sinks": [{"path": "out.csv"}]}`, `typedcsv: pipeline source comma ";;" is not a single character`},
+		{`{"source": {"path": "in.csv"}}`, "typedcsv: pipeline has no sinks"},
+		{`{"source": {"path": "in.csv"}, "sinks": [{"path": "out.xlsx", "format": "xlsx"}]}`, `typedcsv: pipeline sink 0 has unsupported format "xlsx"`},
+		{`{"source": {"path": "in.csv"}, "schema": {"columns": [{"name": "a"}]}, "sinks": [{"path": "out.csv"}], "transforms": [{}]}`,
+			"typedcsv: pipeline transform 0: exactly one of select, rename, set and filter must be set"},
+		{`{"source": {"path": "in.csv"}, "schema": {"columns": [{"name": "a"}]}, "sinks": [{"path": "out.csv"}], "transforms": [{"select": ["a"]}, {"select": ["b"]}]}`,
+			"typedcsv: pipeline transform 1: unknown column 'b'"},
+		{`{"source": {"path": "in.csv"}, "schema": {"columns": [{"name": "a"}, {"name": "b"}]}, "sinks": [{"path": "out.csv"}], "transforms": [{"rename": {"a": "b"}}]}`,
+			"typedcsv: pipeline transform 0: typedcsv: schema column 'b' is duplicated"},
+		{`{"source": {"path": "in.csv"}, "schema": {"columns": [{"name": "a", "type": "bool"}]}, "sinks": [{"path": "out.csv"}], "transforms": [{"filter": [{"column": "a", "op": "<", "value": "true"}]}]}`,
+			"typedcsv: pipeline transform 0: column 'a': op '<' is not allowed for booleans"},
+		{`{"source": {"path": "in.csv"}, "schema": {"columns": [{"name": "a", "type": "int"}]}, "sinks": [{"path": "out.csv"}], "transforms": [{"set": {"a": "x"}}]}`,
+			"typedcsv: pipeline transform 0: column 'a': strconv.ParseInt: parsing \"x\": invalid syntax"},
+	}
+	for _, test := range tests {
+		_, err := typedcsv.LoadPipeline(strings.NewReader(test.spec))
+		if err == nil || err.Error() != test.expected {
+			t.Fatalf("%s: Expected %v, got %v", test.spec, test.expected, err)
+		}
+	}
+}
+
+func TestPipelineRenameSwap(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "in.csv"), []byte("a,b,c\n1,2,3\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	pipeline := typedcsv.Pipeline{
+		Source:     typedcsv.PipelineSource{Path: filepath.Join(dir, "in.csv")},
+		Schema:     typedcsv.Schema{Columns: []typedcsv.SchemaColumn{{Name: "a"}, {Name: "b"}, {Name: "c"}}},
+		Transforms: []typedcsv.PipelineTransform{{Rename: map[string]string{"a": "b", "b": "a", "c": "d"}}},
+		Sinks:      []typedcsv.PipelineSink{{Path: filepath.Join(dir, "out.csv")}},
+	}
+	for i := 0; i < 20; i++ {
+		if err := pipeline.Compile(); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := pipeline.Run(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+		data, err := os.ReadFile(filepath.Join(dir, "out.csv"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if expected := "b,a,d\n1,2,3\n"; string(data) != expected {
+			t.Fatalf("Expected %q, got %q", expected, data)
+		}
+	}
+}
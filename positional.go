@@ -0,0 +1,124 @@
+package typedcsv
+
+import (
+	"fmt"
+	"strconv"
+)
+
+const indexTag = "index"
+
+// UseStructFieldOrder makes TypedCSVReader map the columns to the fields by position rather than by name:
+// the first column to the first field, and so on, following the declaration order of the fields,
+// with the fields of embedded structs in place. The "index" tag value of a field sets its position explicitly.
+// Files without a header can then be read without calling ReadHeader.
+// TypedCSVWriter writes the columns of fields mapped by position in position order.
+// Codec.Err reports an "index" tag value equal to the position of another field in the struct order.
+func UseStructFieldOrder() Option {
+	return func(o *options) {
+		o.fieldOrder = true
+	}
+}
+
+// parseIndexTag parses the zero-based column position of an "index" tag value.
+func parseIndexTag(value string) (int, error) {
+	position, err := strconv.Atoi(value)
+	if err != nil || position < 0 {
+		return 0, fmt.Errorf("invalid index %q, expected a non-negative integer", value)
+	}
+	return position, nil
+}
+
+// positionsOf returns the column positions of the fields if they are mapped by position, because of UseStructFieldOrder
+// or an "index" tag, or nil if they are mapped by name. Fields without a position are mapped to -1.
+// It returns TagErrors for the "index" tag values equal to the position of another field in the struct order;
+// checkTags reports the "index" tag values used twice.
+func positionsOf(fields []field, o *options) ([]int, []error) {
+	positional := o.fieldOrder
+	for i := range fields {
+		positional = positional || fields[i].hasPosition
+	}
+	if !positional {
+		return nil, nil
+	}
+	positions := make([]int, len(fields))
+	implicit := make(map[int]string)
+	for i := range fields {
+		switch {
+		case fields[i].hasPosition && fields[i].positionErr == nil:
+			positions[i] = fields[i].position
+		case o.fieldOrder && !fields[i].hasPosition:
+			positions[i] = i
+			implicit[i] = fields[i].path
+		default:
+			positions[i] = -1
+		}
+	}
+	var errs []error
+	for i := range fields {
+		f := &fields[i]
+		if other, ok := implicit[positions[i]]; ok && f.hasPosition && f.positionErr == nil {
+			errs = append(errs, TagError{Field: f.path, Tag: indexTag, Message: fmt.Sprintf("index %d is also the position of field %s in the struct order", f.position, other)})
+		}
+	}
+	return positions, errs
+}
+
+// layoutOf returns, for each column written by TypedCSVWriter, the index of its field or -1 for an empty column,
+// or nil if the columns are written in the order of the fields. Fields mapped by position are written at their positions,
+// with empty columns for the positions without a field, and the other fields are written after them.
+func layoutOf(positions []int) []int {
+	if positions == nil {
+		return nil
+	}
+	width := 0
+	for _, position := range positions {
+		width = max(width, position+1)
+	}
+	layout := make([]int, width)
+	for column := range layout {
+		layout[column] = -1
+	}
+	for i := len(positions) - 1; i >= 0; i-- {
+		// Of the fields using the same position, reported by Codec.Err, the first one is written.
+		if position := positions[i]; position >= 0 {
+			layout[position] = i
+		}
+	}
+	for i, position := range positions {
+		if position < 0 {
+			layout = append(layout, i)
+		}
+	}
+	for column, i := range layout {
+		if column != i {
+			return layout
+		}
+	}
+	return nil
+}
+
+// arrange returns the values of the fields, in the order of the fields, in the order of the columns written by TypedCSVWriter.
+func (c *Codec[T]) arrange(values []string) []string {
+	if c.layout == nil {
+		return values
+	}
+	arranged := make([]string, len(c.layout))
+	for column, i := range c.layout {
+		if i >= 0 {
+			arranged[column] = values[i]
+		}
+	}
+	return arranged
+}
+
+// positionalHeader returns the header of a file read by position without a header row,
+// mapping the column name of each positioned field to its position.
+func (c *Codec[T]) positionalHeader() map[string]int {
+	header := make(map[string]int, len(c.fields))
+	for i, position := range c.positions {
+		if position >= 0 {
+			header[c.fields[i].name] = position
+		}
+	}
+	return header
+}
@@ -0,0 +1,172 @@
+package typedcsv_test
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hoshiumiarata/typedcsv"
+)
+
+type IndexTestRecord struct {
+	Name  string `csv:"name" index:"2"`
+	Age   int    `csv:"age" index:"0"`
+	Email string `csv:"email"`
+}
+
+type FieldOrderTestRecord struct {
+	Name string `csv:"name"`
+	Age  int    `csv:"age"`
+	City string `csv:"city"`
+}
+
+func TestHeaderlessIndex(t *testing.T) {
+	csvReader := typedcsv.NewReader[IndexTestRecord](csv.NewReader(bytes.NewBufferString("30,x,John\n25,y,Jane\n")))
+	records, err := csvReader.ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []IndexTestRecord{{Name: "John", Age: 30}, {Name: "Jane", Age: 25}}
+	for i := range expected {
+		if !reflect.DeepEqual(*records[i], expected[i]) {
+			t.Fatalf("Expected %v, got %v", expected[i], *records[i])
+		}
+	}
+}
+
+func TestRewindHeaderless(t *testing.T) {
+	source := strings.NewReader("30,x,John\n25,y,Jane\n")
+	csvReader := typedcsv.NewReader[IndexTestRecord](csv.NewReader(source))
+	first, err := csvReader.ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = csvReader.Rewind(source)
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := csvReader.ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(first) != 2 || !reflect.DeepEqual(first, second) {
+		t.Fatalf("Expected %v, got %v", first, second)
+	}
+
+	source = strings.NewReader("Jane,25,Rome\nJohn,30,Paris\n")
+	fastReader := typedcsv.NewFastReader[FieldOrderTestRecord](source, typedcsv.UseStructFieldOrder())
+	if _, err := fastReader.ReadRecord(); err != nil {
+		t.Fatal(err)
+	}
+	err = fastReader.Rewind(source)
+	if err != nil {
+		t.Fatal(err)
+	}
+	records, err := fastReader.ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 2 || records[0].Name != "Jane" {
+		t.Fatalf("Expected 2 records from Jane, got %v", records)
+	}
+}
+
+func TestUseStructFieldOrder(t *testing.T) {
+	csvReader := typedcsv.NewReader[FieldOrderTestRecord](csv.NewReader(bytes.NewBufferString("n,a,c\nJohn,30,Paris\n")), typedcsv.UseStructFieldOrder())
+	if err := csvReader.ReadHeader(); err != nil {
+		t.Fatal(err)
+	}
+	if warnings := csvReader.Warnings(); len(warnings) != 0 {
+		t.Fatalf("Expected no warnings, got %v", warnings)
+	}
+	record, err := csvReader.ReadRecord()
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := FieldOrderTestRecord{Name: "John", Age: 30, City: "Paris"}
+	if *record != expected {
+		t.Fatalf("Expected %v, got %v", expected, *record)
+	}
+
+	csvReader = typedcsv.NewReader[FieldOrderTestRecord](csv.NewReader(bytes.NewBufferString("Jane,25,Rome\n")), typedcsv.UseStructFieldOrder())
+	record, err = csvReader.ReadRecord()
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected = FieldOrderTestRecord{Name: "Jane", Age: 25, City: "Rome"}
+	if *record != expected {
+		t.Fatalf("Expected %v, got %v", expected, *record)
+	}
+}
+
+type InvalidIndexTestRecord struct {
+	A string `csv:"a" index:"-1"`
+	B string `csv:"b" index:"1"`
+	C string `csv:"c" index:"1"`
+}
+
+func TestInvalidIndex(t *testing.T) {
+	err := typedcsv.NewCodec[InvalidIndexTestRecord]().Err()
+	expected := "typedcsv: invalid tag index on field A: invalid index \"-1\", expected a non-negative integer\n" +
+		"typedcsv: invalid tag index on field C: index 1 is also used by field B"
+	var tagErr typedcsv.TagError
+	if !errors.As(err, &tagErr) || err.Error() != expected {
+		t.Fatalf("Expected %v, got %v", expected, err)
+	}
+}
+
+type SwappedIndexTestRecord struct {
+	A string `csv:"a" index:"1"`
+	B string `csv:"b" index:"0"`
+	C string `csv:"c" index:"3"`
+	D string `csv:"d"`
+}
+
+func TestWriteIndex(t *testing.T) {
+	var buffer bytes.Buffer
+	csvWriter := typedcsv.NewWriter[SwappedIndexTestRecord](csv.NewWriter(&buffer))
+	if err := csvWriter.WriteHeader(); err != nil {
+		t.Fatal(err)
+	}
+	record := SwappedIndexTestRecord{A: "x", B: "y", C: "z", D: "w"}
+	if err := csvWriter.WriteRecord(record); err != nil {
+		t.Fatal(err)
+	}
+	csvWriter.Flush()
+	expected := "b,a,,c,d\ny,x,,z,w\n"
+	if buffer.String() != expected {
+		t.Fatalf("Expected %q, got %q", expected, buffer.String())
+	}
+
+	csvReader := typedcsv.NewReader[SwappedIndexTestRecord](csv.NewReader(&buffer))
+	if err := csvReader.ReadHeader(); err != nil {
+		t.Fatal(err)
+	}
+	read, err := csvReader.ReadRecord()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if read.A != "x" || read.B != "y" || read.C != "z" {
+		t.Fatalf("Expected %v, got %v", record, *read)
+	}
+}
+
+type ImplicitIndexTestRecord struct {
+	A string `csv:"a"`
+	B string `csv:"b" index:"0"`
+}
+
+func TestImplicitIndexCollision(t *testing.T) {
+	err := typedcsv.NewCodec[ImplicitIndexTestRecord](typedcsv.UseStructFieldOrder()).Err()
+	expected := "typedcsv: invalid tag index on field B: index 0 is also the position of field A in the struct order"
+	var tagErr typedcsv.TagError
+	if !errors.As(err, &tagErr) || err.Error() != expected {
+		t.Fatalf("Expected %v, got %v", expected, err)
+	}
+	if err := typedcsv.NewCodec[ImplicitIndexTestRecord]().Err(); err != nil {
+		t.Fatalf("Expected no error without UseStructFieldOrder, got %v", err)
+	}
+}
@@ -0,0 +1,86 @@
+package typedcsv_test
+
+import (
+	"bytes"
+	"encoding/csv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hoshiumiarata/typedcsv"
+)
+
+// UnixTime is a wrapper time type that is also a TextMarshaler and a TextUnmarshaler with its own text format.
+type UnixTime time.Time
+
+func (u UnixTime) MarshalText() ([]byte, error) {
+	return []byte(time.Time(u).Format("unix:2006")), nil
+}
+
+func (u *UnixTime) UnmarshalText(text []byte) error {
+	t, err := time.Parse("unix:2006", string(text))
+	*u = UnixTime(t)
+	return err
+}
+
+type PreferTestRecord struct {
+	Text    UnixTime `csv:"text" time_format:"2006-01-02" prefer:"text"`
+	Time    UnixTime `csv:"time" time_format:"2006-01-02" prefer:"time"`
+	Default UnixTime `csv:"default" prefer:"time"`
+}
+
+type PreferConflictTestRecord struct {
+	Implicit UnixTime  `csv:"implicit" time_format:"2006-01-02"`
+	Unknown  UnixTime  `csv:"unknown" prefer:"json"`
+	Time     time.Time `csv:"time" time_format:"2006-01-02"`
+}
+
+func TestPrefer(t *testing.T) {
+	reader := bytes.Buffer{}
+	reader.WriteString("text,time,default\nunix:2020,2021-02-03,2022-03-04T05:06:07Z\n")
+	csvReader := typedcsv.NewReader[PreferTestRecord](csv.NewReader(&reader))
+	err := csvReader.ReadHeader()
+	if err != nil {
+		t.Fatal(err)
+	}
+	record, err := csvReader.ReadRecord()
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	if !time.Time(record.Text).Equal(expected) {
+		t.Fatalf("Expected %v, got %v", expected, time.Time(record.Text))
+	}
+	expected = time.Date(2021, 2, 3, 0, 0, 0, 0, time.UTC)
+	if !time.Time(record.Time).Equal(expected) {
+		t.Fatalf("Expected %v, got %v", expected, time.Time(record.Time))
+	}
+	expected = time.Date(2022, 3, 4, 5, 6, 7, 0, time.UTC)
+	if !time.Time(record.Default).Equal(expected) {
+		t.Fatalf("Expected %v, got %v", expected, time.Time(record.Default))
+	}
+
+	writer := bytes.Buffer{}
+	csvWriter := typedcsv.NewWriter[PreferTestRecord](csv.NewWriter(&writer))
+	err = csvWriter.WriteRecord(*record)
+	if err != nil {
+		t.Fatal(err)
+	}
+	csvWriter.Flush()
+	if writer.String() != "unix:2020,2021-02-03,2022-03-04T05:06:07Z\n" {
+		t.Fatalf("Expected %q, got %q", "unix:2020,2021-02-03,2022-03-04T05:06:07Z\n", writer.String())
+	}
+
+	if err := typedcsv.NewCodec[PreferTestRecord]().Err(); err != nil {
+		t.Fatal(err)
+	}
+	err = typedcsv.NewCodec[PreferConflictTestRecord]().Err()
+	if err == nil {
+		t.Fatal("Expected error, got nil")
+	}
+	lines := strings.Split(err.Error(), "\n")
+	if len(lines) != 2 || !strings.HasPrefix(lines[0], "typedcsv: invalid tag prefer on field Implicit:") ||
+		lines[1] != `typedcsv: invalid tag prefer on field Unknown: unknown value "json", expected "text" or "time"` {
+		t.Fatalf("Expected errors on Implicit and Unknown, got %q", err.Error())
+	}
+}
@@ -0,0 +1,66 @@
+package typedcsv_test
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/hoshiumiarata/typedcsv"
+)
+
+type PrefixAddress struct {
+	Street string `csv:"street"`
+	City   string `csv:"city"`
+}
+
+type PrefixTestRecord struct {
+	Name     string         `csv:"name"`
+	Address  PrefixAddress  `prefix:"address."`
+	Shipping *PrefixAddress `prefix:"shipping."`
+}
+
+func TestPrefix(t *testing.T) {
+	codec := typedcsv.NewCodec[PrefixTestRecord]()
+	expectedHeader := []string{"name", "address.street", "address.city", "shipping.street", "shipping.city"}
+	if !reflect.DeepEqual(codec.Header(), expectedHeader) {
+		t.Fatalf("Expected %v, got %v", expectedHeader, codec.Header())
+	}
+
+	buf := bytes.Buffer{}
+	csvWriter := codec.NewWriter(csv.NewWriter(&buf))
+	record := PrefixTestRecord{Name: "Alice", Address: PrefixAddress{Street: "Main", City: "Paris"}}
+	err := csvWriter.WriteAll([]PrefixTestRecord{record})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := "name,address.street,address.city,shipping.street,shipping.city\nAlice,Main,Paris,,\n"
+	if buf.String() != expected {
+		t.Fatalf("Expected %q, got %q", expected, buf.String())
+	}
+
+	csvReader := codec.NewReader(csv.NewReader(bytes.NewBufferString("name,address.city,shipping.city\nBob,Lyon,Nice\n")))
+	err = csvReader.ReadHeader()
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := csvReader.ReadRecord()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Address.City != "Lyon" || got.Shipping == nil || got.Shipping.City != "Nice" {
+		t.Fatalf("Expected Lyon and Nice, got %+v", got)
+	}
+}
+
+type InvalidPrefixTestRecord struct {
+	Name string `csv:"name" prefix:"x."`
+}
+
+func TestPrefixInvalid(t *testing.T) {
+	var tagError typedcsv.TagError
+	if err := typedcsv.NewCodec[InvalidPrefixTestRecord]().Err(); !errors.As(err, &tagError) || tagError.Tag != "prefix" {
+		t.Fatalf("Expected a prefix TagError, got %v", err)
+	}
+}
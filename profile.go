@@ -0,0 +1,213 @@
+package typedcsv
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"time"
+)
+
+// profileLayouts are the time layouts recognized by Profiler, from the most to the least specific.
+var profileLayouts = []string{
+	time.RFC3339Nano,
+	time.RFC3339,
+	"2006-01-02 15:04:05",
+	"2006-01-02T15:04:05",
+	"2006-01-02",
+	"2006/01/02",
+	"01/02/2006",
+	"02.01.2006",
+	"15:04:05",
+}
+
+// A Suggestion proposes a tighter Go type for a column, based on the values seen by a Profiler.
+type Suggestion struct {
+	// Column is the column name.
+	Column string
+	// Type is the suggested Go type, such as "uint8", "*int32" or "time.Time".
+	Type string
+	// Tag is the struct tag to use with the type, such as `time_format:"2006-01-02"`, or empty.
+	Tag string
+	// Message describes the suggestion, such as "column 'age' always fits uint8".
+	Message string
+}
+
+// String returns the message of the suggestion.
+func (s Suggestion) String() string {
+	return s.Message
+}
+
+// A Profiler collects statistics on the values of the columns of a CSV file,
+// to suggest tighter types for the fields of a struct. It is used by tools inferring struct definitions.
+type Profiler struct {
+	header  []string
+	columns []columnProfile
+}
+
+type columnProfile struct {
+	values, empty    int
+	notInt, notFloat bool
+	notBool          bool
+	leadingZeros     bool
+	min, max         int64
+	layouts          []string
+}
+
+// NewProfiler returns a new Profiler for the columns of the header.
+func NewProfiler(header []string) *Profiler {
+	p := &Profiler{
+		header:  append([]string(nil), header...),
+		columns: make([]columnProfile, len(header)),
+	}
+	for i := range p.columns {
+		p.columns[i].layouts = profileLayouts
+	}
+	return p
+}
+
+// ProfileCSV reads the header and all the records of r with a new Profiler.
+// It returns io.EOF if there is no header, or any other error returned by r.
+func ProfileCSV(r *csv.Reader) (*Profiler, error) {
+	header, err := r.Read()
+	if err != nil {
+		return nil, err
+	}
+	p := NewProfiler(header)
+	for {
+		row, err := r.Read()
+		if err == io.EOF {
+			return p, nil
+		}
+		if err != nil {
+			return p, err
+		}
+		p.Add(row)
+	}
+}
+
+// Add adds the values of a row, in the order of the header. Values beyond the header are ignored.
+func (p *Profiler) Add(row []string) {
+	for i := range p.columns {
+		c := &p.columns[i]
+		if i >= len(row) || row[i] == "" {
+			c.empty++
+			continue
+		}
+		value := row[i]
+		if hasLeadingZero(value) {
+			c.leadingZeros = true
+		}
+		if n, err := strconv.ParseInt(value, 10, 64); err != nil {
+			c.notInt = true
+		} else if c.values == 0 || c.notInt {
+			c.min, c.max = n, n
+		} else {
+			c.min, c.max = min(c.min, n), max(c.max, n)
+		}
+		if _, err := strconv.ParseFloat(value, 64); err != nil {
+			c.notFloat = true
+		}
+		if _, err := strconv.ParseBool(value); err != nil {
+			c.notBool = true
+		}
+		var layouts []string
+		for _, layout := range c.layouts {
+			if _, err := time.Parse(layout, value); err == nil {
+				layouts = append(layouts, layout)
+			}
+		}
+		c.layouts = layouts
+		c.values++
+	}
+}
+
+// Suggestions returns a suggestion for each column whose values all fit a type tighter than string:
+// the smallest integer type, float64, bool or time.Time with the first matching layout.
+// Columns of numbers with leading zeros, such as codes like 007, are suggested string instead, since a number
+// would drop the zeros. Columns with empty values are suggested pointer types with a "null" tag.
+// Columns without values have no suggestion.
+func (p *Profiler) Suggestions() []Suggestion {
+	var suggestions []Suggestion
+	for i, name := range p.header {
+		c := &p.columns[i]
+		if c.values == 0 {
+			continue
+		}
+		var typ, tag, message string
+		switch {
+		case c.leadingZeros && (!c.notInt || !c.notFloat):
+			typ = "string"
+			message = fmt.Sprintf("column '%s' has numbers with leading zeros, keep string", name)
+		case !c.notInt:
+			typ = integerType(c.min, c.max)
+			message = fmt.Sprintf("column '%s' always fits %s", name, typ)
+		case !c.notBool:
+			typ = "bool"
+			message = fmt.Sprintf("column '%s' always fits %s", name, typ)
+		case !c.notFloat:
+			typ = "float64"
+			message = fmt.Sprintf("column '%s' always fits %s", name, typ)
+		case len(c.layouts) > 0:
+			typ = "time.Time"
+			tag = fmt.Sprintf("%s:%q", timeFormatTag, c.layouts[0])
+			message = fmt.Sprintf("column '%s' matches layout %s", name, c.layouts[0])
+		default:
+			continue
+		}
+		if c.empty > 0 {
+			typ = "*" + typ
+			tag = joinTags(tag, nullTag+`:""`)
+			message += " with empty values"
+		}
+		suggestions = append(suggestions, Suggestion{Column: name, Type: typ, Tag: tag, Message: message})
+	}
+	return suggestions
+}
+
+// integerType returns the smallest integer type holding the values between low and high.
+func integerType(low, high int64) string {
+	if low >= 0 {
+		switch {
+		case high <= math.MaxUint8:
+			return "uint8"
+		case high <= math.MaxUint16:
+			return "uint16"
+		case high <= math.MaxUint32:
+			return "uint32"
+		}
+		return "uint64"
+	}
+	switch {
+	case low >= math.MinInt8 && high <= math.MaxInt8:
+		return "int8"
+	case low >= math.MinInt16 && high <= math.MaxInt16:
+		return "int16"
+	case low >= math.MinInt32 && high <= math.MaxInt32:
+		return "int32"
+	}
+	return "int64"
+}
+
+// hasLeadingZero reports whether value starts with a zero followed by a digit, after an optional sign.
+func hasLeadingZero(value string) bool {
+	if value != "" && (value[0] == '+' || value[0] == '-') {
+		value = value[1:]
+	}
+	return len(value) > 1 && value[0] == '0' && '0' <= value[1] && value[1] <= '9'
+}
+
+func joinTags(tags ...string) string {
+	joined := ""
+	for _, tag := range tags {
+		if tag == "" {
+			continue
+		}
+		if joined != "" {
+			joined += " "
+		}
+		joined += tag
+	}
+	return joined
+}
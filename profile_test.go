@@ -0,0 +1,36 @@
+package typedcsv_test
+
+import (
+	"encoding/csv"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hoshiumiarata/typedcsv"
+)
+
+func TestProfileCSV(t *testing.T) {
+	input := "age,delta,ratio,active,ts,name,score,empty,code\n" +
+		"30,-5,0.5,true,2024-01-02,John,70000,,007\n" +
+		"255,200,1,false,2024-12-31,Mary,,,120\n"
+	profiler, err := typedcsv.ProfileCSV(csv.NewReader(strings.NewReader(input)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	suggestions := profiler.Suggestions()
+	expected := []typedcsv.Suggestion{
+		{Column: "age", Type: "uint8", Message: "column 'age' always fits uint8"},
+		{Column: "delta", Type: "int16", Message: "column 'delta' always fits int16"},
+		{Column: "ratio", Type: "float64", Message: "column 'ratio' always fits float64"},
+		{Column: "active", Type: "bool", Message: "column 'active' always fits bool"},
+		{Column: "ts", Type: "time.Time", Tag: `time_format:"2006-01-02"`, Message: "column 'ts' matches layout 2006-01-02"},
+		{Column: "score", Type: "*uint32", Tag: `null:""`, Message: "column 'score' always fits uint32 with empty values"},
+		{Column: "code", Type: "string", Message: "column 'code' has numbers with leading zeros, keep string"},
+	}
+	if !reflect.DeepEqual(suggestions, expected) {
+		t.Fatalf("Expected %v, got %v", expected, suggestions)
+	}
+	if suggestions[0].String() != "column 'age' always fits uint8" {
+		t.Fatalf("Expected %q, got %q", "column 'age' always fits uint8", suggestions[0].String())
+	}
+}
@@ -0,0 +1,66 @@
+package typedcsv
+
+// A QuotePolicy chooses the fields enclosed in quotes by a TokenWriter.
+type QuotePolicy int
+
+const (
+	// QuoteMinimal quotes only the fields that need it, like csv.Writer. It is the default.
+	QuoteMinimal QuotePolicy = iota
+	// QuoteAll quotes all the fields, including empty ones.
+	QuoteAll
+	// QuoteNonNumeric quotes all the fields that are not decimal numbers, such as 42, -1.5 or 6.02e23.
+	QuoteNonNumeric
+)
+
+// WithQuoting sets the quote policy of the writers created by NewFastWriter.
+// Writers wrapping a csv.Writer always quote minimally.
+func WithQuoting(policy QuotePolicy) Option {
+	return func(o *options) {
+		o.quoting = policy
+	}
+}
+
+// mustQuote reports whether the policy requires quoting the field, even if it does not need to be quoted.
+func (p QuotePolicy) mustQuote(field string) bool {
+	switch p {
+	case QuoteAll:
+		return true
+	case QuoteNonNumeric:
+		return !isNumeric(field)
+	}
+	return false
+}
+
+// isNumeric reports whether s is a decimal number with an optional sign, fraction and exponent.
+func isNumeric(s string) bool {
+	i := 0
+	if i < len(s) && (s[i] == '+' || s[i] == '-') {
+		i++
+	}
+	digits := 0
+	for ; i < len(s) && s[i] >= '0' && s[i] <= '9'; i++ {
+		digits++
+	}
+	if i < len(s) && s[i] == '.' {
+		i++
+		for ; i < len(s) && s[i] >= '0' && s[i] <= '9'; i++ {
+			digits++
+		}
+	}
+	if digits == 0 {
+		return false
+	}
+	if i < len(s) && (s[i] == 'e' || s[i] == 'E') {
+		i++
+		if i < len(s) && (s[i] == '+' || s[i] == '-') {
+			i++
+		}
+		exponent := i
+		for ; i < len(s) && s[i] >= '0' && s[i] <= '9'; i++ {
+		}
+		if i == exponent {
+			return false
+		}
+	}
+	return i == len(s)
+}
@@ -0,0 +1,50 @@
+package typedcsv_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/hoshiumiarata/typedcsv"
+)
+
+type QuotingRecord struct {
+	Name  string  `csv:"name"`
+	Count int     `csv:"count"`
+	Ratio float64 `csv:"ratio"`
+	Note  string  `csv:"note"`
+}
+
+func TestQuoting(t *testing.T) {
+	records := []QuotingRecord{{Name: "a", Count: -3, Ratio: 1.5}, {Name: "b,c", Count: 7, Ratio: 2e21, Note: "x"}}
+	tests := []struct {
+		policy   typedcsv.QuotePolicy
+		expected string
+	}{
+		{typedcsv.QuoteMinimal, "name,count,ratio,note\na,-3,1.5,\n\"b,c\",7,2e+21,x\n"},
+		{typedcsv.QuoteAll, "\"name\",\"count\",\"ratio\",\"note\"\n\"a\",\"-3\",\"1.5\",\"\"\n\"b,c\",\"7\",\"2e+21\",\"x\"\n"},
+		{typedcsv.QuoteNonNumeric, "\"name\",\"count\",\"ratio\",\"note\"\n\"a\",-3,1.5,\"\"\n\"b,c\",7,2e+21,\"x\"\n"},
+	}
+	for _, test := range tests {
+		buf := bytes.Buffer{}
+		csvWriter := typedcsv.NewFastWriter[QuotingRecord](&buf, typedcsv.WithQuoting(test.policy))
+		err := csvWriter.WriteAll(records)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if buf.String() != test.expected {
+			t.Fatalf("Expected %q, got %q", test.expected, buf.String())
+		}
+	}
+}
+
+func TestQuotingNumbers(t *testing.T) {
+	buf := bytes.Buffer{}
+	tokenWriter := typedcsv.NewTokenWriter(&buf)
+	tokenWriter.Quoting = typedcsv.QuoteNonNumeric
+	_ = tokenWriter.Write([]string{"1", "+.5", "1.", "-2E-3", ".", "1e", "0x10", "Inf", "1_000"})
+	tokenWriter.Flush()
+	expected := "1,+.5,1.,-2E-3,\".\",\"1e\",\"0x10\",\"Inf\",\"1_000\"\n"
+	if buf.String() != expected {
+		t.Fatalf("Expected %q, got %q", expected, buf.String())
+	}
+}
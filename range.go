@@ -0,0 +1,67 @@
+package typedcsv
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// A Range is an interval of values, such as the validity interval of reference data, read from two columns or from one cell.
+//
+// A Range field with a "prefix" tag is mapped to two columns named with the tag value followed by "start" and "end",
+// such as "valid_start" and "valid_end" for a field tagged `prefix:"valid_"`. Otherwise it is mapped to one cell
+// holding the start and the end separated by the "separator" tag value, "/" by default, as in "2024-01-01/2024-12-31",
+// like a struct field with the "encoding" tag value "csv". The tags of Start and End can be set for both columns
+// with WithColumnTimeFormat or the options setting default tag values, such as WithTimeFormat.
+//
+// TypedCSVReader returns a FieldParseError wrapping ErrValidation if the start is after the end.
+// Integers, floats, strings, time.Time, time.Duration and types with a Compare(T) int method are compared,
+// and a nil pointer bound leaves the range open.
+type Range[T any] struct {
+	Start T `csv:"start"`
+	End   T `csv:"end"`
+}
+
+// defaultRangeSeparator is the separator of the start and the end of a Range read from one cell.
+const defaultRangeSeparator = "/"
+
+// isRange is the method identifying Range types.
+func (Range[T]) isRange() {}
+
+var rangeInterfaceType = reflect.TypeFor[interface{ isRange() }]()
+
+// isRangeType reports whether t is a Range type.
+func isRangeType(t reflect.Type) bool {
+	return t.Kind() == reflect.Struct && t.Implements(rangeInterfaceType)
+}
+
+// checkRange checks that the start of the Range holding the End field f is not after its end.
+// holder is the value the index of f is relative to, the record or the struct of a nested row.
+func (f *field) checkRange(holder reflect.Value) error {
+	bounds := reflect.Indirect(holder.FieldByIndex(f.index[:len(f.index)-1]))
+	start, end := bounds.Field(0), bounds.Field(1)
+	if c, ok := compareBounds(start, end); ok && c > 0 {
+		return FieldParseError{Field: f.name, NestedError: fmt.Errorf("%w: start %v is after end %v",
+			ErrValidation, reflect.Indirect(start), reflect.Indirect(end))}
+	}
+	return nil
+}
+
+// compareBounds compares the bounds of a Range. It reports false if a bound is a nil pointer or if they cannot be compared.
+func compareBounds(start, end reflect.Value) (int, bool) {
+	if start.Kind() == reflect.Ptr {
+		if start.IsNil() || end.IsNil() {
+			return 0, false
+		}
+		start, end = start.Elem(), end.Elem()
+	}
+	if a, ok := rangeValue(start); ok {
+		b, _ := rangeValue(end)
+		return compareValues(a, b), true
+	}
+	method := start.MethodByName("Compare")
+	if !method.IsValid() || method.Type().NumIn() != 1 || method.Type().In(0) != end.Type() ||
+		method.Type().NumOut() != 1 || method.Type().Out(0).Kind() != reflect.Int {
+		return 0, false
+	}
+	return int(method.Call([]reflect.Value{end})[0].Int()), true
+}
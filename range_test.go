@@ -0,0 +1,120 @@
+package typedcsv_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hoshiumiarata/typedcsv"
+)
+
+type RangeColumnsTestRecord struct {
+	Code     string                     `csv:"code"`
+	Validity typedcsv.Range[time.Time]  `prefix:"valid_"`
+	Amount   typedcsv.Range[*float64]   `prefix:"amount_"`
+	Period   *typedcsv.Range[time.Time] `prefix:"period_"`
+}
+
+type RangeCellTestRecord struct {
+	Code     string                    `csv:"code"`
+	Validity typedcsv.Range[time.Time] `csv:"validity"`
+	Ages     typedcsv.Range[int]       `csv:"ages" separator:"-"`
+}
+
+func TestRangeColumns(t *testing.T) {
+	input := "code,valid_start,valid_end,amount_start,amount_end,period_start,period_end\n" +
+		"A,2024-01-01T00:00:00Z,2024-12-31T00:00:00Z,1.5,,2024-02-01T00:00:00Z,2024-02-01T00:00:00Z\n" +
+		"B,2024-01-01T00:00:00Z,2023-12-31T00:00:00Z,1,2,2024-02-01T00:00:00Z,2024-03-01T00:00:00Z\n" +
+		"C,2024-01-01T00:00:00Z,2024-12-31T00:00:00Z,3,2,2024-02-01T00:00:00Z,2024-03-01T00:00:00Z\n"
+	reader := typedcsv.NewReaderFrom[RangeColumnsTestRecord](strings.NewReader(input), typedcsv.WithNullValue(""))
+	if err := reader.ReadHeader(); err != nil {
+		t.Fatal(err)
+	}
+	record, err := reader.ReadRecord()
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectedEnd := time.Date(2024, 12, 31, 0, 0, 0, 0, time.UTC)
+	if !record.Validity.End.Equal(expectedEnd) || *record.Amount.Start != 1.5 || record.Amount.End != nil || record.Period == nil {
+		t.Fatalf("Expected the ranges, got %+v", *record)
+	}
+
+	tests := []string{
+		"typedcsv: error parsing field 'valid_end' in record 2 on line 3: typedcsv: validation failed: start 2024-01-01 00:00:00 +0000 UTC is after end 2023-12-31 00:00:00 +0000 UTC",
+		"typedcsv: error parsing field 'amount_end' in record 3 on line 4: typedcsv: validation failed: start 3 is after end 2",
+	}
+	for _, expected := range tests {
+		_, err = reader.ReadRecord()
+		if err == nil || err.Error() != expected {
+			t.Fatalf("Expected %v, got %v", expected, err)
+		}
+		if !errors.Is(err, typedcsv.ErrValidation) {
+			t.Fatalf("Expected %v, got %v", typedcsv.ErrValidation, err)
+		}
+	}
+
+	var buffer strings.Builder
+	writer := typedcsv.NewWriterTo[RangeColumnsTestRecord](&buffer, typedcsv.WithNullValue(""))
+	if err := writer.WriteHeader(); err != nil {
+		t.Fatal(err)
+	}
+	if err := writer.WriteRecord(*record); err != nil {
+		t.Fatal(err)
+	}
+	writer.Flush()
+	expectedOutput := input[:strings.Index(input, "B,")]
+	if buffer.String() != expectedOutput {
+		t.Fatalf("Expected %q, got %q", expectedOutput, buffer.String())
+	}
+}
+
+func TestRangeCell(t *testing.T) {
+	input := "code,validity,ages\n" +
+		"A,2024-01-01T00:00:00Z/2024-12-31T00:00:00Z,18-65\n" +
+		"B,,\n" +
+		"C,2024-01-01T00:00:00Z/2024-12-31T00:00:00Z,65-18\n" +
+		"D,2024-01-01T00:00:00Z,1-2\n"
+	reader := typedcsv.NewReaderFrom[RangeCellTestRecord](strings.NewReader(input))
+	if err := reader.ReadHeader(); err != nil {
+		t.Fatal(err)
+	}
+	record, err := reader.ReadRecord()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if record.Validity.Start.Year() != 2024 || record.Validity.End.Month() != time.December || record.Ages != (typedcsv.Range[int]{Start: 18, End: 65}) {
+		t.Fatalf("Expected the ranges, got %+v", *record)
+	}
+	record, err = reader.ReadRecord()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !record.Validity.Start.IsZero() || record.Ages != (typedcsv.Range[int]{}) {
+		t.Fatalf("Expected zero ranges, got %+v", *record)
+	}
+	_, err = reader.ReadRecord()
+	if !errors.Is(err, typedcsv.ErrValidation) || !strings.Contains(err.Error(), "start 65 is after end 18") {
+		t.Fatalf("Expected %v, got %v", typedcsv.ErrValidation, err)
+	}
+	_, err = reader.ReadRecord()
+	if err == nil || !strings.Contains(err.Error(), "expected 2 values, got 1") {
+		t.Fatalf("Expected an error, got %v", err)
+	}
+
+	var buffer strings.Builder
+	writer := typedcsv.NewWriterTo[RangeCellTestRecord](&buffer)
+	err = writer.WriteRecord(RangeCellTestRecord{
+		Code:     "A",
+		Validity: typedcsv.Range[time.Time]{Start: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), End: time.Date(2024, 12, 31, 0, 0, 0, 0, time.UTC)},
+		Ages:     typedcsv.Range[int]{Start: 18, End: 65},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	writer.Flush()
+	expected := "A,2024-01-01T00:00:00Z/2024-12-31T00:00:00Z,18-65\n"
+	if buffer.String() != expected {
+		t.Fatalf("Expected %q, got %q", expected, buffer.String())
+	}
+}
@@ -0,0 +1,62 @@
+package typedcsv
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// A RateLimiter blocks until the next record may be read.
+// *rate.Limiter from golang.org/x/time/rate implements this interface.
+type RateLimiter interface {
+	Wait(ctx context.Context) error
+}
+
+// WithRateLimit sets the limiter that ReadRecord waits on before reading each record.
+// ReadRecordContext passes its context to Wait, so that the wait can be cancelled.
+func WithRateLimit(limiter RateLimiter) Option {
+	return func(o *options) {
+		o.rateLimiter = limiter
+	}
+}
+
+// NewRateLimiter returns a RateLimiter that allows at most rowsPerSecond records per second, evenly spaced.
+// A non-positive rowsPerSecond disables limiting.
+func NewRateLimiter(rowsPerSecond float64) RateLimiter {
+	if rowsPerSecond <= 0 {
+		return &intervalLimiter{}
+	}
+	return &intervalLimiter{interval: time.Duration(float64(time.Second) / rowsPerSecond)}
+}
+
+type intervalLimiter struct {
+	mu       sync.Mutex
+	interval time.Duration
+	next     time.Time
+}
+
+func (l *intervalLimiter) Wait(ctx context.Context) error {
+	if l.interval == 0 {
+		return ctx.Err()
+	}
+	l.mu.Lock()
+	now := time.Now()
+	if l.next.Before(now) {
+		l.next = now
+	}
+	delay := l.next.Sub(now)
+	l.next = l.next.Add(l.interval)
+	l.mu.Unlock()
+
+	if delay == 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
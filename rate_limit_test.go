@@ -0,0 +1,89 @@
+package typedcsv_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/hoshiumiarata/typedcsv"
+)
+
+type countingLimiter struct {
+	calls int
+	err   error
+}
+
+func (l *countingLimiter) Wait(context.Context) error {
+	l.calls++
+	return l.err
+}
+
+func TestWithRateLimit(t *testing.T) {
+	reader := bytes.Buffer{}
+	reader.WriteString("person_status\n")
+	reader.WriteString("active\n")
+	reader.WriteString("inactive\n")
+	limiter := &countingLimiter{}
+	csvReader := typedcsv.NewReader[MarshalTextTestRecord](csv.NewReader(&reader), typedcsv.WithRateLimit(limiter))
+	err := csvReader.ReadHeader()
+	if err != nil {
+		t.Fatal(err)
+	}
+	records, err := csvReader.ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 2 {
+		t.Fatalf("Expected %v, got %v", 2, len(records))
+	}
+	if limiter.calls != 3 {
+		t.Fatalf("Expected %v, got %v", 3, limiter.calls)
+	}
+
+	limiter.err = errors.New("limited")
+	reader.WriteString("active\n")
+	_, err = csvReader.ReadRecord()
+	if err != limiter.err {
+		t.Fatalf("Expected %v, got %v", limiter.err, err)
+	}
+}
+
+func TestNewRateLimiter(t *testing.T) {
+	limiter := typedcsv.NewRateLimiter(100)
+	start := time.Now()
+	for i := 0; i < 4; i++ {
+		err := limiter.Wait(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 30*time.Millisecond {
+		t.Fatalf("Expected at least %v, got %v", 30*time.Millisecond, elapsed)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err := limiter.Wait(ctx)
+	if err != context.Canceled {
+		t.Fatalf("Expected %v, got %v", context.Canceled, err)
+	}
+}
+
+func TestReadRecordContext(t *testing.T) {
+	reader := bytes.NewBufferString("person_status\nactive\ninactive\n")
+	csvReader := typedcsv.NewReader[MarshalTextTestRecord](csv.NewReader(reader), typedcsv.WithRateLimit(typedcsv.NewRateLimiter(0.001)))
+	if err := csvReader.ReadHeader(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := csvReader.ReadRecordContext(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := csvReader.ReadRecordContext(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Expected %v, got %v", context.DeadlineExceeded, err)
+	}
+}
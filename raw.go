@@ -0,0 +1,10 @@
+package typedcsv
+
+// Raw is a string whose content is the exact cell text, read and written without any parsing or formatting.
+// The "format", "time_format" and other formatting tags do not apply to it.
+//
+// It can be used for values already formatted by the caller, such as numbers with leading zeros.
+// A TypedCSVWriter created by NewFastWriter writes Raw values to the file as is, without quoting them,
+// so they can also hold pre-quoted cells. The caller is responsible for the validity of the resulting CSV.
+// A csv.Writer quotes them if they contain the separator, quotes or line breaks, like any other value.
+type Raw string
@@ -0,0 +1,54 @@
+package typedcsv_test
+
+import (
+	"bytes"
+	"encoding/csv"
+	"testing"
+
+	"github.com/hoshiumiarata/typedcsv"
+)
+
+type RawTestRecord struct {
+	Code   typedcsv.Raw  `csv:"code" format:"%5s"`
+	Amount typedcsv.Raw  `csv:"amount"`
+	Note   *typedcsv.Raw `csv:"note" null:"NULL"`
+}
+
+func TestRaw(t *testing.T) {
+	reader := bytes.Buffer{}
+	reader.WriteString("code,amount,note\n007,\"1,000\",hello world\n")
+	csvReader := typedcsv.NewReader[RawTestRecord](csv.NewReader(&reader))
+	err := csvReader.ReadHeader()
+	if err != nil {
+		t.Fatal(err)
+	}
+	record, err := csvReader.ReadRecord()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if record.Code != "007" || record.Amount != "1,000" || record.Note == nil || *record.Note != "hello world" {
+		t.Fatalf("Expected the raw values, got %+v", record)
+	}
+
+	writer := bytes.Buffer{}
+	csvWriter := typedcsv.NewWriter[RawTestRecord](csv.NewWriter(&writer))
+	err = csvWriter.WriteRecord(*record)
+	if err != nil {
+		t.Fatal(err)
+	}
+	csvWriter.Flush()
+	if writer.String() != "007,\"1,000\",hello world\n" {
+		t.Fatalf("Expected %q, got %q", "007,\"1,000\",hello world\n", writer.String())
+	}
+
+	writer.Reset()
+	fastWriter := typedcsv.NewFastWriter[RawTestRecord](&writer)
+	err = fastWriter.WriteRecord(RawTestRecord{Code: "007", Amount: `"1,000"`})
+	if err != nil {
+		t.Fatal(err)
+	}
+	fastWriter.Flush()
+	if writer.String() != "007,\"1,000\",NULL\n" {
+		t.Fatalf("Expected %q, got %q", "007,\"1,000\",NULL\n", writer.String())
+	}
+}
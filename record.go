@@ -0,0 +1,130 @@
+package typedcsv
+
+import (
+	"errors"
+	"io"
+)
+
+// A RecordReader reads records one at a time. ReadRecord returns io.EOF when there are no more records.
+//
+// It is implemented by TypedCSVReader[T] and VerifiedReader[T] as RecordReader[*T],
+// by DynamicReader as RecordReader[map[string]any], and by DedupeReader[T] as RecordReader[T],
+// so that pipeline code, such as Transform, MultiWriter and Join, can be written once for all of them.
+type RecordReader[T any] interface {
+	ReadRecord() (T, error)
+}
+
+// A RecordWriter writes records one at a time.
+//
+// It is implemented by TypedCSVWriter[T], ManifestWriter[T], PartitionedWriter[T] and TypedTemplateWriter[T]
+// as RecordWriter[T], and by DynamicWriter as RecordWriter[map[string]any].
+type RecordWriter[T any] interface {
+	WriteRecord(record T) error
+}
+
+// RecordWriterFunc is an adapter to use a function as a RecordWriter.
+type RecordWriterFunc[T any] func(record T) error
+
+// WriteRecord calls f(record).
+func (f RecordWriterFunc[T]) WriteRecord(record T) error {
+	return f(record)
+}
+
+// MultiWriter returns a RecordWriter that writes each record to all the given writers in order, like io.MultiWriter.
+// It stops at the first error.
+func MultiWriter[T any](writers ...RecordWriter[T]) RecordWriter[T] {
+	return RecordWriterFunc[T](func(record T) error {
+		for _, writer := range writers {
+			if err := writer.WriteRecord(record); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// Transform streams the records of r through fn into w until r returns io.EOF.
+// For each record read, fn returns the record to write and whether to write it; a record is skipped if it returns false.
+// Unlike Pipe, it neither reads nor writes headers, nor flushes w.
+// It stops at the first error returned by r, fn or w, and returns it with the counts so far.
+func Transform[A, B any](r RecordReader[A], w RecordWriter[B], fn func(A) (B, bool, error)) (Stats, error) {
+	var stats Stats
+	for {
+		a, err := r.ReadRecord()
+		if errors.Is(err, io.EOF) {
+			return stats, nil
+		}
+		if err != nil {
+			return stats, err
+		}
+		stats.Read++
+		b, ok, err := fn(a)
+		if err != nil {
+			return stats, err
+		}
+		if !ok {
+			stats.Skipped++
+			continue
+		}
+		err = w.WriteRecord(b)
+		if err != nil {
+			return stats, err
+		}
+		stats.Written++
+	}
+}
+
+// Join streams the records of left joined with the records of right that have the same key into w,
+// like an SQL inner join. It reads all the records of right into memory first, then the records of left in order;
+// for each pair of a left record and a right record whose keys are equal, in the order of right,
+// fn returns the record to write and whether to write it.
+// A left record without a matching right record is skipped, as is a pair for which fn returns false.
+// The Read count includes the records of both readers.
+// Like Transform, it neither reads nor writes headers, nor flushes w.
+// It stops at the first error returned by left, right, fn or w, and returns it with the counts so far.
+func Join[L, R, O any, K comparable](left RecordReader[L], right RecordReader[R], leftKey func(L) K, rightKey func(R) K,
+	w RecordWriter[O], fn func(L, R) (O, bool, error)) (Stats, error) {
+	var stats Stats
+	matches := make(map[K][]R)
+	for {
+		b, err := right.ReadRecord()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return stats, err
+		}
+		stats.Read++
+		key := rightKey(b)
+		matches[key] = append(matches[key], b)
+	}
+	for {
+		a, err := left.ReadRecord()
+		if errors.Is(err, io.EOF) {
+			return stats, nil
+		}
+		if err != nil {
+			return stats, err
+		}
+		stats.Read++
+		pairs := matches[leftKey(a)]
+		if len(pairs) == 0 {
+			stats.Skipped++
+			continue
+		}
+		for _, b := range pairs {
+			o, ok, err := fn(a, b)
+			if err != nil {
+				return stats, err
+			}
+			if !ok {
+				stats.Skipped++
+				continue
+			}
+			if err := w.WriteRecord(o); err != nil {
+				return stats, err
+			}
+			stats.Written++
+		}
+	}
+}
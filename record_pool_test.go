@@ -0,0 +1,52 @@
+package typedcsv_test
+
+import (
+	"bytes"
+	"encoding/csv"
+	"io"
+	"testing"
+
+	"github.com/hoshiumiarata/typedcsv"
+)
+
+func TestReadRecordInto(t *testing.T) {
+	reader := bytes.Buffer{}
+	reader.WriteString("name,optional\nJohn,Hello\nMary,NULL\n")
+	csvReader := typedcsv.NewReader[Person](csv.NewReader(&reader))
+	record := csvReader.AcquireRecord()
+	err := csvReader.ReadRecordInto(record)
+	if err != typedcsv.ErrHeaderNotRead {
+		t.Fatalf("Expected %v, got %v", typedcsv.ErrHeaderNotRead, err)
+	}
+	err = csvReader.ReadHeader()
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = csvReader.ReadRecordInto(record)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if record.Name != "John" || record.Optional == nil || *record.Optional != "Hello" {
+		t.Fatalf("Expected John, got %+v", record)
+	}
+	err = csvReader.ReadRecordInto(record)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if record.Name != "Mary" || record.Optional != nil {
+		t.Fatalf("Expected Mary, got %+v", record)
+	}
+	err = csvReader.ReadRecordInto(record)
+	if err != io.EOF {
+		t.Fatalf("Expected %v, got %v", io.EOF, err)
+	}
+
+	record.Name = "Changed"
+	csvReader.ReleaseRecord(record)
+	if record.Name != "" {
+		t.Fatalf("Expected the released record to be zeroed, got %+v", record)
+	}
+	if acquired := csvReader.AcquireRecord(); acquired.Name != "" {
+		t.Fatalf("Expected a zero record, got %+v", acquired)
+	}
+}
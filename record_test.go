@@ -0,0 +1,117 @@
+package typedcsv_test
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/hoshiumiarata/typedcsv"
+)
+
+var (
+	_ typedcsv.RecordReader[*Person]        = (*typedcsv.TypedCSVReader[Person])(nil)
+	_ typedcsv.RecordReader[*Person]        = (*typedcsv.VerifiedReader[Person])(nil)
+	_ typedcsv.RecordReader[map[string]any] = (*typedcsv.DynamicReader)(nil)
+	_ typedcsv.RecordWriter[Person]         = (*typedcsv.TypedCSVWriter[Person])(nil)
+	_ typedcsv.RecordWriter[Person]         = (*typedcsv.ManifestWriter[Person])(nil)
+	_ typedcsv.RecordWriter[Person]         = (*typedcsv.PartitionedWriter[Person])(nil)
+	_ typedcsv.RecordWriter[Person]         = (*typedcsv.TypedTemplateWriter[Person])(nil)
+	_ typedcsv.RecordWriter[map[string]any] = (*typedcsv.DynamicWriter)(nil)
+	_ typedcsv.RecordWriter[map[string]any] = typedcsv.RecordWriterFunc[map[string]any](nil)
+)
+
+func TestTransform(t *testing.T) {
+	schema, err := typedcsv.LoadSchema(strings.NewReader(`{"columns": [{"name": "person_status"}]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	dynamicReader := typedcsv.NewDynamicReader(csv.NewReader(bytes.NewBufferString("person_status\nactive\nunknown\ninactive\n")), schema)
+	err = dynamicReader.ReadHeader()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	first, second := bytes.Buffer{}, bytes.Buffer{}
+	firstWriter := typedcsv.NewWriter[MarshalTextTestRecord](csv.NewWriter(&first))
+	secondWriter := typedcsv.NewWriter[MarshalTextTestRecord](csv.NewWriter(&second))
+	var statuses []PersonStatus
+	collector := typedcsv.RecordWriterFunc[MarshalTextTestRecord](func(record MarshalTextTestRecord) error {
+		statuses = append(statuses, record.PersonStatus)
+		return nil
+	})
+	writer := typedcsv.MultiWriter[MarshalTextTestRecord](firstWriter, secondWriter, collector)
+	stats, err := typedcsv.Transform(dynamicReader, writer, func(row map[string]any) (MarshalTextTestRecord, bool, error) {
+		var record MarshalTextTestRecord
+		err := record.PersonStatus.UnmarshalText([]byte(row["person_status"].(string)))
+		return record, record.PersonStatus != PersonStatusUnknown, err
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats != (typedcsv.Stats{Read: 3, Written: 2, Skipped: 1}) {
+		t.Fatalf("Expected 3 read, 2 written and 1 skipped, got %+v", stats)
+	}
+	firstWriter.Flush()
+	secondWriter.Flush()
+	if first.String() != "active\ninactive\n" || second.String() != first.String() || len(statuses) != 2 {
+		t.Fatalf("Expected the records in all writers, got %q, %q and %v", first.String(), second.String(), statuses)
+	}
+}
+
+func TestMultiWriterError(t *testing.T) {
+	errStop := errors.New("stop")
+	called := false
+	writer := typedcsv.MultiWriter[int](
+		typedcsv.RecordWriterFunc[int](func(int) error { return errStop }),
+		typedcsv.RecordWriterFunc[int](func(int) error { called = true; return nil }),
+	)
+	if err := writer.WriteRecord(1); err != errStop || called {
+		t.Fatalf("Expected %v before the second writer, got %v", errStop, err)
+	}
+}
+
+type JoinTestOrder struct {
+	ID         int `csv:"id"`
+	CustomerID int `csv:"customer_id"`
+}
+
+type JoinTestCustomer struct {
+	ID   int    `csv:"id"`
+	Name string `csv:"name"`
+}
+
+func TestJoin(t *testing.T) {
+	orders := typedcsv.NewReaderFrom[JoinTestOrder](strings.NewReader("id,customer_id\n1,10\n2,30\n3,20\n4,10\n"))
+	customers := typedcsv.NewReaderFrom[JoinTestCustomer](strings.NewReader("id,name\n10,alice\n20,bob\n20,robert\n"))
+	if err := orders.ReadHeader(); err != nil {
+		t.Fatal(err)
+	}
+	if err := customers.ReadHeader(); err != nil {
+		t.Fatal(err)
+	}
+	var joined []string
+	writer := typedcsv.RecordWriterFunc[string](func(record string) error {
+		joined = append(joined, record)
+		return nil
+	})
+	stats, err := typedcsv.Join(orders, customers,
+		func(order *JoinTestOrder) int { return order.CustomerID },
+		func(customer *JoinTestCustomer) int { return customer.ID },
+		writer, func(order *JoinTestOrder, customer *JoinTestCustomer) (string, bool, error) {
+			return fmt.Sprint(order.ID, ":", customer.Name), customer.Name != "robert", nil
+		})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats != (typedcsv.Stats{Read: 7, Written: 3, Skipped: 2}) {
+		t.Fatalf("Expected 7 read, 3 written and 2 skipped, got %+v", stats)
+	}
+	expected := []string{"1:alice", "3:bob", "4:alice"}
+	if !slices.Equal(joined, expected) {
+		t.Fatalf("Expected %v, got %v", expected, joined)
+	}
+}
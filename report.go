@@ -0,0 +1,202 @@
+package typedcsv
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+)
+
+// A Report summarizes the records read by the readers created with WithReport,
+// so that ingestion jobs can attach a standard summary to each processed file.
+// A Report is not safe for concurrent use: use one Report per reader.
+type Report struct {
+	// RowsOK is the number of records read successfully.
+	RowsOK int `json:"rows_ok"`
+	// RowsFailed is the number of records that could not be read because of a RowReadError, a FieldParseError
+	// or an error wrapping ErrValidation. Other errors, such as those of the underlying reader, are not counted.
+	RowsFailed int `json:"rows_failed"`
+	// ColumnErrors is the number of FieldParseErrors of each column.
+	ColumnErrors map[string]int `json:"column_errors"`
+	// Ranges is the range of the values of each key column in the records read successfully.
+	Ranges map[string]*ValueRange `json:"ranges"`
+	// Duration is the time elapsed between the first and the last record read.
+	Duration time.Duration `json:"-"`
+
+	keyColumns []string
+	// keyFields are the indexes of the fields of the key columns, or -1, resolved by the first record read.
+	keyFields []int
+	started   time.Time
+}
+
+// A ValueRange is the smallest and the largest value of a key column of a Report.
+// They are nil if the column had no non-null value.
+type ValueRange struct {
+	Min any `json:"min"`
+	Max any `json:"max"`
+
+	min, max any
+}
+
+// NewReport returns an empty Report recording the range of the values of the given key columns.
+// The key columns must be integer, float, string, time.Time or time.Duration fields, or pointers to them.
+func NewReport(keyColumns ...string) *Report {
+	report := &Report{
+		ColumnErrors: make(map[string]int),
+		Ranges:       make(map[string]*ValueRange),
+		keyColumns:   keyColumns,
+	}
+	for _, column := range keyColumns {
+		report.Ranges[column] = &ValueRange{}
+	}
+	return report
+}
+
+// WithReport makes the readers record the result of reading each record in the report.
+// Use NewReport to create it.
+func WithReport(report *Report) Option {
+	return func(o *options) {
+		o.report = report
+	}
+}
+
+// add records the result of reading a record, whose fields are fields.
+func (r *Report) add(fields []field, record reflect.Value, err error) {
+	now := time.Now()
+	if r.started.IsZero() {
+		r.started = now
+	}
+	r.Duration = now.Sub(r.started)
+
+	if err != nil {
+		var rowErr RowReadError
+		var fieldErr FieldParseError
+		switch {
+		case errors.As(err, &fieldErr):
+			r.RowsFailed++
+			r.ColumnErrors[fieldErr.Field]++
+		case errors.As(err, &rowErr), errors.Is(err, ErrValidation):
+			r.RowsFailed++
+		}
+		return
+	}
+	r.RowsOK++
+	if r.keyFields == nil {
+		r.keyFields = make([]int, len(r.keyColumns))
+		for i, column := range r.keyColumns {
+			r.keyFields[i] = slices.IndexFunc(fields, func(f field) bool { return f.name == column })
+		}
+	}
+	for i, column := range r.keyColumns {
+		if r.keyFields[i] < 0 {
+			continue
+		}
+		value, ok := fields[r.keyFields[i]].valueForEncode(record)
+		if !ok {
+			continue
+		}
+		r.Ranges[column].add(value)
+	}
+}
+
+// add extends the range with the value, ignoring nil pointers and values that cannot be compared.
+func (r *ValueRange) add(value reflect.Value) {
+	if value.Kind() == reflect.Ptr {
+		if value.IsNil() {
+			return
+		}
+		value = value.Elem()
+	}
+	comparable, ok := rangeValue(value)
+	if !ok {
+		return
+	}
+	if r.min == nil || compareValues(comparable, r.min) < 0 {
+		r.min, r.Min = comparable, value.Interface()
+	}
+	if r.max == nil || compareValues(comparable, r.max) > 0 {
+		r.max, r.Max = comparable, value.Interface()
+	}
+}
+
+// rangeValue returns the value converted to one of the types compared by compareValues.
+func rangeValue(value reflect.Value) (any, bool) {
+	switch value.Interface().(type) {
+	case time.Time:
+		return value.Interface(), true
+	case time.Duration:
+		return time.Duration(value.Int()), true
+	}
+	switch value.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return value.Int(), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return value.Uint(), true
+	case reflect.Float32, reflect.Float64:
+		return value.Float(), true
+	case reflect.String:
+		return value.String(), true
+	}
+	return nil, false
+}
+
+// MarshalJSON returns the report as a JSON object, with the duration in seconds under "duration_seconds".
+func (r *Report) MarshalJSON() ([]byte, error) {
+	type report Report
+	return json.Marshal(struct {
+		*report
+		DurationSeconds float64 `json:"duration_seconds"`
+	}{(*report)(r), r.Duration.Seconds()})
+}
+
+// WriteJSON writes the report to w as indented JSON.
+func (r *Report) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(r)
+}
+
+// String returns the report in a human-readable form: the number of records and the duration,
+// then the columns with errors by decreasing number of errors, then the ranges of the key columns.
+func (r *Report) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "rows: %d ok, %d failed, in %v\n", r.RowsOK, r.RowsFailed, r.Duration)
+	if len(r.ColumnErrors) > 0 {
+		columns := make([]string, 0, len(r.ColumnErrors))
+		for column := range r.ColumnErrors {
+			columns = append(columns, column)
+		}
+		slices.SortFunc(columns, func(a, b string) int {
+			if count := r.ColumnErrors[b] - r.ColumnErrors[a]; count != 0 {
+				return count
+			}
+			return strings.Compare(a, b)
+		})
+		b.WriteString("column errors:\n")
+		for _, column := range columns {
+			fmt.Fprintf(&b, "  %s: %d\n", column, r.ColumnErrors[column])
+		}
+	}
+	if len(r.keyColumns) > 0 {
+		b.WriteString("ranges:\n")
+		for _, column := range r.keyColumns {
+			valueRange := r.Ranges[column]
+			if valueRange.min == nil {
+				fmt.Fprintf(&b, "  %s: no values\n", column)
+				continue
+			}
+			fmt.Fprintf(&b, "  %s: %v to %v\n", column, valueRange.Min, valueRange.Max)
+		}
+	}
+	return b.String()
+}
+
+// WriteText writes the human-readable form of the report returned by String to w.
+func (r *Report) WriteText(w io.Writer) error {
+	_, err := io.WriteString(w, r.String())
+	return err
+}
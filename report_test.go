@@ -0,0 +1,137 @@
+package typedcsv_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/iotest"
+	"time"
+
+	"github.com/hoshiumiarata/typedcsv"
+)
+
+type ReportTestRecord struct {
+	ID    int      `csv:"id"`
+	Name  string   `csv:"name"`
+	Score *float64 `csv:"score" null:""`
+}
+
+func TestReport(t *testing.T) {
+	input := "id,name,score\n" +
+		"3,carol,1.5\n" +
+		"x,bad,2\n" +
+		"1,alice,\n" +
+		"y,worse,z\n" +
+		"7,bob,0.5\n" +
+		"2,dave,w\n"
+	report := typedcsv.NewReport("id", "score", "name")
+	reader := typedcsv.NewReaderFrom[ReportTestRecord](strings.NewReader(input), typedcsv.WithReport(report))
+	if err := reader.ReadHeader(); err != nil {
+		t.Fatal(err)
+	}
+	records, err := reader.ReadAllLenient()
+	if err == nil {
+		t.Fatal("Expected error, got nil")
+	}
+	if len(records) != 3 {
+		t.Fatalf("Expected %v, got %v", 3, len(records))
+	}
+	if report.RowsOK != 3 || report.RowsFailed != 3 {
+		t.Fatalf("Expected %v and %v, got %v and %v", 3, 3, report.RowsOK, report.RowsFailed)
+	}
+	expectedErrors := map[string]int{"id": 2, "score": 1}
+	if !reflect.DeepEqual(report.ColumnErrors, expectedErrors) {
+		t.Fatalf("Expected %v, got %v", expectedErrors, report.ColumnErrors)
+	}
+	if valueRange := report.Ranges["id"]; valueRange.Min != 1 || valueRange.Max != 7 {
+		t.Fatalf("Expected %v to %v, got %v to %v", 1, 7, valueRange.Min, valueRange.Max)
+	}
+	if valueRange := report.Ranges["score"]; valueRange.Min != 0.5 || valueRange.Max != 1.5 {
+		t.Fatalf("Expected %v to %v, got %v to %v", 0.5, 1.5, valueRange.Min, valueRange.Max)
+	}
+	if valueRange := report.Ranges["name"]; valueRange.Min != "alice" || valueRange.Max != "carol" {
+		t.Fatalf("Expected %v to %v, got %v to %v", "alice", "carol", valueRange.Min, valueRange.Max)
+	}
+
+	report.Duration = 0
+	expectedText := "rows: 3 ok, 3 failed, in 0s\n" +
+		"column errors:\n" +
+		"  id: 2\n" +
+		"  score: 1\n" +
+		"ranges:\n" +
+		"  id: 1 to 7\n" +
+		"  score: 0.5 to 1.5\n" +
+		"  name: alice to carol\n"
+	var text bytes.Buffer
+	if err := report.WriteText(&text); err != nil {
+		t.Fatal(err)
+	}
+	if text.String() != expectedText {
+		t.Fatalf("Expected %q, got %q", expectedText, text.String())
+	}
+}
+
+func TestReportJSON(t *testing.T) {
+	report := typedcsv.NewReport("id", "score")
+	reader := typedcsv.NewReaderFrom[ReportTestRecord](strings.NewReader("id,name,score\n5,eve,\nx,bad,1\n"), typedcsv.WithReport(report))
+	if err := reader.ReadHeader(); err != nil {
+		t.Fatal(err)
+	}
+	reader.ReadAllLenient()
+	report.Duration = 1500 * time.Millisecond
+
+	var buffer bytes.Buffer
+	if err := report.WriteJSON(&buffer); err != nil {
+		t.Fatal(err)
+	}
+	var decoded map[string]any
+	if err := json.Unmarshal(buffer.Bytes(), &decoded); err != nil {
+		t.Fatal(err)
+	}
+	expected := map[string]any{
+		"rows_ok":          1.0,
+		"rows_failed":      1.0,
+		"column_errors":    map[string]any{"id": 1.0},
+		"ranges":           map[string]any{"id": map[string]any{"min": 5.0, "max": 5.0}, "score": map[string]any{"min": nil, "max": nil}},
+		"duration_seconds": 1.5,
+	}
+	if !reflect.DeepEqual(decoded, expected) {
+		t.Fatalf("Expected %v, got %v", expected, decoded)
+	}
+}
+
+func TestReportFailedRows(t *testing.T) {
+	report := typedcsv.NewReport("id")
+	input := io.MultiReader(strings.NewReader("id,name,score\n1,a,\n2,b\n3,c,\n"), iotest.ErrReader(errors.New("read failed")))
+	reader := typedcsv.NewReaderFrom[ReportTestRecord](input, typedcsv.WithReport(report))
+	if err := reader.ReadHeader(); err != nil {
+		t.Fatal(err)
+	}
+	var errs []error
+	for {
+		_, err := reader.ReadRecord()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			errs = append(errs, err)
+			var rowErr typedcsv.RowReadError
+			if !errors.As(err, &rowErr) {
+				break
+			}
+		}
+	}
+	if len(errs) != 2 {
+		t.Fatalf("Expected %v errors, got %v", 2, errs)
+	}
+	if report.RowsOK != 2 || report.RowsFailed != 1 {
+		t.Fatalf("Expected %v and %v, got %v and %v", 2, 1, report.RowsOK, report.RowsFailed)
+	}
+	if valueRange := report.Ranges["id"]; valueRange.Min != 1 || valueRange.Max != 3 {
+		t.Fatalf("Expected %v to %v, got %v to %v", 1, 3, valueRange.Min, valueRange.Max)
+	}
+}
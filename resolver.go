@@ -0,0 +1,68 @@
+package typedcsv
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const resolveTag = "resolve"
+
+// A Resolver returns the value of a placeholder such as ${secret:db_password}, given its kind "secret"
+// and its key "db_password". The kind is empty for placeholders without a colon, such as ${HOME}.
+type Resolver func(kind, key string) (string, error)
+
+// WithResolver makes TypedCSVReader expand the placeholders ${kind:key} of the CSV values with the resolver
+// before parsing them, for example to look up secrets or codes of external tables.
+// "$${" is read as a literal "${". A field with the "resolve" tag value "false" is read without expansion,
+// and Raw fields are never expanded. The placeholders of a struct field with the "encoding" tag value "csv"
+// are expanded in the values of its fields, after the cell is split. An error of the resolver is returned as a FieldParseError.
+func WithResolver(resolver Resolver) Option {
+	return func(o *options) {
+		o.resolver = resolver
+	}
+}
+
+// resolves reports whether the CSV value of the field is expanded by the resolver set by WithResolver.
+func (f *field) resolves(o *options, value string) bool {
+	return o.resolver != nil && !f.noResolve && !f.raw && strings.Contains(value, "${")
+}
+
+var errUnterminatedPlaceholder = errors.New("unterminated placeholder")
+
+// expand replaces the placeholders of the value with the values returned by the resolver.
+func (r Resolver) expand(value string) (string, error) {
+	if !strings.Contains(value, "${") {
+		return value, nil
+	}
+	var builder strings.Builder
+	for {
+		start := strings.Index(value, "${")
+		if start < 0 {
+			builder.WriteString(value)
+			return builder.String(), nil
+		}
+		if start > 0 && value[start-1] == '$' {
+			builder.WriteString(value[:start-1])
+			builder.WriteString("${")
+			value = value[start+2:]
+			continue
+		}
+		end := strings.IndexByte(value[start:], '}')
+		if end < 0 {
+			return "", errUnterminatedPlaceholder
+		}
+		placeholder := value[start+2 : start+end]
+		kind, key, found := strings.Cut(placeholder, ":")
+		if !found {
+			kind, key = "", placeholder
+		}
+		resolved, err := r(kind, key)
+		if err != nil {
+			return "", fmt.Errorf("resolving ${%s}: %w", placeholder, err)
+		}
+		builder.WriteString(value[:start])
+		builder.WriteString(resolved)
+		value = value[start+end+1:]
+	}
+}
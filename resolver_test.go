@@ -0,0 +1,84 @@
+package typedcsv_test
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/hoshiumiarata/typedcsv"
+)
+
+type ResolverTestRecord struct {
+	Name     string       `csv:"name"`
+	Password string       `csv:"password"`
+	Port     int          `csv:"port"`
+	Template string       `csv:"template" resolve:"false"`
+	Raw      typedcsv.Raw `csv:"raw"`
+}
+
+func TestResolver(t *testing.T) {
+	values := map[string]string{"secret:db_password": "hunter2", ":PORT": "5432"}
+	resolver := func(kind, key string) (string, error) {
+		if value, ok := values[kind+":"+key]; ok {
+			return value, nil
+		}
+		return "", fmt.Errorf("unknown %s", key)
+	}
+	input := "name,password,port,template,raw\n" +
+		"x$${literal},pre-${secret:db_password}-post,${PORT},${secret:db_password},${PORT}\n" +
+		"db,${secret:missing},1,,\n" +
+		"db,${secret:db_password,1,,\n"
+	csvReader := typedcsv.NewReader[ResolverTestRecord](csv.NewReader(bytes.NewBufferString(input)), typedcsv.WithResolver(resolver))
+	if err := csvReader.ReadHeader(); err != nil {
+		t.Fatal(err)
+	}
+	record, err := csvReader.ReadRecord()
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := ResolverTestRecord{Name: "x${literal}", Password: "pre-hunter2-post", Port: 5432, Template: "${secret:db_password}", Raw: "${PORT}"}
+	if *record != expected {
+		t.Fatalf("Expected %v, got %v", expected, *record)
+	}
+	_, err = csvReader.ReadRecord()
+	expectedErr := "typedcsv: error parsing field 'password' in record 2 on line 3: resolving ${secret:missing}: unknown missing"
+	if err == nil || err.Error() != expectedErr {
+		t.Fatalf("Expected %v, got %v", expectedErr, err)
+	}
+	_, err = csvReader.ReadRecord()
+	var fieldParseError typedcsv.FieldParseError
+	if !errors.As(err, &fieldParseError) || fieldParseError.Field != "password" {
+		t.Fatalf("Expected a FieldParseError on password, got %v", err)
+	}
+}
+
+type ResolverNestedTestRecord struct {
+	Name    string                `csv:"name"`
+	Address ResolverNestedAddress `csv:"address" encoding:"csv" separator:"|"`
+}
+
+type ResolverNestedAddress struct {
+	Street string `csv:"street"`
+	City   string `csv:"city"`
+}
+
+func TestResolverNestedRow(t *testing.T) {
+	resolver := func(kind, key string) (string, error) {
+		return "R(" + kind + ":" + key + ")", nil
+	}
+	input := "name,address\n${k:v},$${k:v}|${city:x}\n"
+	csvReader := typedcsv.NewReader[ResolverNestedTestRecord](csv.NewReader(bytes.NewBufferString(input)), typedcsv.WithResolver(resolver))
+	if err := csvReader.ReadHeader(); err != nil {
+		t.Fatal(err)
+	}
+	record, err := csvReader.ReadRecord()
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := ResolverNestedTestRecord{Name: "R(k:v)", Address: ResolverNestedAddress{Street: "${k:v}", City: "R(city:x)"}}
+	if *record != expected {
+		t.Fatalf("Expected %v, got %v", expected, *record)
+	}
+}
@@ -0,0 +1,117 @@
+package typedcsv
+
+import (
+	"bufio"
+	"encoding/csv"
+	"errors"
+	"io"
+)
+
+var (
+	// ErrBareCR is reported by ValidateRFC4180 for a carriage return that is not followed by a line feed outside a quoted field.
+	ErrBareCR = errors.New("bare \\r outside quoted field")
+	// ErrLineEnding is reported by ValidateRFC4180 for a line ending with a line feed not preceded by a carriage return.
+	ErrLineEnding = errors.New("line not terminated by \\r\\n")
+)
+
+// rfc4180State is the state of ValidateRFC4180 in the current field.
+type rfc4180State int
+
+const (
+	rfc4180FieldStart rfc4180State = iota
+	rfc4180Unquoted
+	rfc4180Quoted
+	rfc4180QuoteInQuoted
+)
+
+// ValidateRFC4180 reads the whole input and checks that it strictly conforms to RFC 4180, unlike csv.Reader
+// and Tokenizer that also accept common deviations. The delimiter must be a comma and the quote a double quote.
+//
+// It reports all the violations as *csv.ParseError joined with errors.Join, with the line and the column (1-based, in runes) of each:
+//
+//   - a quote in an unquoted field (csv.ErrBareQuote), text after the closing quote of a quoted field or an unterminated quoted field (csv.ErrQuote).
+//   - a carriage return outside a quoted field that does not end a line (ErrBareCR), or a line ending with a line feed only (ErrLineEnding).
+//   - a record with a different number of fields than the first record (csv.ErrFieldCount).
+//
+// The last record may end without a line break. It returns nil if the input conforms, or the error of the underlying reader.
+func ValidateRFC4180(r io.Reader) error {
+	reader := bufio.NewReader(r)
+	var errs []error
+	state := rfc4180FieldStart
+	line, column, startLine := 1, 0, 1
+	fields, expected, empty := 0, -1, true
+	report := func(err error) {
+		errs = append(errs, &csv.ParseError{StartLine: startLine, Line: line, Column: column, Err: err})
+	}
+	endRecord := func() {
+		fields++
+		if expected < 0 {
+			expected = fields
+		} else if fields != expected {
+			errs = append(errs, &csv.ParseError{StartLine: startLine, Line: startLine, Column: 1, Err: csv.ErrFieldCount})
+		}
+		line++
+		column, startLine = 0, line
+		fields, empty, state = 0, true, rfc4180FieldStart
+	}
+	for {
+		c, err := reader.ReadByte()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if c&0xC0 != 0x80 {
+			column++
+		}
+		if state == rfc4180Quoted {
+			switch c {
+			case '"':
+				state = rfc4180QuoteInQuoted
+			case '\n':
+				line++
+				column = 0
+			}
+			continue
+		}
+		empty = false
+		switch c {
+		case ',':
+			fields++
+			state = rfc4180FieldStart
+		case '\r':
+			if next, err := reader.Peek(1); err == nil && next[0] == '\n' {
+				_, _ = reader.ReadByte()
+				endRecord()
+				continue
+			}
+			report(ErrBareCR)
+			state = rfc4180Unquoted
+		case '\n':
+			report(ErrLineEnding)
+			endRecord()
+		case '"':
+			switch state {
+			case rfc4180FieldStart:
+				state = rfc4180Quoted
+			case rfc4180QuoteInQuoted:
+				state = rfc4180Quoted
+			default:
+				report(csv.ErrBareQuote)
+			}
+		default:
+			if state == rfc4180QuoteInQuoted {
+				report(csv.ErrQuote)
+			}
+			state = rfc4180Unquoted
+		}
+	}
+	if state == rfc4180Quoted {
+		column++
+		report(csv.ErrQuote)
+	} else if !empty {
+		endRecord()
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,58 @@
+package typedcsv_test
+
+import (
+	"encoding/csv"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/hoshiumiarata/typedcsv"
+)
+
+func TestValidateRFC4180(t *testing.T) {
+	valid := []string{
+		"",
+		"a,b\r\n1,2\r\n",
+		"a,b\r\n1,2",
+		"a,b\r\n\"x,\"\"y\"\"\",\"multi\r\nline\"\r\n",
+		"a,b\r\n,\r\n",
+	}
+	for _, input := range valid {
+		err := typedcsv.ValidateRFC4180(strings.NewReader(input))
+		if err != nil {
+			t.Fatalf("Expected %q to be valid, got %v", input, err)
+		}
+	}
+
+	tests := []struct {
+		input  string
+		errors []csv.ParseError
+	}{
+		{"a,b\n1,2\r\n", []csv.ParseError{{StartLine: 1, Line: 1, Column: 4, Err: typedcsv.ErrLineEnding}}},
+		{"a,b\r\n1,x\ry\r\n", []csv.ParseError{{StartLine: 2, Line: 2, Column: 4, Err: typedcsv.ErrBareCR}}},
+		{"a,b\r\n1,x\"y\r\n", []csv.ParseError{{StartLine: 2, Line: 2, Column: 4, Err: csv.ErrBareQuote}}},
+		{"a,b\r\n\"é\"x,2\r\n", []csv.ParseError{{StartLine: 2, Line: 2, Column: 4, Err: csv.ErrQuote}}},
+		{"a,b\r\n1,\"x\r\n", []csv.ParseError{{StartLine: 2, Line: 3, Column: 1, Err: csv.ErrQuote}}},
+		{"a,b\r\n1\r\n1,2,3\r\n", []csv.ParseError{
+			{StartLine: 2, Line: 2, Column: 1, Err: csv.ErrFieldCount},
+			{StartLine: 3, Line: 3, Column: 1, Err: csv.ErrFieldCount},
+		}},
+	}
+	for _, test := range tests {
+		err := typedcsv.ValidateRFC4180(strings.NewReader(test.input))
+		joined, ok := err.(interface{ Unwrap() []error })
+		if !ok {
+			t.Fatalf("Expected joined errors for %q, got %v", test.input, err)
+		}
+		errs := joined.Unwrap()
+		if len(errs) != len(test.errors) {
+			t.Fatalf("Expected %d errors for %q, got %v", len(test.errors), test.input, err)
+		}
+		for i, expected := range test.errors {
+			var parseError *csv.ParseError
+			if !errors.As(errs[i], &parseError) || *parseError != expected {
+				t.Fatalf("Expected %v for %q, got %v", &expected, test.input, errs[i])
+			}
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package typedcsv
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"hash"
+)
+
+// ErrRowHashMismatch is returned by ReadRecord when the row hash column does not match the row, or is missing.
+var ErrRowHashMismatch = errors.New("typedcsv: row hash mismatch")
+
+type rowHashOption struct {
+	column  string
+	newHash func() hash.Hash
+}
+
+// WithRowHash adds a column with the given name holding a hash of the other values of the row,
+// computed with the hash function returned by newHash, such as sha256.New.
+//
+// A TypedCSVWriter appends the column to the header and the hex-encoded hash to each record.
+// A TypedCSVReader verifies the hash of each record and returns ErrRowHashMismatch if it does not match.
+// The hash is computed on the serialized values in the order of the file, so it does not depend on the struct type.
+func WithRowHash(column string, newHash func() hash.Hash) Option {
+	return func(o *options) {
+		o.rowHash = &rowHashOption{column: column, newHash: newHash}
+	}
+}
+
+// sum returns the hex-encoded hash of the values, skipping the value at index skip.
+// Each value is prefixed with its length, so that the hash is not ambiguous.
+func (h *rowHashOption) sum(values []string, skip int) string {
+	digest := h.newHash()
+	var length [binary.MaxVarintLen64]byte
+	for i, value := range values {
+		if i == skip {
+			continue
+		}
+		digest.Write(length[:binary.PutUvarint(length[:], uint64(len(value)))])
+		digest.Write([]byte(value))
+	}
+	return hex.EncodeToString(digest.Sum(nil))
+}
+
+// verify reports whether the row has a valid hash in the column at index, which is -1 if the header has no such column.
+func (h *rowHashOption) verify(values []string, index int) bool {
+	return index >= 0 && index < len(values) && values[index] == h.sum(values, index)
+}
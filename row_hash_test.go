@@ -0,0 +1,88 @@
+package typedcsv_test
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/csv"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/hoshiumiarata/typedcsv"
+)
+
+func TestRowHash(t *testing.T) {
+	writer := bytes.Buffer{}
+	csvWriter := typedcsv.NewWriter[MarshalTextTestRecord](csv.NewWriter(&writer), typedcsv.WithRowHash("row_hash", sha256.New))
+	err := csvWriter.WriteHeader()
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = csvWriter.WriteRecord(MarshalTextTestRecord{PersonStatus: PersonStatusActive})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = csvWriter.WriteRecord(MarshalTextTestRecord{PersonStatus: PersonStatusInactive})
+	if err != nil {
+		t.Fatal(err)
+	}
+	csvWriter.Flush()
+	lines := strings.Split(writer.String(), "\n")
+	if lines[0] != "person_status,row_hash" {
+		t.Fatalf("Expected %q, got %q", "person_status,row_hash", lines[0])
+	}
+	// SHA-256 of the length-prefixed value "\x06active".
+	expected := "active,470a873b58bdbd3bafeec7aeeababcf5d2d3fc0ead6946fbdca4a9d13c3b3e3c"
+	if lines[1] != expected {
+		t.Fatalf("Expected %q, got %q", expected, lines[1])
+	}
+
+	csvReader := typedcsv.NewReader[MarshalTextTestRecord](csv.NewReader(strings.NewReader(writer.String())), typedcsv.WithRowHash("row_hash", sha256.New))
+	err = csvReader.ReadHeader()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(csvReader.Warnings()) != 0 {
+		t.Fatalf("Expected no warnings, got %v", csvReader.Warnings())
+	}
+	records, err := csvReader.ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 2 || records[1].PersonStatus != PersonStatusInactive {
+		t.Fatalf("Expected 2 records, got %v", records)
+	}
+
+	tampered := strings.Replace(writer.String(), "inactive,", "active,", 1)
+	csvReader = typedcsv.NewReader[MarshalTextTestRecord](csv.NewReader(strings.NewReader(tampered)), typedcsv.WithRowHash("row_hash", sha256.New))
+	err = csvReader.ReadHeader()
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = csvReader.ReadAll()
+	if !errors.Is(err, typedcsv.ErrRowHashMismatch) {
+		t.Fatalf("Expected %v, got %v", typedcsv.ErrRowHashMismatch, err)
+	}
+	expectedError := "typedcsv: row hash mismatch in record 2"
+	if err.Error() != expectedError {
+		t.Fatalf("Expected %q, got %q", expectedError, err.Error())
+	}
+}
+
+func TestRowHashMissingColumn(t *testing.T) {
+	reader := bytes.Buffer{}
+	reader.WriteString("person_status\nactive\n")
+	csvReader := typedcsv.NewReader[MarshalTextTestRecord](csv.NewReader(&reader), typedcsv.WithRowHash("row_hash", sha256.New))
+	err := csvReader.ReadHeader()
+	if err != nil {
+		t.Fatal(err)
+	}
+	warnings := csvReader.Warnings()
+	if len(warnings) != 1 || warnings[0].Kind != typedcsv.WarningMissingColumn || warnings[0].Column != "row_hash" {
+		t.Fatalf("Expected a missing row_hash warning, got %v", warnings)
+	}
+	_, err = csvReader.ReadRecord()
+	if !errors.Is(err, typedcsv.ErrRowHashMismatch) {
+		t.Fatalf("Expected %v, got %v", typedcsv.ErrRowHashMismatch, err)
+	}
+}
@@ -0,0 +1,176 @@
+package typedcsv
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// ErrOutOfRange is wrapped by the FieldParseError returned when an integer value does not fit in the type of its field,
+// such as 300 or -1 for a uint8 field.
+var ErrOutOfRange = errors.New("typedcsv: value out of range")
+
+// isScalarKind reports whether values of kind k are parsed by parseScalar.
+func isScalarKind(k reflect.Kind) bool {
+	switch k {
+	case reflect.String, reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64, reflect.Complex64, reflect.Complex128:
+		return true
+	}
+	return false
+}
+
+// scalarVerb reports whether values of kind k scanned with the fmt verb scan are parsed by parseScalar
+// instead of fmt.Sscanf: the "%v" verb, or the decimal verbs of the kind, for fields whose "format" tag value is such as "%.2f".
+// Strings scanned with "%s" are also set verbatim, as fmt.Sscanf would stop at the first space.
+func scalarVerb(k reflect.Kind, scan string) bool {
+	if !isScalarKind(k) {
+		return false
+	}
+	switch scan {
+	case "%v":
+		return true
+	case "%d":
+		return k >= reflect.Int && k <= reflect.Uintptr
+	case "%f", "%F", "%g", "%G", "%e", "%E":
+		return k >= reflect.Float32 && k <= reflect.Complex128
+	case "%t":
+		return k == reflect.Bool
+	case "%s":
+		return k == reflect.String
+	}
+	return false
+}
+
+// parseScalar parses value into v, a settable value of a kind accepted by isScalarKind, with strconv.
+// Strings are set verbatim. Other values may be surrounded by white space, and integers are in base,
+// optionally with its prefix, such as "0x" for base 16.
+// It replaces fmt.Sscanf with the "%v" verb, which splits strings at spaces and allocates.
+func parseScalar(v reflect.Value, value string, base int) error {
+	if v.Kind() == reflect.String {
+		v.SetString(value)
+		return nil
+	}
+	value = strings.TrimSpace(value)
+	digits := trimBasePrefix(value, base)
+	switch v.Kind() {
+	case reflect.Bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(digits, base, v.Type().Bits())
+		if errors.Is(err, strconv.ErrRange) {
+			return rangeError{value: value, typ: v.Type(), err: err}
+		}
+		if err != nil {
+			return err
+		}
+		v.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		n, err := strconv.ParseUint(digits, base, v.Type().Bits())
+		if errors.Is(err, strconv.ErrRange) {
+			return rangeError{value: value, typ: v.Type(), err: err}
+		}
+		if _, signedErr := strconv.ParseInt(digits, base, 64); err != nil && (signedErr == nil || errors.Is(signedErr, strconv.ErrRange)) {
+			// A negative integer.
+			return rangeError{value: value, typ: v.Type(), err: err}
+		}
+		if err != nil {
+			return err
+		}
+		v.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(value, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetFloat(f)
+	case reflect.Complex64, reflect.Complex128:
+		c, err := strconv.ParseComplex(value, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetComplex(c)
+	}
+	return nil
+}
+
+// scanValue scans value into v, a settable value, with fmt.Sscanf and the format scan.
+// Integers are scanned as 64-bit integers and checked against the range of the type of v,
+// as fmt.Sscanf reports overflows without the range of the type.
+// Input left after the scan, such as "abc" in "12abc" scanned with "%d", is an error; trailing white space is not.
+func scanValue(v reflect.Value, scan, value string) error {
+	if _, ok := v.Addr().Interface().(fmt.Scanner); !ok {
+		switch v.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			var n int64
+			if err := sscanf(value, scan, &n); err != nil {
+				return err
+			}
+			if v.OverflowInt(n) {
+				return rangeError{value: value, typ: v.Type()}
+			}
+			v.SetInt(n)
+			return nil
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+			var n uint64
+			if err := sscanf(value, scan, &n); err != nil {
+				return err
+			}
+			if v.OverflowUint(n) {
+				return rangeError{value: value, typ: v.Type()}
+			}
+			v.SetUint(n)
+			return nil
+		}
+	}
+	return sscanf(value, scan, v.Addr().Interface())
+}
+
+// sscanf scans value into arg like fmt.Sscanf, and reports an error if anything but white space is left.
+func sscanf(value, scan string, arg any) error {
+	reader := strings.NewReader(value)
+	if _, err := fmt.Fscanf(reader, scan, arg); err != nil {
+		return err
+	}
+	if rest, _ := io.ReadAll(reader); strings.TrimSpace(string(rest)) != "" {
+		return fmt.Errorf("unexpected %q after the value scanned with %q", rest, scan)
+	}
+	return nil
+}
+
+// A rangeError reports an integer value that does not fit in an integer type. It wraps ErrOutOfRange,
+// and the error of the parser if any.
+type rangeError struct {
+	value string
+	typ   reflect.Type
+	err   error
+}
+
+func (e rangeError) Error() string {
+	var low, high string
+	bits := e.typ.Bits()
+	switch e.typ.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		low, high = strconv.FormatInt(math.MinInt64>>(64-bits), 10), strconv.FormatInt(math.MaxInt64>>(64-bits), 10)
+	default:
+		low, high = "0", strconv.FormatUint(math.MaxUint64>>(64-bits), 10)
+	}
+	return fmt.Sprintf("%v: '%s' does not fit in %v, whose range is %s to %s", ErrOutOfRange, e.value, e.typ, low, high)
+}
+
+func (e rangeError) Unwrap() []error {
+	if e.err == nil {
+		return []error{ErrOutOfRange}
+	}
+	return []error{ErrOutOfRange, e.err}
+}
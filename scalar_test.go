@@ -0,0 +1,241 @@
+package typedcsv_test
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/hoshiumiarata/typedcsv"
+)
+
+type ScalarTestRecord struct {
+	Name    string     `csv:"name"`
+	Count   int16      `csv:"count"`
+	Size    uint       `csv:"size"`
+	Ratio   float32    `csv:"ratio" format:"%.2f"`
+	Enabled bool       `csv:"enabled"`
+	Point   complex128 `csv:"point"`
+	Scores  []int      `csv:"scores" separator:";"`
+}
+
+func TestScalarParsing(t *testing.T) {
+	input := "name,count,size,ratio,enabled,point,scores\n" +
+		"John Smith, -12 ,007,0.25,true,(1+2i),1; 2;3\n" +
+		",,,,,,4\n"
+	reader := typedcsv.NewReaderFrom[ScalarTestRecord](strings.NewReader(input))
+	if err := reader.ReadHeader(); err != nil {
+		t.Fatal(err)
+	}
+	record, err := reader.ReadRecord()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if record.Name != "John Smith" || record.Count != -12 || record.Size != 7 || record.Ratio != 0.25 ||
+		!record.Enabled || record.Point != complex(1, 2) || len(record.Scores) != 3 || record.Scores[1] != 2 {
+		t.Fatalf("Expected the parsed values, got %+v", *record)
+	}
+	record, err = reader.ReadRecord()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if record.Name != "" || record.Count != 0 || record.Enabled {
+		t.Fatalf("Expected zero values, got %+v", *record)
+	}
+	if warnings := reader.Warnings(); len(warnings) != 5 || warnings[0].Column != "count" || warnings[0].Kind != typedcsv.WarningCoercedValue {
+		t.Fatalf("Expected 5 coerced values, got %v", warnings)
+	}
+
+	reader = typedcsv.NewReaderFrom[ScalarTestRecord](strings.NewReader("name,count,size,ratio,enabled,point,scores\nx,010,0100,1,true,0,08\n"))
+	if err := reader.ReadHeader(); err != nil {
+		t.Fatal(err)
+	}
+	record, err = reader.ReadRecord()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if record.Count != 10 || record.Size != 100 || record.Scores[0] != 8 {
+		t.Fatalf("Expected %v, %v and %v in base 10, got %v, %v and %v", 10, 100, 8, record.Count, record.Size, record.Scores[0])
+	}
+
+	tests := []struct {
+		row      string
+		expected string
+	}{
+		{"x,40000,1,1,true,0,1", `typedcsv: error parsing field 'count' in record 1 on line 2: typedcsv: value out of range: '40000' does not fit in int16, whose range is -32768 to 32767`},
+		{"x,1,-1,1,true,0,1", `typedcsv: error parsing field 'size' in record 1 on line 2: typedcsv: value out of range: '-1' does not fit in uint, whose range is 0 to 18446744073709551615`},
+		{"x,12abc,1,1,true,0,1", `typedcsv: error parsing field 'count' in record 1 on line 2: strconv.ParseInt: parsing "12abc": invalid syntax`},
+		{"x,0x1F,1,1,true,0,1", `typedcsv: error parsing field 'count' in record 1 on line 2: strconv.ParseInt: parsing "0x1F": invalid syntax`},
+		{"x,1_000,1,1,true,0,1", `typedcsv: error parsing field 'count' in record 1 on line 2: strconv.ParseInt: parsing "1_000": invalid syntax`},
+		{"x,1,1,1,yes,0,1", `typedcsv: error parsing field 'enabled' in record 1 on line 2: strconv.ParseBool: parsing "yes": invalid syntax`},
+		{"x,1,1,1,true,0,1;x", `typedcsv: error parsing field 'scores[1]' in record 1 on line 2: strconv.ParseInt: parsing "x": invalid syntax`},
+	}
+	for _, test := range tests {
+		reader := typedcsv.NewReaderFrom[ScalarTestRecord](strings.NewReader("name,count,size,ratio,enabled,point,scores\n" + test.row + "\n"))
+		if err := reader.ReadHeader(); err != nil {
+			t.Fatal(err)
+		}
+		_, err := reader.ReadRecord()
+		if err == nil || err.Error() != test.expected {
+			t.Fatalf("%q: Expected %v, got %v", test.row, test.expected, err)
+		}
+		var numError *strconv.NumError
+		if !errors.As(err, &numError) {
+			t.Fatalf("%q: Expected a strconv.NumError, got %v", test.row, err)
+		}
+	}
+}
+
+type StringFieldsTestRecord struct {
+	Name    string   `csv:"name"`
+	Title   string   `csv:"title" format:"%s"`
+	Note    string   `csv:"note" parse:"%s"`
+	Aliases []string `csv:"aliases" separator:";"`
+	Codes   []string `csv:"codes" format:"%3s"`
+}
+
+func TestStringFieldsVerbatim(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    string
+		expected string
+	}{
+		{"spaces", "John Smith", "John Smith"},
+		{"surrounding spaces", "  John Smith  ", "  John Smith  "},
+		{"tabs", "John\tSmith\t", "John\tSmith\t"},
+		{"unicode", "Zoë Åström 山田 太郎 🙂", "Zoë Åström 山田 太郎 🙂"},
+		{"embedded commas", `"Smith, John"`, "Smith, John"},
+		{"embedded quotes", `"John ""Johnny"" Smith"`, `John "Johnny" Smith`},
+		{"percent signs", "100% %s %d", "100% %s %d"},
+	}
+	for _, test := range tests {
+		input := "name,title,note,aliases,codes\n" +
+			test.value + "," + test.value + "," + test.value + ",\"a b;\tÅ, 🙂\",  a  b\n"
+		reader := typedcsv.NewReaderFrom[StringFieldsTestRecord](strings.NewReader(input))
+		if err := reader.ReadHeader(); err != nil {
+			t.Fatal(err)
+		}
+		record, err := reader.ReadRecord()
+		if err != nil {
+			t.Fatalf("%s: %v", test.name, err)
+		}
+		if record.Name != test.expected || record.Title != test.expected || record.Note != test.expected {
+			t.Fatalf("%s: Expected %q, got %q, %q and %q", test.name, test.expected, record.Name, record.Title, record.Note)
+		}
+		if len(record.Aliases) != 2 || record.Aliases[0] != "a b" || record.Aliases[1] != "\tÅ, 🙂" {
+			t.Fatalf("%s: Expected [a b \tÅ, 🙂], got %q", test.name, record.Aliases)
+		}
+		if len(record.Codes) != 2 || record.Codes[0] != "a" || record.Codes[1] != "b" {
+			t.Fatalf("%s: Expected [a b], got %q", test.name, record.Codes)
+		}
+
+		var buffer strings.Builder
+		writer := typedcsv.NewWriterTo[StringFieldsTestRecord](&buffer)
+		if err := writer.WriteRecord(*record); err != nil {
+			t.Fatal(err)
+		}
+		writer.Flush()
+		reader = typedcsv.NewReaderFrom[StringFieldsTestRecord](strings.NewReader(input[:strings.IndexByte(input, '\n')+1] + buffer.String()))
+		if err := reader.ReadHeader(); err != nil {
+			t.Fatal(err)
+		}
+		roundTrip, err := reader.ReadRecord()
+		if err != nil {
+			t.Fatalf("%s: %v", test.name, err)
+		}
+		if roundTrip.Name != test.expected || roundTrip.Title != test.expected || roundTrip.Note != test.expected {
+			t.Fatalf("%s: Expected %q, got %+v", test.name, test.expected, *roundTrip)
+		}
+	}
+}
+
+type PaddedStringTestRecord struct {
+	Right string `csv:"right" format:"%8s"`
+	Left  string `csv:"left" format:"%-8s"`
+}
+
+func TestPaddedStringRoundTrip(t *testing.T) {
+	var buffer strings.Builder
+	writer := typedcsv.NewWriterTo[PaddedStringTestRecord](&buffer)
+	record := PaddedStringTestRecord{Right: "a b", Left: "c d"}
+	if err := writer.WriteRecord(record); err != nil {
+		t.Fatal(err)
+	}
+	writer.Flush()
+	if expected := "\"     a b\",c d     \n"; buffer.String() != expected {
+		t.Fatalf("Expected %q, got %q", expected, buffer.String())
+	}
+	reader := typedcsv.NewReaderFrom[PaddedStringTestRecord](strings.NewReader("right,left\n" + buffer.String()))
+	if err := reader.ReadHeader(); err != nil {
+		t.Fatal(err)
+	}
+	got, err := reader.ReadRecord()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *got != record {
+		t.Fatalf("Expected %+v, got %+v", record, *got)
+	}
+}
+
+type IntegerRangeTestRecord struct {
+	Int8   int8    `csv:"int8"`
+	Int16  int16   `csv:"int16"`
+	Int32  int32   `csv:"int32"`
+	Int64  int64   `csv:"int64"`
+	Uint8  uint8   `csv:"uint8"`
+	Uint16 uint16  `csv:"uint16"`
+	Uint32 uint32  `csv:"uint32"`
+	Uint64 uint64  `csv:"uint64"`
+	Hex    uint8   `csv:"hex" format:"%x"`
+	Bytes  []uint8 `csv:"bytes" separator:";"`
+}
+
+func TestIntegerRange(t *testing.T) {
+	header := "int8,int16,int32,int64,uint8,uint16,uint32,uint64,hex,bytes\n"
+	reader := typedcsv.NewReaderFrom[IntegerRangeTestRecord](strings.NewReader(header +
+		"-128,32767,-2147483648,9223372036854775807,255,65535,4294967295,18446744073709551615,ff,0;255\n"))
+	if err := reader.ReadHeader(); err != nil {
+		t.Fatal(err)
+	}
+	record, err := reader.ReadRecord()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if record.Int8 != -128 || record.Int64 != 9223372036854775807 || record.Uint64 != 18446744073709551615 || record.Hex != 255 || record.Bytes[1] != 255 {
+		t.Fatalf("Expected the bounds, got %+v", *record)
+	}
+
+	tests := []struct {
+		row      string
+		expected string
+	}{
+		{"128,0,0,0,0,0,0,0,0,0", "typedcsv: error parsing field 'int8' in record 1 on line 2: typedcsv: value out of range: '128' does not fit in int8, whose range is -128 to 127"},
+		{"0,-32769,0,0,0,0,0,0,0,0", "typedcsv: error parsing field 'int16' in record 1 on line 2: typedcsv: value out of range: '-32769' does not fit in int16, whose range is -32768 to 32767"},
+		{"0,0,2147483648,0,0,0,0,0,0,0", "typedcsv: error parsing field 'int32' in record 1 on line 2: typedcsv: value out of range: '2147483648' does not fit in int32, whose range is -2147483648 to 2147483647"},
+		{"0,0,0,9223372036854775808,0,0,0,0,0,0", "typedcsv: error parsing field 'int64' in record 1 on line 2: typedcsv: value out of range: '9223372036854775808' does not fit in int64, whose range is -9223372036854775808 to 9223372036854775807"},
+		{"0,0,0,0,300,0,0,0,0,0", "typedcsv: error parsing field 'uint8' in record 1 on line 2: typedcsv: value out of range: '300' does not fit in uint8, whose range is 0 to 255"},
+		{"0,0,0,0,0,65536,0,0,0,0", "typedcsv: error parsing field 'uint16' in record 1 on line 2: typedcsv: value out of range: '65536' does not fit in uint16, whose range is 0 to 65535"},
+		{"0,0,0,0,0,0,-5,0,0,0", "typedcsv: error parsing field 'uint32' in record 1 on line 2: typedcsv: value out of range: '-5' does not fit in uint32, whose range is 0 to 4294967295"},
+		{"0,0,0,0,0,0,0,18446744073709551616,0,0", "typedcsv: error parsing field 'uint64' in record 1 on line 2: typedcsv: value out of range: '18446744073709551616' does not fit in uint64, whose range is 0 to 18446744073709551615"},
+		{"0,0,0,0,0,0,0,0,100,0", "typedcsv: error parsing field 'hex' in record 1 on line 2: typedcsv: value out of range: '100' does not fit in uint8, whose range is 0 to 255"},
+		{"0,0,0,0,0,0,0,0,0,1;256", "typedcsv: error parsing field 'bytes[1]' in record 1 on line 2: typedcsv: value out of range: '256' does not fit in uint8, whose range is 0 to 255"},
+	}
+	for _, test := range tests {
+		reader := typedcsv.NewReaderFrom[IntegerRangeTestRecord](strings.NewReader(header + test.row + "\n"))
+		if err := reader.ReadHeader(); err != nil {
+			t.Fatal(err)
+		}
+		_, err := reader.ReadRecord()
+		if err == nil || err.Error() != test.expected {
+			t.Fatalf("%q: Expected %v, got %v", test.row, test.expected, err)
+		}
+		if !errors.Is(err, typedcsv.ErrOutOfRange) {
+			t.Fatalf("%q: Expected %v, got %v", test.row, typedcsv.ErrOutOfRange, err)
+		}
+		var fieldErr typedcsv.FieldParseError
+		if !errors.As(err, &fieldErr) {
+			t.Fatalf("%q: Expected a FieldParseError, got %v", test.row, err)
+		}
+	}
+}
@@ -0,0 +1,257 @@
+package typedcsv
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Column types of a Schema.
+const (
+	SchemaString   = "string"
+	SchemaInt      = "int"
+	SchemaUint     = "uint"
+	SchemaFloat    = "float"
+	SchemaBool     = "bool"
+	SchemaTime     = "time"
+	SchemaDuration = "duration"
+)
+
+// A Schema describes the columns of a CSV file without a Go struct.
+// It is used by DynamicReader and DynamicWriter, and can be loaded from JSON or YAML with ParseSchema.
+type Schema struct {
+	Columns []SchemaColumn `json:"columns" yaml:"columns"`
+}
+
+// A SchemaColumn describes one column of a Schema.
+type SchemaColumn struct {
+	// Name is the column name in the header.
+	Name string `json:"name" yaml:"name"`
+	// Type is one of SchemaString, SchemaInt, SchemaUint, SchemaFloat, SchemaBool, SchemaTime or SchemaDuration.
+	// An empty type means SchemaString.
+	Type string `json:"type,omitempty" yaml:"type,omitempty"`
+	// Format is the time layout of SchemaTime columns (time.RFC3339 by default),
+	// or the fmt format used to write other columns.
+	Format string `json:"format,omitempty" yaml:"format,omitempty"`
+	// Location is the time.Location name of SchemaTime columns.
+	Location string `json:"location,omitempty" yaml:"location,omitempty"`
+	// Null is the value representing a null value. Null values are read as nil.
+	Null *string `json:"null,omitempty" yaml:"null,omitempty"`
+	// Required makes the column mandatory in the header and its values non-empty.
+	Required bool `json:"required,omitempty" yaml:"required,omitempty"`
+	// Enum lists the allowed values, if not empty.
+	Enum []string `json:"enum,omitempty" yaml:"enum,omitempty"`
+	// Pattern is a regular expression the values must match, if not empty.
+	Pattern string `json:"pattern,omitempty" yaml:"pattern,omitempty"`
+	// Min and Max bound numeric values, or the length of string values.
+	Min *float64 `json:"min,omitempty" yaml:"min,omitempty"`
+	Max *float64 `json:"max,omitempty" yaml:"max,omitempty"`
+
+	pattern  *regexp.Regexp
+	location *time.Location
+}
+
+// ErrValidation is wrapped by the errors returned when a value does not satisfy the validations of its column.
+var ErrValidation = errors.New("typedcsv: validation failed")
+
+// LoadSchema reads a JSON schema from r.
+func LoadSchema(r io.Reader) (*Schema, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSchema(data, json.Unmarshal)
+}
+
+// ParseSchema parses a schema from data with the given unmarshal function,
+// for example json.Unmarshal or Unmarshal of gopkg.in/yaml.v3, and compiles it.
+func ParseSchema(data []byte, unmarshal func([]byte, any) error) (*Schema, error) {
+	var schema Schema
+	err := unmarshal(data, &schema)
+	if err != nil {
+		return nil, err
+	}
+	err = schema.Compile()
+	if err != nil {
+		return nil, err
+	}
+	return &schema, nil
+}
+
+// Compile checks the schema and prepares its patterns and locations.
+// It must be called on schemas that are not created by LoadSchema or ParseSchema before use.
+func (s *Schema) Compile() error {
+	seen := make(map[string]bool)
+	for i := range s.Columns {
+		column := &s.Columns[i]
+		if column.Name == "" {
+			return fmt.Errorf("typedcsv: schema column %d has no name", i)
+		}
+		if seen[column.Name] {
+			return fmt.Errorf("typedcsv: schema column '%s' is duplicated", column.Name)
+		}
+		seen[column.Name] = true
+		switch column.Type {
+		case "":
+			column.Type = SchemaString
+		case SchemaString, SchemaInt, SchemaUint, SchemaFloat, SchemaBool, SchemaTime, SchemaDuration:
+		default:
+			return fmt.Errorf("typedcsv: schema column '%s' has unknown type '%s'", column.Name, column.Type)
+		}
+		if column.Pattern != "" {
+			pattern, err := regexp.Compile(column.Pattern)
+			if err != nil {
+				return fmt.Errorf("typedcsv: schema column '%s': %w", column.Name, err)
+			}
+			column.pattern = pattern
+		}
+		if column.Location != "" {
+			location, err := time.LoadLocation(column.Location)
+			if err != nil {
+				return fmt.Errorf("typedcsv: schema column '%s': %w", column.Name, err)
+			}
+			column.location = location
+		}
+	}
+	return nil
+}
+
+// Header returns the column names of the schema.
+func (s *Schema) Header() []string {
+	header := make([]string, len(s.Columns))
+	for i, column := range s.Columns {
+		header[i] = column.Name
+	}
+	return header
+}
+
+// parse validates and converts a CSV value of the column.
+func (c *SchemaColumn) parse(value string) (any, error) {
+	if c.Null != nil && value == *c.Null {
+		if c.Required {
+			return nil, fmt.Errorf("%w: value is required", ErrValidation)
+		}
+		return nil, nil
+	}
+	err := c.validateText(value)
+	if err != nil {
+		return nil, err
+	}
+	var parsed any
+	switch c.Type {
+	case SchemaInt:
+		parsed, err = strconv.ParseInt(strings.TrimSpace(value), 10, 64)
+	case SchemaUint:
+		parsed, err = strconv.ParseUint(strings.TrimSpace(value), 10, 64)
+	case SchemaFloat:
+		parsed, err = strconv.ParseFloat(strings.TrimSpace(value), 64)
+	case SchemaBool:
+		parsed, err = strconv.ParseBool(strings.TrimSpace(value))
+	case SchemaDuration:
+		parsed, err = time.ParseDuration(strings.TrimSpace(value))
+	case SchemaTime:
+		layout := c.Format
+		if layout == "" {
+			layout = time.RFC3339
+		}
+		if c.location != nil {
+			parsed, err = time.ParseInLocation(layout, value, c.location)
+		} else {
+			parsed, err = time.Parse(layout, value)
+		}
+	default:
+		parsed = value
+	}
+	if err != nil {
+		return nil, err
+	}
+	return parsed, c.validateRange(parsed, value)
+}
+
+func (c *SchemaColumn) validateText(value string) error {
+	if c.Required && value == "" {
+		return fmt.Errorf("%w: value is required", ErrValidation)
+	}
+	if len(c.Enum) > 0 {
+		found := false
+		for _, allowed := range c.Enum {
+			if value == allowed {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("%w: value '%s' is not one of %s", ErrValidation, value, strings.Join(c.Enum, ", "))
+		}
+	}
+	if c.pattern != nil && !c.pattern.MatchString(value) {
+		return fmt.Errorf("%w: value '%s' does not match pattern '%s'", ErrValidation, value, c.Pattern)
+	}
+	return nil
+}
+
+func (c *SchemaColumn) validateRange(parsed any, value string) error {
+	if c.Min == nil && c.Max == nil {
+		return nil
+	}
+	var n float64
+	subject := fmt.Sprintf("value '%s'", value)
+	switch v := parsed.(type) {
+	case int64:
+		n = float64(v)
+	case uint64:
+		n = float64(v)
+	case float64:
+		n = v
+	case string:
+		n = float64(len([]rune(v)))
+		subject = "length of " + subject
+	default:
+		return nil
+	}
+	if c.Min != nil && n < *c.Min {
+		return fmt.Errorf("%w: %s is less than %v", ErrValidation, subject, *c.Min)
+	}
+	if c.Max != nil && n > *c.Max {
+		return fmt.Errorf("%w: %s is greater than %v", ErrValidation, subject, *c.Max)
+	}
+	return nil
+}
+
+// format converts a value of the column to a CSV value and validates it.
+func (c *SchemaColumn) format(value any) (string, error) {
+	if value == nil {
+		if c.Null == nil || c.Required {
+			return "", fmt.Errorf("%w: value is required", ErrValidation)
+		}
+		return *c.Null, nil
+	}
+	var text string
+	switch v := value.(type) {
+	case time.Time:
+		layout := c.Format
+		if layout == "" {
+			layout = time.RFC3339
+		}
+		if c.location != nil {
+			v = v.In(c.location)
+		}
+		text = v.Format(layout)
+	default:
+		format := c.Format
+		if format == "" || c.Type == SchemaTime {
+			format = "%v"
+		}
+		text = fmt.Sprintf(format, v)
+	}
+	_, err := c.parse(text)
+	if err != nil {
+		return "", err
+	}
+	return text, nil
+}
@@ -0,0 +1,67 @@
+package typedcsv
+
+import (
+	"errors"
+	"strings"
+)
+
+var errSliceItemQuote = errors.New("bare or unterminated quote in slice item")
+
+// splitItems splits a slice value into its items.
+// If the field is quoted, items may be enclosed in double quotes to contain the separator,
+// and double quotes inside quoted items are escaped by doubling them.
+// Without a separator, items formatted with a fixed width, such as "%02x", are split by width.
+func (f *field) splitItems(value string) ([]string, error) {
+	if f.separator == "" && f.scanWidth > 0 {
+		return splitWidth(value, f.scanWidth), nil
+	}
+	if !f.quoted {
+		return strings.Split(value, f.separator), nil
+	}
+	var items []string
+	for {
+		if !strings.HasPrefix(value, `"`) {
+			item, rest, found := strings.Cut(value, f.separator)
+			if strings.Contains(item, `"`) {
+				return nil, errSliceItemQuote
+			}
+			items = append(items, item)
+			if !found {
+				return items, nil
+			}
+			value = rest
+			continue
+		}
+		var builder strings.Builder
+		value = value[1:]
+		for {
+			i := strings.IndexByte(value, '"')
+			if i < 0 {
+				return nil, errSliceItemQuote
+			}
+			builder.WriteString(value[:i])
+			value = value[i+1:]
+			if !strings.HasPrefix(value, `"`) {
+				break
+			}
+			builder.WriteByte('"')
+			value = value[1:]
+		}
+		items = append(items, builder.String())
+		if value == "" {
+			return items, nil
+		}
+		if !strings.HasPrefix(value, f.separator) {
+			return nil, errSliceItemQuote
+		}
+		value = value[len(f.separator):]
+	}
+}
+
+// quoteItem quotes a slice item if the field is quoted and the item contains the separator or a double quote.
+func (f *field) quoteItem(item string) string {
+	if !f.quoted || (!strings.Contains(item, f.separator) && !strings.Contains(item, `"`)) {
+		return item
+	}
+	return `"` + strings.ReplaceAll(item, `"`, `""`) + `"`
+}
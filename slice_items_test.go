@@ -0,0 +1,65 @@
+package typedcsv_test
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/hoshiumiarata/typedcsv"
+)
+
+type QuotedSliceTestRecord struct {
+	Items []string `csv:"items" separator:";" quoted:"true"`
+}
+
+func TestQuotedSlice(t *testing.T) {
+	writer := bytes.Buffer{}
+	csvWriter := typedcsv.NewWriter[QuotedSliceTestRecord](csv.NewWriter(&writer))
+	err := csvWriter.WriteHeader()
+	if err != nil {
+		t.Fatal(err)
+	}
+	items := []string{"a;b", "c", `d"e`}
+	err = csvWriter.WriteRecord(QuotedSliceTestRecord{Items: items})
+	if err != nil {
+		t.Fatal(err)
+	}
+	csvWriter.Flush()
+	expected := "items\n\"\"\"a;b\"\";c;\"\"d\"\"\"\"e\"\"\"\n"
+	if writer.String() != expected {
+		t.Fatalf("Expected %q, got %q", expected, writer.String())
+	}
+
+	csvReader := typedcsv.NewReader[QuotedSliceTestRecord](csv.NewReader(&writer))
+	err = csvReader.ReadHeader()
+	if err != nil {
+		t.Fatal(err)
+	}
+	record, err := csvReader.ReadRecord()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(record.Items, items) {
+		t.Fatalf("Expected %q, got %q", items, record.Items)
+	}
+
+	for _, value := range []string{`"a;b`, `"a"b;c`, `a"b;c`} {
+		reader := bytes.Buffer{}
+		csvWriter := csv.NewWriter(&reader)
+		_ = csvWriter.Write([]string{"items"})
+		_ = csvWriter.Write([]string{value})
+		csvWriter.Flush()
+		csvReader := typedcsv.NewReader[QuotedSliceTestRecord](csv.NewReader(&reader))
+		err = csvReader.ReadHeader()
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = csvReader.ReadRecord()
+		var fieldParseError typedcsv.FieldParseError
+		if !errors.As(err, &fieldParseError) {
+			t.Fatalf("Expected %T for %q, got %v", fieldParseError, value, err)
+		}
+	}
+}
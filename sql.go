@@ -0,0 +1,163 @@
+package typedcsv
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// A SQLDialect selects the identifier quoting, string escaping and placeholder style of generated SQL.
+type SQLDialect int
+
+const (
+	// PostgreSQL quotes identifiers with double quotes and uses $1, $2, ... placeholders.
+	PostgreSQL SQLDialect = iota
+	// MySQL quotes identifiers with backticks, escapes backslashes in strings and uses ? placeholders.
+	MySQL
+	// SQLite quotes identifiers with double quotes and uses ? placeholders.
+	SQLite
+	// SQLServer quotes identifiers with brackets and uses @p1, @p2, ... placeholders.
+	SQLServer
+)
+
+// An InsertStatement is an INSERT statement generated by ToInserts.
+type InsertStatement struct {
+	// Query is the parameterized statement.
+	Query string
+	// Args are the arguments of Query. Null values are nil, other values are strings formatted according to the tags.
+	Args []any
+	// Literal is the statement with the values inlined and quoted, suitable for seed or migration scripts.
+	Literal string
+}
+
+// ToInserts returns one INSERT statement per record into the given table.
+// The column names are the ones used by TypedCSVWriter, and the values are formatted like TypedCSVWriter does,
+// including the values generated for the "default_func" tag. Nil pointers and the fields excluded by their "include_if" tag
+// are inserted as NULL. Numbers and booleans are inlined unquoted in Literal only if they are formatted by strconv,
+// without "format", "base", "locale" or other tags changing their text; other values are quoted as strings.
+// It returns the TypeError of the Codec if T cannot be used as a record, and a FieldFormatError if a field cannot be formatted,
+// or if a float field is NaN or infinite, which SQL cannot represent portably.
+func ToInserts[T any](records []T, table string, dialect SQLDialect, opts ...Option) ([]InsertStatement, error) {
+	codec := NewCodec[T](opts...)
+	if codec.typeErr != nil {
+		return nil, codec.typeErr
+	}
+	columns := make([]string, len(codec.header))
+	for i, column := range codec.header {
+		columns[i] = dialect.quoteIdentifier(column)
+	}
+	prefix := fmt.Sprintf("INSERT INTO %s (%s) VALUES (", dialect.quoteIdentifier(table), strings.Join(columns, ", "))
+	placeholders := make([]string, len(codec.fields))
+	for i := range placeholders {
+		placeholders[i] = dialect.placeholder(i + 1)
+	}
+	query := prefix + strings.Join(placeholders, ", ") + ");"
+
+	statements := make([]InsertStatement, 0, len(records))
+	for _, record := range records {
+		recordValue := reflect.ValueOf(record)
+		for i := range codec.fields {
+			field := &codec.fields[i]
+			fieldValue, ok := field.valueForEncode(recordValue)
+			if !ok || (field.pointer && fieldValue.IsNil()) {
+				continue
+			}
+			if number := reflect.Indirect(fieldValue); number.CanFloat() && (math.IsNaN(number.Float()) || math.IsInf(number.Float(), 0)) {
+				return statements, FieldFormatError{Field: field.name, NestedError: fmt.Errorf("%v cannot be inserted in SQL", number.Float())}
+			}
+		}
+		nulls := make([]bool, len(codec.fields))
+		values, err := codec.encodeFields(record, true, true, nulls)
+		if err != nil {
+			return statements, err
+		}
+		args := make([]any, len(codec.fields))
+		literals := make([]string, len(codec.fields))
+		for i := range codec.fields {
+			if nulls[i] {
+				literals[i] = "NULL"
+				continue
+			}
+			args[i] = values[i]
+			literals[i] = dialect.literal(&codec.fields[i], values[i])
+		}
+		statements = append(statements, InsertStatement{
+			Query:   query,
+			Args:    args,
+			Literal: prefix + strings.Join(literals, ", ") + ");",
+		})
+	}
+	return statements, nil
+}
+
+func (d SQLDialect) quoteIdentifier(identifier string) string {
+	switch d {
+	case MySQL:
+		return "`" + strings.ReplaceAll(identifier, "`", "``") + "`"
+	case SQLServer:
+		return "[" + strings.ReplaceAll(identifier, "]", "]]") + "]"
+	default:
+		return `"` + strings.ReplaceAll(identifier, `"`, `""`) + `"`
+	}
+}
+
+func (d SQLDialect) placeholder(n int) string {
+	switch d {
+	case PostgreSQL:
+		return "$" + strconv.Itoa(n)
+	case SQLServer:
+		return "@p" + strconv.Itoa(n)
+	default:
+		return "?"
+	}
+}
+
+func (d SQLDialect) literal(f *field, value string) string {
+	switch f.typ.Kind() {
+	case reflect.Bool:
+		if f.plainText() {
+			b := value == "true"
+			switch {
+			case d == PostgreSQL && b:
+				return "TRUE"
+			case d == PostgreSQL:
+				return "FALSE"
+			case b:
+				return "1"
+			default:
+				return "0"
+			}
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		if f.plainText() {
+			return value
+		}
+	}
+	if d == MySQL {
+		value = strings.ReplaceAll(value, `\`, `\\`)
+	}
+	return "'" + strings.ReplaceAll(value, "'", "''") + "'"
+}
+
+// plainText reports whether the values of the field are the decimal text of numbers or booleans, formatted without a
+// String method, a "format" tag other than a decimal verb, or another tag or method changing their text,
+// so that they can be inlined in SQL.
+func (f *field) plainText() bool {
+	if f.hasFormat && !sqlDecimalFormat.MatchString(f.format) {
+		return false
+	}
+	return !f.raw && !f.marshaler && !f.hasBase && f.locale == nil && f.enum == nil && !f.omitEmpty &&
+		f.converter == nil && f.formatMethod == nil && f.translation == nil && f.nested == nil && f.durationCodec == nil &&
+		!f.typ.Implements(stringerType) && !f.typ.Implements(errorType)
+}
+
+var (
+	stringerType = reflect.TypeFor[fmt.Stringer]()
+	// sqlDecimalFormat matches the formats of a single decimal verb, such as "%d", "%.2f" or "%e".
+	sqlDecimalFormat = regexp.MustCompile(`^%[+ ]?[0-9]*(\.[0-9]+)?[dfFeEgG]$`)
+)
@@ -0,0 +1,103 @@
+package typedcsv_test
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/hoshiumiarata/typedcsv"
+)
+
+func TestToInserts(t *testing.T) {
+	optional := `it's a \ test`
+	records := []Person{
+		{
+			Name:       "John",
+			Birthday:   time.Date(1970, 6, 17, 0, 0, 0, 0, time.UTC),
+			Age:        55,
+			PetNames:   []string{"Fluffy", "Spot"},
+			Active:     true,
+			Status:     PersonStatusActive,
+			Percentage: 12.3456,
+			Optional:   &optional,
+		},
+		{Name: "Mary"},
+	}
+	statements, err := typedcsv.ToInserts(records, "people", typedcsv.PostgreSQL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `INSERT INTO "people" ("name", "birthday", "age", "pet names", "active", "status", "percentage", "optional") VALUES ($1, $2, $3, $4, $5, $6, $7, $8);`
+	if statements[0].Query != expected {
+		t.Fatalf("Expected %q, got %q", expected, statements[0].Query)
+	}
+	expectedArgs := []any{"John", "1970-06-17", "55", "Fluffy;Spot", "true", "active", "12.35", optional}
+	if !reflect.DeepEqual(statements[0].Args, expectedArgs) {
+		t.Fatalf("Expected %v, got %v", expectedArgs, statements[0].Args)
+	}
+	expected = `INSERT INTO "people" ("name", "birthday", "age", "pet names", "active", "status", "percentage", "optional") VALUES ('John', '1970-06-17', 55, 'Fluffy;Spot', TRUE, 'active', 12.35, 'it''s a \ test');`
+	if statements[0].Literal != expected {
+		t.Fatalf("Expected %q, got %q", expected, statements[0].Literal)
+	}
+	if statements[1].Args[7] != nil {
+		t.Fatalf("Expected %v, got %v", nil, statements[1].Args[7])
+	}
+
+	statements, err = typedcsv.ToInserts(records, "people", typedcsv.MySQL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected = "INSERT INTO `people` (`name`, `birthday`, `age`, `pet names`, `active`, `status`, `percentage`, `optional`) VALUES ('Mary', '0001-01-01', 0, '', 0, 'unknown', 0.00, NULL);"
+	if statements[1].Literal != expected {
+		t.Fatalf("Expected %q, got %q", expected, statements[1].Literal)
+	}
+	statements, err = typedcsv.ToInserts(records[:1], "people", typedcsv.MySQL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `'it''s a \\ test');`; statements[0].Literal[len(statements[0].Literal)-len(want):] != want {
+		t.Fatalf("Expected suffix %q, got %q", want, statements[0].Literal)
+	}
+}
+
+func TestToInsertsNonFinite(t *testing.T) {
+	for _, percentage := range []float64{math.NaN(), math.Inf(1), math.Inf(-1)} {
+		_, err := typedcsv.ToInserts([]Person{{Name: "John", Percentage: percentage}}, "people", typedcsv.PostgreSQL)
+		var formatErr typedcsv.FieldFormatError
+		if !errors.As(err, &formatErr) || formatErr.Field != "percentage" {
+			t.Fatalf("Expected a FieldFormatError for percentage, got %v", err)
+		}
+	}
+}
+
+type SQLTagsTestRecord struct {
+	ID     int    `csv:"id" base:"16"`
+	Code   int    `csv:"code" format:"%03d"`
+	Region string `csv:"region" include_if:"HasRegion"`
+}
+
+func (r SQLTagsTestRecord) HasRegion() bool {
+	return r.Region != ""
+}
+
+func TestToInsertsTags(t *testing.T) {
+	statements, err := typedcsv.ToInserts([]SQLTagsTestRecord{{ID: 255, Code: 7}}, "codes", typedcsv.SQLite)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `INSERT INTO "codes" ("id", "code", "region") VALUES ('ff', 007, NULL);`
+	if statements[0].Literal != expected {
+		t.Fatalf("Expected %q, got %q", expected, statements[0].Literal)
+	}
+	if statements[0].Args[2] != nil {
+		t.Fatalf("Expected %v, got %v", nil, statements[0].Args[2])
+	}
+
+	_, err = typedcsv.ToInserts([]int{1}, "numbers", typedcsv.SQLite)
+	var typeError typedcsv.TypeError
+	if !errors.As(err, &typeError) {
+		t.Fatalf("Expected %T, got %v", typeError, err)
+	}
+}
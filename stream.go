@@ -0,0 +1,44 @@
+package typedcsv
+
+import (
+	"context"
+	"io"
+)
+
+// ReadStream reads the remaining records in a new goroutine and sends them on the returned records channel,
+// so that they can be consumed concurrently, for example by a pool of workers ranging over the channel.
+// Both channels are closed when reading ends. At most one error is sent on the error channel, which is buffered:
+// the first error of ReadRecord other than io.EOF, or the error of ctx once it is done. Reading stops at that error.
+//
+// Cancelling ctx stops the goroutine before the next record is read or while it waits for a consumer,
+// but not during a read blocked on the underlying reader. The TypedCSVReader must not be used until the channels are closed.
+// Like ReadRecord, ReadStream requires ReadHeader to be called first, unless the fields are mapped by position.
+func (r *TypedCSVReader[T]) ReadStream(ctx context.Context) (<-chan *T, <-chan error) {
+	records := make(chan *T)
+	errs := make(chan error, 1)
+	go func() {
+		defer close(records)
+		defer close(errs)
+		for {
+			if err := ctx.Err(); err != nil {
+				errs <- err
+				return
+			}
+			record, err := r.ReadRecord()
+			if err == io.EOF {
+				return
+			}
+			if err != nil {
+				errs <- err
+				return
+			}
+			select {
+			case records <- record:
+			case <-ctx.Done():
+				errs <- ctx.Err()
+				return
+			}
+		}
+	}()
+	return records, errs
+}
@@ -0,0 +1,85 @@
+package typedcsv_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/hoshiumiarata/typedcsv"
+)
+
+func streamInput(n int) *bytes.Buffer {
+	var buffer bytes.Buffer
+	buffer.WriteString("id,name\n")
+	for i := 1; i <= n; i++ {
+		fmt.Fprintf(&buffer, "%d,x\n", i)
+	}
+	return &buffer
+}
+
+func TestReadStream(t *testing.T) {
+	csvReader := typedcsv.NewReader[AppendTestRecord](csv.NewReader(streamInput(100)))
+	if err := csvReader.ReadHeader(); err != nil {
+		t.Fatal(err)
+	}
+	records, errs := csvReader.ReadStream(context.Background())
+	var sum atomic.Int64
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for record := range records {
+				sum.Add(int64(record.ID))
+			}
+		}()
+	}
+	wg.Wait()
+	if err := <-errs; err != nil {
+		t.Fatal(err)
+	}
+	if sum.Load() != 5050 {
+		t.Fatalf("Expected %v, got %v", 5050, sum.Load())
+	}
+}
+
+func TestReadStreamCancel(t *testing.T) {
+	csvReader := typedcsv.NewReader[AppendTestRecord](csv.NewReader(streamInput(100)))
+	if err := csvReader.ReadHeader(); err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	records, errs := csvReader.ReadStream(ctx)
+	count := 0
+	for range records {
+		count++
+		if count == 10 {
+			cancel()
+		}
+	}
+	if err := <-errs; !errors.Is(err, context.Canceled) || count > 11 {
+		t.Fatalf("Expected %v after at most 11 records, got %v after %d", context.Canceled, err, count)
+	}
+}
+
+func TestReadStreamError(t *testing.T) {
+	csvReader := typedcsv.NewReader[AppendTestRecord](csv.NewReader(bytes.NewBufferString("id,name\n1,a\nx,b\n3,c\n")))
+	if err := csvReader.ReadHeader(); err != nil {
+		t.Fatal(err)
+	}
+	records, errs := csvReader.ReadStream(context.Background())
+	count := 0
+	for range records {
+		count++
+	}
+	var fieldParseError typedcsv.FieldParseError
+	if err := <-errs; !errors.As(err, &fieldParseError) || count != 1 {
+		t.Fatalf("Expected a FieldParseError after 1 record, got %v after %d", err, count)
+	}
+}
@@ -0,0 +1,111 @@
+package typedcsv
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// checkTags reports the misconfigured tags of the fields as TagErrors joined with errors.Join,
+// followed by the errors found by other checks, such as positionsOf.
+// path is the Go name path of the struct holding the fields, for nested rows.
+func checkTags(fields []field, path string, others ...error) error {
+	var errs []error
+	report := func(f *field, tag, message string, args ...any) {
+		errs = append(errs, TagError{Field: path + f.path, Tag: tag, Message: fmt.Sprintf(message, args...)})
+	}
+	columns := make(map[string]string, len(fields))
+	positions := make(map[int]string)
+	for i := range fields {
+		f := &fields[i]
+		if other, ok := columns[f.key]; ok {
+			report(f, csvTag, "column %q is also used by field %s", f.name, path+other)
+		} else {
+			columns[f.key] = f.path
+		}
+		switch {
+		case f.prefer != "" && f.prefer != "text" && f.prefer != "time":
+			report(f, preferTag, "unknown value %q, expected \"text\" or \"time\"", f.prefer)
+		case f.prefer == "" && f.time && (f.unmarshaler || f.marshaler) && f.typ != timeType:
+			report(f, preferTag, "field type %v converts to time.Time and implements encoding.TextMarshaler or encoding.TextUnmarshaler, set prefer to \"text\" or \"time\"", f.typ)
+		}
+		if f.positionErr != nil {
+			report(f, indexTag, "%v", f.positionErr)
+		} else if other, ok := positions[f.position]; ok && f.hasPosition {
+			report(f, indexTag, "index %d is also used by field %s", f.position, path+other)
+		} else if f.hasPosition {
+			positions[f.position] = f.path
+		}
+		if f.includeIf != nil && f.includeIf.err != nil {
+			report(f, includeIfTag, "%v", f.includeIf.err)
+		}
+		if f.parseMethod != nil && f.parseMethod.err != nil {
+			report(f, parseMethodTag, "%v", f.parseMethod.err)
+		}
+		if f.formatMethod != nil && f.formatMethod.err != nil {
+			report(f, formatMethodTag, "%v", f.formatMethod.err)
+		}
+		if f.validationErr != nil {
+			report(f, "validation", "%v", f.validationErr)
+		}
+		if f.hasTimeFormat && !f.time {
+			report(f, timeFormatTag, "field type %v is not a time", f.typ)
+		}
+		if f.hasTimeZone && !f.time {
+			report(f, timeZoneTag, "field type %v is not a time", f.typ)
+		} else if f.timeZoneErr != nil {
+			report(f, timeZoneTag, "%v", f.timeZoneErr)
+		}
+		if f.hasTimeLocation && !f.time {
+			report(f, timeLocationTag, "field type %v is not a time", f.typ)
+		}
+		if (f.timeTruncate != 0 || f.timeRound != 0 || f.timeAdjustErr != nil) && !f.time {
+			report(f, timeTruncateTag+"/"+timeRoundTag, "field type %v is not a time", f.typ)
+		}
+		if (f.durationCodec != nil || f.durationErr != nil) && !f.duration {
+			report(f, durationTag, "field type %v is not a duration", f.typ)
+		}
+		if f.hasLocale && !f.localizable() {
+			report(f, localeTag, "field type %v is not a number or a time with a time_format", f.typ)
+		} else if f.localeErr != nil {
+			report(f, localeTag, "%v", f.localeErr)
+		}
+		if f.defaultErr != nil {
+			report(f, defaultTag, "invalid default %q: %v", f.defaultValue, f.defaultErr)
+		}
+		if f.defaultFuncErr != nil {
+			report(f, defaultFuncTag, "%v", f.defaultFuncErr)
+		} else if f.defaultFunc != nil && f.hasDefault {
+			report(f, defaultFuncTag, "field also has a default, which is used instead")
+		}
+		if f.baseErr != nil {
+			report(f, baseTag, "%v", f.baseErr)
+		}
+		if f.bytesErr != nil {
+			report(f, encodingTag, "%v", f.bytesErr)
+		}
+		if f.encoding != "" && f.encoding != "csv" && f.bytes == nil && f.bytesErr == nil {
+			report(f, encodingTag, "field type %v is not a byte array", f.typ)
+		}
+		if f.hasPrefix {
+			report(f, prefixTag, "field type %v is not a struct", f.typ)
+		}
+		if f.separator != "" && !f.slice && f.nested == nil {
+			report(f, separatorTag, "field type %v is not a slice", f.typ)
+		}
+		if f.hasFormat && !(f.timeFormatted()) && !f.marshaler && f.nested == nil {
+			typ := f.typ
+			if f.slice {
+				typ = f.sliceItemType
+			}
+			if text := fmt.Sprintf(f.format, reflect.Zero(typ).Interface()); strings.Contains(text, "%!") {
+				report(f, formatTag, "format %q cannot be used with type %v: %s", f.format, typ, text)
+			}
+		}
+		if f.nested != nil {
+			errs = append(errs, checkTags(f.nested, path+f.path+"."))
+		}
+	}
+	return errors.Join(append(errs, others...)...)
+}
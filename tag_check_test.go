@@ -0,0 +1,67 @@
+package typedcsv_test
+
+import (
+	"bytes"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hoshiumiarata/typedcsv"
+)
+
+type TagConflictTestRecord struct {
+	Name      string            `csv:"name" separator:";"`
+	Other     string            `csv:"name"`
+	Count     int               `csv:"count" format:"%s"`
+	Ratio     float64           `csv:"ratio" format:"%.2f %d"`
+	Date      string            `csv:"date" time_format:"2006-01-02"`
+	Items     []int             `csv:"items" separator:";" format:"%t"`
+	Time      time.Time         `csv:"time" duration_format:"seconds"`
+	Nested    TagConflictNested `csv:"nested" encoding:"csv"`
+	Formatted float64           `csv:"formatted" format:"%.1f"`
+}
+
+type TagConflictNested struct {
+	A int `csv:"a" time_location:"UTC"`
+}
+
+func TestCodecErr(t *testing.T) {
+	if err := typedcsv.NewCodec[Person]().Err(); err != nil {
+		t.Fatal(err)
+	}
+	if err := typedcsv.NewCodec[FormatTestRecord]().Err(); err != nil {
+		t.Fatal(err)
+	}
+	if err := typedcsv.NewCodec[SliceTestRecord]().Err(); err != nil {
+		t.Fatal(err)
+	}
+	if err := typedcsv.NewCodec[TimeTestRecord]().Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	logs := bytes.Buffer{}
+	codec := typedcsv.NewCodec[TagConflictTestRecord](typedcsv.WithLogger(slog.New(slog.NewTextHandler(&logs, nil))))
+	err := codec.Err()
+	var tagError typedcsv.TagError
+	if !errors.As(err, &tagError) {
+		t.Fatalf("Expected %T, got %v", tagError, err)
+	}
+	expected := []string{
+		"typedcsv: invalid tag separator on field Name: field type string is not a slice",
+		"typedcsv: invalid tag csv on field Other: column \"name\" is also used by field Name",
+		"typedcsv: invalid tag format on field Count: format \"%s\" cannot be used with type int: %!s(int=0)",
+		"typedcsv: invalid tag format on field Ratio: format \"%.2f %d\" cannot be used with type float64: 0.00 %!d(MISSING)",
+		"typedcsv: invalid tag time_format on field Date: field type string is not a time",
+		"typedcsv: invalid tag format on field Items: format \"%t\" cannot be used with type int: %!t(int=0)",
+		"typedcsv: invalid tag duration_format on field Time: field type time.Time is not a duration",
+		"typedcsv: invalid tag time_location on field Nested.A: field type int is not a time",
+	}
+	if err.Error() != strings.Join(expected, "\n") {
+		t.Fatalf("Expected %q, got %q", strings.Join(expected, "\n"), err.Error())
+	}
+	if !strings.Contains(logs.String(), "typedcsv: invalid struct tags") {
+		t.Fatalf("Expected a warning log, got %q", logs.String())
+	}
+}
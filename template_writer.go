@@ -0,0 +1,75 @@
+package typedcsv
+
+import (
+	"io"
+	"text/template"
+)
+
+// A TemplateRow is the data passed to the template of a TypedTemplateWriter for each record.
+type TemplateRow[T any] struct {
+	// Record is the record being written.
+	Record T
+	// Index is the zero-based index of the record.
+	Index int
+	// Columns are the column names, in the same order as Values.
+	Columns []string
+	// Values are the field values formatted as they would be written to a CSV file.
+	Values []string
+	// Fields maps the column names to the formatted values.
+	Fields map[string]string
+}
+
+// A TypedTemplateWriter writes structs by executing a text/template for each record instead of writing CSV cells.
+// It can be used to produce SQL, reports or configuration lines from the same records and tags as TypedCSVWriter.
+//
+// The template is executed with a TemplateRow[T], so it can use the typed record as well as the formatted values.
+type TypedTemplateWriter[T any] struct {
+	Writer   io.Writer
+	Template *template.Template
+
+	codec *Codec[T]
+	index int
+}
+
+// NewTemplateWriter returns a new TypedTemplateWriter that executes the given template for each record.
+func NewTemplateWriter[T any](writer io.Writer, tmpl *template.Template, opts ...Option) *TypedTemplateWriter[T] {
+	return NewCodec[T](opts...).NewTemplateWriter(writer, tmpl)
+}
+
+// NewTemplateWriter returns a new TypedTemplateWriter that uses the Codec.
+func (c *Codec[T]) NewTemplateWriter(writer io.Writer, tmpl *template.Template) *TypedTemplateWriter[T] {
+	return &TypedTemplateWriter[T]{
+		Writer:   writer,
+		Template: tmpl,
+		codec:    c,
+	}
+}
+
+// WriteRecord executes the template for the record.
+// It returns a FieldFormatError if a field cannot be formatted.
+// Otherwise, it returns any error returned by the template execution.
+func (w *TypedTemplateWriter[T]) WriteRecord(record T) error {
+	values, err := w.codec.encode(record)
+	if err != nil {
+		w.codec.options.emit(MetricsEvent{Kind: MetricsRecordFailed, Err: err})
+		return err
+	}
+	fields := make(map[string]string, len(values))
+	for i, column := range w.codec.header {
+		fields[column] = values[i]
+	}
+	err = w.Template.Execute(w.Writer, TemplateRow[T]{
+		Record:  record,
+		Index:   w.index,
+		Columns: w.codec.header,
+		Values:  values,
+		Fields:  fields,
+	})
+	if err != nil {
+		w.codec.options.emit(MetricsEvent{Kind: MetricsRecordFailed, Err: err})
+		return err
+	}
+	w.index++
+	w.codec.options.emit(MetricsEvent{Kind: MetricsRecordEncoded})
+	return nil
+}
@@ -0,0 +1,31 @@
+package typedcsv_test
+
+import (
+	"bytes"
+	"testing"
+	"text/template"
+	"time"
+
+	"github.com/hoshiumiarata/typedcsv"
+)
+
+func TestTemplateWriter(t *testing.T) {
+	writer := bytes.Buffer{}
+	tmpl := template.Must(template.New("row").Parse(
+		`{{.Index}}: {{.Record.Name}} born {{index .Fields "birthday"}} ({{len .Columns}} columns)` + "\n",
+	))
+	templateWriter := typedcsv.NewTemplateWriter[Person](&writer, tmpl)
+	for _, person := range []Person{
+		{Name: "John", Birthday: time.Date(1970, 6, 17, 0, 0, 0, 0, time.UTC)},
+		{Name: "Mary", Birthday: time.Date(1971, 7, 18, 0, 0, 0, 0, time.UTC)},
+	} {
+		err := templateWriter.WriteRecord(person)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	expected := "0: John born 1970-06-17 (8 columns)\n1: Mary born 1971-07-18 (8 columns)\n"
+	if writer.String() != expected {
+		t.Fatalf("Expected %q, got %q", expected, writer.String())
+	}
+}
@@ -0,0 +1,162 @@
+package typedcsv
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// A timeCodec parses and formats a special "time_format" tag value that cannot be expressed as a Go layout.
+type timeCodec struct {
+	parse  func(value string, location *time.Location) (time.Time, error)
+	format func(t time.Time) string
+}
+
+// timeCodecs are the special "time_format" tag values.
+var timeCodecs = map[string]*timeCodec{
+	"excel":     excelTimeCodec(time.Date(1899, time.December, 30, 0, 0, 0, 0, time.UTC), true),
+	"excel1904": excelTimeCodec(time.Date(1904, time.January, 1, 0, 0, 0, 0, time.UTC), false),
+	// Days since 1970-01-01.
+	"epoch_days": dayCountTimeCodec("epoch day", time.Unix(0, 0), 0, true),
+	// Julian Day, the number of days since noon UTC on November 24, 4714 BC in the proleptic Gregorian calendar.
+	"julian_day": dayCountTimeCodec("Julian day", time.Unix(0, 0), 2440587.5, false),
+	// Modified Julian Day, the Julian Day minus 2400000.5.
+	"mjd": dayCountTimeCodec("modified Julian day", time.Unix(0, 0), 40587, false),
+	// ISO 8601 week date, such as 2024-W05-3.
+	"iso_week": {parse: parseISOWeek, format: formatISOWeek},
+}
+
+// parseTime parses a time with the time format of the field, in the given location or UTC if it is nil.
+func (f *field) parseTime(value string, location *time.Location) (time.Time, error) {
+	if f.timeCodec != nil {
+		if location == nil {
+			location = time.UTC
+		}
+		return f.timeCodec.parse(value, location)
+	}
+	if location != nil {
+		return time.ParseInLocation(f.timeFormat, value, location)
+	}
+	return time.Parse(f.timeFormat, value)
+}
+
+// formatTime formats a time with the time format of the field.
+func (f *field) formatTime(t time.Time) string {
+	if f.timeCodec != nil {
+		return f.timeCodec.format(t)
+	}
+	return t.Format(f.timeFormat)
+}
+
+// adjustTime truncates and then rounds a time with the "time_truncate" and "time_round" tag values of the field.
+func (f *field) adjustTime(t time.Time) time.Time {
+	if f.timeTruncate > 0 {
+		t = t.Truncate(f.timeTruncate)
+	}
+	if f.timeRound > 0 {
+		t = t.Round(f.timeRound)
+	}
+	return t
+}
+
+// parseTimeAdjustTag parses the positive duration of a "time_truncate" or "time_round" tag value.
+func parseTimeAdjustTag(key, value string) (time.Duration, error) {
+	d, err := time.ParseDuration(value)
+	if err != nil || d <= 0 {
+		return 0, fmt.Errorf("invalid %s %q", key, value)
+	}
+	return d, nil
+}
+
+// excelTimeCodec returns the codec of Excel serial date-times, the number of days since the epoch with the time as fraction.
+// Negative serials are invalid, as in Excel.
+// If lotusBug is true, the serial 60 is the nonexistent 1900-02-29 of the 1900 date system,
+// so the serials before it are shifted by one day.
+func excelTimeCodec(epoch time.Time, lotusBug bool) *timeCodec {
+	codec := dayCountTimeCodec("Excel serial date", epoch, 0, true)
+	parse, format := codec.parse, codec.format
+	codec.parse = func(value string, location *time.Location) (time.Time, error) {
+		if serial, err := strconv.ParseFloat(strings.TrimSpace(value), 64); err == nil && serial < 0 {
+			return time.Time{}, fmt.Errorf("invalid Excel serial date %q", value)
+		}
+		t, err := parse(value, location)
+		if lotusBug && err == nil && t.Before(time.Date(1900, time.March, 1, 0, 0, 0, 0, location)) {
+			t = t.AddDate(0, 0, 1)
+		}
+		return t, err
+	}
+	if !lotusBug {
+		return codec
+	}
+	codec.format = func(t time.Time) string {
+		if time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC).Before(time.Date(1900, time.March, 1, 0, 0, 0, 0, time.UTC)) {
+			t = t.AddDate(0, 0, -1)
+		}
+		return format(t)
+	}
+	return codec
+}
+
+// dayCountTimeCodec returns the codec of day counts: the number of days since the epoch plus offset, with the time as fraction.
+// If wall is true, the count represents the wall clock in the location of the field. Otherwise, it is an absolute count in UTC.
+func dayCountTimeCodec(name string, epoch time.Time, offset float64, wall bool) *timeCodec {
+	const millisecondsPerDay = 24 * 60 * 60 * 1000
+	return &timeCodec{
+		parse: func(value string, location *time.Location) (time.Time, error) {
+			days, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+			if err != nil || math.IsInf(days, 0) || math.IsNaN(days) {
+				return time.Time{}, fmt.Errorf("invalid %s %q", name, value)
+			}
+			t := time.UnixMilli(epoch.UnixMilli() + int64(math.Round((days-offset)*millisecondsPerDay))).UTC()
+			if !wall {
+				return t.In(location), nil
+			}
+			return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), location), nil
+		},
+		format: func(t time.Time) string {
+			if wall {
+				t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), time.UTC)
+			}
+			days := float64(t.Round(time.Millisecond).UnixMilli()-epoch.UnixMilli())/millisecondsPerDay + offset
+			return strconv.FormatFloat(days, 'f', -1, 64)
+		},
+	}
+}
+
+// parseISOWeek parses an ISO 8601 week date "YYYY-Www-D", or "YYYY-Www" for the Monday of the week, as midnight in the location.
+func parseISOWeek(value string, location *time.Location) (time.Time, error) {
+	var year, week, weekday int
+	var err error
+	yearText, rest, ok := strings.Cut(value, "-W")
+	if ok {
+		year, err = strconv.Atoi(yearText)
+	}
+	if ok && err == nil {
+		weekText, weekdayText, hasWeekday := strings.Cut(rest, "-")
+		ok = len(weekText) == 2 && (!hasWeekday || len(weekdayText) == 1)
+		week, err = strconv.Atoi(weekText)
+		weekday = 1
+		if hasWeekday && err == nil {
+			weekday, err = strconv.Atoi(weekdayText)
+		}
+	}
+	if !ok || err != nil || week < 1 || weekday < 1 || weekday > 7 {
+		return time.Time{}, fmt.Errorf("invalid ISO week date %q", value)
+	}
+	// The week 1 is the week with the 4th of January.
+	january4 := time.Date(year, time.January, 4, 0, 0, 0, 0, location)
+	monday := january4.AddDate(0, 0, -(int(january4.Weekday())+6)%7)
+	t := monday.AddDate(0, 0, (week-1)*7+weekday-1)
+	if weekYear, _ := t.ISOWeek(); weekYear != year {
+		return time.Time{}, fmt.Errorf("invalid ISO week date %q: week %d out of range", value, week)
+	}
+	return t, nil
+}
+
+// formatISOWeek formats a time as an ISO 8601 week date "YYYY-Www-D".
+func formatISOWeek(t time.Time) string {
+	year, week := t.ISOWeek()
+	return fmt.Sprintf("%04d-W%02d-%d", year, week, (int(t.Weekday())+6)%7+1)
+}
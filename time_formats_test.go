@@ -0,0 +1,232 @@
+package typedcsv_test
+
+import (
+	"bytes"
+	"encoding/csv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hoshiumiarata/typedcsv"
+)
+
+type ExcelTimeTestRecord struct {
+	Time     time.Time  `csv:"time" time_format:"excel"`
+	Time1904 time.Time  `csv:"time_1904" time_format:"excel1904"`
+	Local    CustomTime `csv:"local" time_format:"excel" time_location:"Asia/Tokyo"`
+}
+
+func TestExcelTimeFormat(t *testing.T) {
+	reader := bytes.Buffer{}
+	reader.WriteString("time,time_1904,local\n")
+	reader.WriteString("44927.5,43465.25,59\n")
+	csvReader := typedcsv.NewReader[ExcelTimeTestRecord](csv.NewReader(&reader))
+	err := csvReader.ReadHeader()
+	if err != nil {
+		t.Fatal(err)
+	}
+	record, err := csvReader.ReadRecord()
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+	if !record.Time.Equal(expected) {
+		t.Fatalf("Expected %v, got %v", expected, record.Time)
+	}
+	expected = time.Date(2023, 1, 1, 6, 0, 0, 0, time.UTC)
+	if !record.Time1904.Equal(expected) {
+		t.Fatalf("Expected %v, got %v", expected, record.Time1904)
+	}
+	expected = time.Date(1900, 2, 28, 0, 0, 0, 0, time.FixedZone("Asia/Tokyo", 9*60*60))
+	if !time.Time(record.Local).Equal(expected) {
+		t.Fatalf("Expected %v, got %v", expected, time.Time(record.Local))
+	}
+
+	writer := bytes.Buffer{}
+	csvWriter := typedcsv.NewWriter[ExcelTimeTestRecord](csv.NewWriter(&writer))
+	err = csvWriter.WriteRecord(*record)
+	if err != nil {
+		t.Fatal(err)
+	}
+	csvWriter.Flush()
+	if writer.String() != "44927.5,43465.25,59\n" {
+		t.Fatalf("Expected %q, got %q", "44927.5,43465.25,59\n", writer.String())
+	}
+
+	reader.Reset()
+	reader.WriteString("time\nabc\n")
+	csvReader = typedcsv.NewReader[ExcelTimeTestRecord](csv.NewReader(&reader))
+	err = csvReader.ReadHeader()
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = csvReader.ReadRecord()
+	expectedError := `typedcsv: error parsing field 'time' in record 1 on line 2: invalid Excel serial date "abc"`
+	if err == nil || err.Error() != expectedError {
+		t.Fatalf("Expected %v, got %v", expectedError, err)
+	}
+
+	for _, row := range []string{"time\n-1\n", "time_1904\n-0.5\n"} {
+		csvReader = typedcsv.NewReader[ExcelTimeTestRecord](csv.NewReader(bytes.NewBufferString(row)))
+		err = csvReader.ReadHeader()
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = csvReader.ReadRecord()
+		if err == nil || !strings.Contains(err.Error(), "invalid Excel serial date") {
+			t.Fatalf("Expected an invalid Excel serial date error for %q, got %v", row, err)
+		}
+	}
+}
+
+type DayCountTimeTestRecord struct {
+	EpochDays time.Time `csv:"epoch_days" time_format:"epoch_days"`
+	Julian    time.Time `csv:"julian" time_format:"julian_day" time_location:"Asia/Tokyo"`
+	MJD       time.Time `csv:"mjd" time_format:"mjd"`
+}
+
+func TestDayCountTimeFormat(t *testing.T) {
+	reader := bytes.Buffer{}
+	reader.WriteString("epoch_days,julian,mjd\n")
+	reader.WriteString("19358.25,2459946,59945.5\n")
+	csvReader := typedcsv.NewReader[DayCountTimeTestRecord](csv.NewReader(&reader))
+	err := csvReader.ReadHeader()
+	if err != nil {
+		t.Fatal(err)
+	}
+	record, err := csvReader.ReadRecord()
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := time.Date(2023, 1, 1, 6, 0, 0, 0, time.UTC)
+	if !record.EpochDays.Equal(expected) {
+		t.Fatalf("Expected %v, got %v", expected, record.EpochDays)
+	}
+	expected = time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+	if !record.Julian.Equal(expected) {
+		t.Fatalf("Expected %v, got %v", expected, record.Julian)
+	}
+	if record.Julian.Location().String() != "Asia/Tokyo" {
+		t.Fatalf("Expected %q, got %q", "Asia/Tokyo", record.Julian.Location().String())
+	}
+	if !record.MJD.Equal(expected) {
+		t.Fatalf("Expected %v, got %v", expected, record.MJD)
+	}
+
+	writer := bytes.Buffer{}
+	csvWriter := typedcsv.NewWriter[DayCountTimeTestRecord](csv.NewWriter(&writer))
+	err = csvWriter.WriteRecord(*record)
+	if err != nil {
+		t.Fatal(err)
+	}
+	csvWriter.Flush()
+	if writer.String() != "19358.25,2459946,59945.5\n" {
+		t.Fatalf("Expected %q, got %q", "19358.25,2459946,59945.5\n", writer.String())
+	}
+
+	reader.Reset()
+	reader.WriteString("julian\nabc\n")
+	csvReader = typedcsv.NewReader[DayCountTimeTestRecord](csv.NewReader(&reader))
+	err = csvReader.ReadHeader()
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = csvReader.ReadRecord()
+	expectedError := `typedcsv: error parsing field 'julian' in record 1 on line 2: invalid Julian day "abc"`
+	if err == nil || err.Error() != expectedError {
+		t.Fatalf("Expected %q, got %v", expectedError, err)
+	}
+}
+
+type ISOWeekTimeTestRecord struct {
+	Date time.Time `csv:"date" time_format:"iso_week"`
+}
+
+func TestISOWeekTimeFormat(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected time.Time
+		written  string
+	}{
+		{"2024-W05-3", time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC), "2024-W05-3"},
+		{"2020-W01-1", time.Date(2019, 12, 30, 0, 0, 0, 0, time.UTC), "2020-W01-1"},
+		{"2020-W53-7", time.Date(2021, 1, 3, 0, 0, 0, 0, time.UTC), "2020-W53-7"},
+		{"2024-W10", time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC), "2024-W10-1"},
+	}
+	for _, test := range tests {
+		reader := bytes.Buffer{}
+		reader.WriteString("date\n" + test.value + "\n")
+		csvReader := typedcsv.NewReader[ISOWeekTimeTestRecord](csv.NewReader(&reader))
+		err := csvReader.ReadHeader()
+		if err != nil {
+			t.Fatal(err)
+		}
+		record, err := csvReader.ReadRecord()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !record.Date.Equal(test.expected) {
+			t.Fatalf("Expected %v, got %v", test.expected, record.Date)
+		}
+		writer := bytes.Buffer{}
+		csvWriter := typedcsv.NewWriter[ISOWeekTimeTestRecord](csv.NewWriter(&writer))
+		err = csvWriter.WriteRecord(*record)
+		if err != nil {
+			t.Fatal(err)
+		}
+		csvWriter.Flush()
+		if writer.String() != test.written+"\n" {
+			t.Fatalf("Expected %q, got %q", test.written+"\n", writer.String())
+		}
+	}
+
+	for _, value := range []string{"2024-05-3", "2024-W5-3", "2024-W05-8", "2024-W53-1", "abc"} {
+		reader := bytes.Buffer{}
+		reader.WriteString("date\n" + value + "\n")
+		csvReader := typedcsv.NewReader[ISOWeekTimeTestRecord](csv.NewReader(&reader))
+		err := csvReader.ReadHeader()
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = csvReader.ReadRecord()
+		if err == nil {
+			t.Fatalf("Expected error for %q, got nil", value)
+		}
+	}
+}
+
+type TimeAdjustTestRecord struct {
+	Truncated time.Time  `csv:"truncated" time_truncate:"1s"`
+	Rounded   CustomTime `csv:"rounded" time_format:"15:04:05" time_round:"1m"`
+	Both      *time.Time `csv:"both" time_format:"15:04" time_truncate:"1m" time_round:"1h"`
+}
+
+type TimeAdjustWithWrongDurationTestRecord struct {
+	Time time.Time `csv:"time" time_round:"abc"`
+}
+
+func TestTimeTruncateAndRound(t *testing.T) {
+	both := time.Date(2024, 1, 2, 3, 29, 59, 0, time.UTC)
+	writer := bytes.Buffer{}
+	csvWriter := typedcsv.NewWriter[TimeAdjustTestRecord](csv.NewWriter(&writer))
+	err := csvWriter.WriteRecord(TimeAdjustTestRecord{
+		Truncated: time.Date(2024, 1, 2, 3, 4, 5, 999999999, time.UTC),
+		Rounded:   CustomTime(time.Date(2024, 1, 2, 3, 4, 30, 0, time.UTC)),
+		Both:      &both,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	csvWriter.Flush()
+	expected := "2024-01-02T03:04:05Z,03:05:00,03:00\n"
+	if writer.String() != expected {
+		t.Fatalf("Expected %q, got %q", expected, writer.String())
+	}
+
+	csvWriter2 := typedcsv.NewWriter[TimeAdjustWithWrongDurationTestRecord](csv.NewWriter(&writer))
+	err = csvWriter2.WriteRecord(TimeAdjustWithWrongDurationTestRecord{})
+	expectedError := `typedcsv: error formatting field 'time' in record 1: invalid time_round "abc"`
+	if err == nil || err.Error() != expectedError {
+		t.Fatalf("Expected %q, got %v", expectedError, err)
+	}
+}
@@ -0,0 +1,39 @@
+package typedcsv
+
+import (
+	"reflect"
+	"time"
+)
+
+// WithTimeZone makes TypedCSVReader convert all the parsed time values to the given location, such as time.UTC,
+// whatever the offsets in the CSV values, so that records read from files with different offsets can be compared.
+// The "time_zone" tag value sets the location of one field instead.
+func WithTimeZone(location *time.Location) Option {
+	return func(o *options) {
+		o.timeZone = location
+	}
+}
+
+// normalizeTime converts the parsed time value of the field to the location of its "time_zone" tag value,
+// or to the location set by WithTimeZone.
+func (f *field) normalizeTime(state *decodeState, fieldValue reflect.Value) error {
+	location := state.options.timeZone
+	if f.hasTimeZone {
+		if f.timeZoneErr != nil {
+			return FieldParseError{Field: f.name, NestedError: f.timeZoneErr}
+		}
+		location = f.timeZone
+	}
+	if location == nil {
+		return nil
+	}
+	if f.pointer {
+		if fieldValue.IsNil() {
+			return nil
+		}
+		fieldValue = fieldValue.Elem()
+	}
+	timeValue := fieldValue.Convert(timeType).Interface().(time.Time)
+	fieldValue.Set(reflect.ValueOf(timeValue.In(location)).Convert(f.typ))
+	return nil
+}
@@ -0,0 +1,58 @@
+package typedcsv_test
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/hoshiumiarata/typedcsv"
+)
+
+type TimeZoneTestRecord struct {
+	Time   time.Time  `csv:"time"`
+	Tokyo  *time.Time `csv:"tokyo" time_format:"2006-01-02 15:04 -0700" time_zone:"Asia/Tokyo" null:""`
+	Custom CustomTime `csv:"custom" time_format:"2006-01-02 15:04 -0700"`
+}
+
+func TestTimeZone(t *testing.T) {
+	input := "time,tokyo,custom\n2024-01-02T03:04:05+02:00,2024-01-02 00:00 +0000,2024-01-02 10:00 -0500\n2024-01-02T01:04:05Z,,2024-01-02 15:00 +0000\n"
+	csvReader := typedcsv.NewReader[TimeZoneTestRecord](csv.NewReader(bytes.NewBufferString(input)), typedcsv.WithTimeZone(time.UTC))
+	err := csvReader.ReadHeader()
+	if err != nil {
+		t.Fatal(err)
+	}
+	records, err := csvReader.ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if records[0].Time != records[1].Time || records[0].Time.Location() != time.UTC {
+		t.Fatalf("Expected equal UTC times, got %v and %v", records[0].Time, records[1].Time)
+	}
+	if records[0].Tokyo.Location().String() != "Asia/Tokyo" || records[0].Tokyo.Hour() != 9 || records[1].Tokyo != nil {
+		t.Fatalf("Expected 09:00 in Asia/Tokyo, got %v", records[0].Tokyo)
+	}
+	if records[0].Custom != records[1].Custom {
+		t.Fatalf("Expected equal times, got %v and %v", records[0].Custom, records[1].Custom)
+	}
+}
+
+type InvalidTimeZoneTestRecord struct {
+	Time time.Time `csv:"time" time_zone:"Nowhere/Else"`
+	Name string    `csv:"name" time_zone:"UTC"`
+}
+
+func TestTimeZoneInvalid(t *testing.T) {
+	codec := typedcsv.NewCodec[InvalidTimeZoneTestRecord]()
+	if joined := codec.Err().(interface{ Unwrap() []error }).Unwrap(); len(joined) != 2 {
+		t.Fatalf("Expected 2 errors, got %v", codec.Err())
+	}
+	csvReader := codec.NewReader(csv.NewReader(bytes.NewBufferString("time\n2024-01-02T03:04:05Z\n")))
+	_ = csvReader.ReadHeader()
+	_, err := csvReader.ReadRecord()
+	var fieldParseError typedcsv.FieldParseError
+	if !errors.As(err, &fieldParseError) {
+		t.Fatalf("Expected a FieldParseError, got %v", err)
+	}
+}
@@ -0,0 +1,123 @@
+package typedcsv
+
+import (
+	"bufio"
+	"io"
+	"strings"
+)
+
+// A TokenWriter writes CSV records. It is an alternative to csv.Writer, used by NewFastWriter,
+// that writes the dialects read by Tokenizer: the quote character can be changed, and an escape character
+// can be set to escape quotes, line breaks and itself instead of doubling quotes.
+type TokenWriter struct {
+	// Comma is the field delimiter. It is set to ',' by NewTokenWriter.
+	Comma rune
+	// Quote is the quote character. It must be an ASCII character, or Write returns ErrNonASCIIQuote.
+	// It is set to '"' by NewTokenWriter.
+	Quote rune
+	// Escape is the escape character, or 0 to double quotes. It must be an ASCII character, or Write returns ErrNonASCIIQuote.
+	// A field equal to the escape character followed by N is written as is, so that \N can be used as the null value.
+	Escape rune
+	// UseCRLF makes the TokenWriter end lines with \r\n instead of \n.
+	UseCRLF bool
+	// Quoting chooses the fields enclosed in quotes besides those that need it. It is QuoteMinimal by default.
+	Quoting QuotePolicy
+
+	writer *bufio.Writer
+	err    error
+}
+
+// NewTokenWriter returns a new TokenWriter that writes to w.
+func NewTokenWriter(w io.Writer) *TokenWriter {
+	return &TokenWriter{
+		Comma:  ',',
+		Quote:  '"',
+		writer: bufio.NewWriter(w),
+	}
+}
+
+// Write writes a single CSV record. Writes are buffered, so Flush must be called to write to the underlying io.Writer.
+func (w *TokenWriter) Write(record []string) error {
+	return w.write(record, nil)
+}
+
+// write writes a single CSV record. The fields for which raw is true are written as is.
+func (w *TokenWriter) write(record []string, raw []bool) error {
+	if w.err != nil {
+		return w.err
+	}
+	if err := checkQuote(w.Quote, w.Escape); err != nil {
+		return err
+	}
+	for i, field := range record {
+		if i > 0 {
+			w.writer.WriteRune(w.Comma)
+		}
+		if i < len(raw) && raw[i] {
+			w.writer.WriteString(field)
+			continue
+		}
+		w.writeField(field)
+	}
+	if w.UseCRLF {
+		w.writer.WriteByte('\r')
+	}
+	w.err = w.writer.WriteByte('\n')
+	return w.err
+}
+
+func (w *TokenWriter) writeField(field string) {
+	quote, escape, hasEscape := byte(w.Quote), byte(w.Escape), w.Escape != 0
+	if hasEscape && field == string([]byte{escape, 'N'}) {
+		w.writer.WriteString(field)
+		return
+	}
+	quoted := field != "" && (strings.ContainsRune(field, w.Comma) || strings.ContainsAny(field, string([]byte{quote, '\r', '\n'})) ||
+		field[0] == ' ' || field[0] == '\t') || w.Quoting.mustQuote(field)
+	if !quoted && !(hasEscape && strings.IndexByte(field, escape) >= 0) {
+		w.writer.WriteString(field)
+		return
+	}
+	if quoted {
+		w.writer.WriteByte(quote)
+	}
+	for i := 0; i < len(field); i++ {
+		c := field[i]
+		switch {
+		case hasEscape && (c == quote || c == escape):
+			w.writer.WriteByte(escape)
+			w.writer.WriteByte(c)
+		case hasEscape && c == '\n':
+			w.writer.WriteByte(escape)
+			w.writer.WriteByte('n')
+		case hasEscape && c == '\r':
+			w.writer.WriteByte(escape)
+			w.writer.WriteByte('r')
+		case hasEscape && c == 0:
+			w.writer.WriteByte(escape)
+			w.writer.WriteByte('0')
+		case c == quote:
+			w.writer.WriteByte(quote)
+			w.writer.WriteByte(quote)
+		case c == '\n' && w.UseCRLF:
+			w.writer.WriteString("\r\n")
+		default:
+			w.writer.WriteByte(c)
+		}
+	}
+	if quoted {
+		w.writer.WriteByte(quote)
+	}
+}
+
+// Flush writes any buffered data to the underlying io.Writer. To check if an error occurred, call Error.
+func (w *TokenWriter) Flush() {
+	if w.err == nil {
+		w.err = w.writer.Flush()
+	}
+}
+
+// Error reports any error that has occurred during a previous Write or Flush.
+func (w *TokenWriter) Error() error {
+	return w.err
+}
@@ -0,0 +1,120 @@
+package typedcsv_test
+
+import (
+	"bytes"
+	"encoding/csv"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hoshiumiarata/typedcsv"
+)
+
+func TestTokenWriter(t *testing.T) {
+	records := [][]string{
+		{"a", "", "b,c", "d\"e", " f", "g\nh"},
+		{"plain", "x"},
+	}
+	writer := bytes.Buffer{}
+	tokenWriter := typedcsv.NewTokenWriter(&writer)
+	for _, record := range records {
+		err := tokenWriter.Write(record)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	tokenWriter.Flush()
+	if tokenWriter.Error() != nil {
+		t.Fatal(tokenWriter.Error())
+	}
+	expected := bytes.Buffer{}
+	csvWriter := csv.NewWriter(&expected)
+	err := csvWriter.WriteAll(records)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if writer.String() != expected.String() {
+		t.Fatalf("Expected %q, got %q", expected.String(), writer.String())
+	}
+}
+
+func TestTokenWriterDialect(t *testing.T) {
+	records := [][]string{{"it's", "a,b", `\N`, "line\nbreak", `C:\dir`, "x\ty"}}
+	writer := bytes.Buffer{}
+	tokenWriter := typedcsv.NewTokenWriter(&writer)
+	tokenWriter.Quote = '\''
+	tokenWriter.Escape = '\\'
+	tokenWriter.UseCRLF = true
+	err := tokenWriter.Write(records[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	tokenWriter.Flush()
+	expected := "'it\\'s','a,b',\\N,'line\\nbreak',C:\\\\dir,x\ty\r\n"
+	if writer.String() != expected {
+		t.Fatalf("Expected %q, got %q", expected, writer.String())
+	}
+
+	tokenizer := typedcsv.NewTokenizer(strings.NewReader(writer.String()))
+	tokenizer.Quote = '\''
+	tokenizer.Escape = '\\'
+	record, err := tokenizer.Read()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(record, records[0]) {
+		t.Fatalf("Expected %q, got %q", records[0], record)
+	}
+}
+
+type DialectTestRecord struct {
+	Name     string  `csv:"name"`
+	Optional *string `csv:"optional" null:"\\N"`
+}
+
+func TestNewFastWriter(t *testing.T) {
+	writer := bytes.Buffer{}
+	csvWriter := typedcsv.NewFastWriter[DialectTestRecord](&writer)
+	csvWriter.TokenWriter().Escape = '\\'
+	err := csvWriter.WriteHeader()
+	if err != nil {
+		t.Fatal(err)
+	}
+	value := `"quoted"`
+	for _, record := range []DialectTestRecord{{Name: "a,b"}, {Name: "c", Optional: &value}} {
+		err = csvWriter.WriteRecord(record)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	csvWriter.Flush()
+	if csvWriter.Error() != nil {
+		t.Fatal(csvWriter.Error())
+	}
+	expected := "name,optional\n\"a,b\",\\N\nc,\"\\\"quoted\\\"\"\n"
+	if writer.String() != expected {
+		t.Fatalf("Expected %q, got %q", expected, writer.String())
+	}
+
+	csvReader := typedcsv.NewFastReader[DialectTestRecord](strings.NewReader(writer.String()))
+	csvReader.Tokenizer().Escape = '\\'
+	err = csvReader.ReadHeader()
+	if err != nil {
+		t.Fatal(err)
+	}
+	records, err := csvReader.ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 2 || records[0].Name != "a,b" || records[0].Optional != nil || *records[1].Optional != value {
+		t.Fatalf("Expected the written records, got %v", records)
+	}
+}
+
+func TestTokenWriterNonASCIIQuote(t *testing.T) {
+	writer := typedcsv.NewTokenWriter(&bytes.Buffer{})
+	writer.Quote = '«'
+	if err := writer.Write([]string{"a b", "c"}); err != typedcsv.ErrNonASCIIQuote {
+		t.Fatalf("Expected %v, got %v", typedcsv.ErrNonASCIIQuote, err)
+	}
+}
@@ -0,0 +1,226 @@
+package typedcsv
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"io"
+	"strings"
+	"unicode/utf8"
+)
+
+// A Tokenizer splits CSV input into records. It is an alternative to csv.Reader, used by NewFastReader.
+//
+// It follows RFC 4180 like csv.Reader with its default settings, but is optimized for the common case of
+// lines without quotes: such a line is converted to a string once and split with the vectorized
+// byte search of the standard library. Lines with quotes fall back to a slower parser.
+// Like csv.Reader, it skips empty lines and normalizes \r\n to \n in quoted fields.
+// It does not check the number of fields per record.
+//
+// Unlike csv.Reader, the quote character can be changed, and an escape character can be set for dialects
+// such as MySQL dumps, where a backslash escapes the next character instead of doubling quotes.
+type Tokenizer struct {
+	// Comma is the field delimiter. It is set to ',' by NewTokenizer.
+	Comma rune
+	// Quote is the quote character. It must be an ASCII character, or Read returns ErrNonASCIIQuote.
+	// It is set to '"' by NewTokenizer.
+	Quote rune
+	// Escape is the escape character, or 0 if there is none. It must be an ASCII character, or Read returns ErrNonASCIIQuote.
+	// The escape character followed by n, r, t or 0 is a newline, a carriage return, a tab or a NUL character,
+	// and followed by N, it is kept as is, so that \N can be used as the null value.
+	// Followed by any other character, it is that character.
+	// The quote state is not kept, so a quoted \N is read as \N too, and cannot be told apart from the null value.
+	Escape rune
+
+	reader    *bufio.Reader
+	buffer    []byte
+	offset    int64
+	line      int
+	startLine int
+	// needed marks the columns split by Read, set by TypedCSVReader for WithColumns, or nil for all the columns.
+	needed []bool
+}
+
+// ErrNonASCIIQuote is returned by Tokenizer.Read and TokenWriter.Write when the quote or escape character is not ASCII.
+var ErrNonASCIIQuote = errors.New("typedcsv: quote and escape characters must be ASCII")
+
+// checkQuote returns ErrNonASCIIQuote if the quote or escape character is not ASCII.
+func checkQuote(quote, escape rune) error {
+	if quote < 0 || quote >= utf8.RuneSelf || escape < 0 || escape >= utf8.RuneSelf {
+		return ErrNonASCIIQuote
+	}
+	return nil
+}
+
+// NewTokenizer returns a new Tokenizer that reads from r.
+func NewTokenizer(r io.Reader) *Tokenizer {
+	return &Tokenizer{
+		Comma:  ',',
+		Quote:  '"',
+		reader: bufio.NewReader(r),
+	}
+}
+
+// Read reads one record from the input. It returns io.EOF at the end of the input,
+// and a *csv.ParseError if the record has a bare or unterminated quote.
+func (t *Tokenizer) Read() ([]string, error) {
+	if err := checkQuote(t.Quote, t.Escape); err != nil {
+		return nil, err
+	}
+	comma := string(t.Comma)
+	if t.Comma < utf8.RuneSelf {
+		comma = string([]byte{byte(t.Comma)})
+	}
+	for {
+		line, err := t.readLine()
+		if len(line) == 0 && err != nil {
+			return nil, err
+		}
+		line = trimLineEnding(line)
+		if len(line) == 0 {
+			continue
+		}
+		t.startLine = t.line
+		if bytes.IndexByte(line, byte(t.Quote)) < 0 && (t.Escape == 0 || bytes.IndexByte(line, byte(t.Escape)) < 0) {
+			if t.needed != nil {
+				return t.splitNeeded(line, []byte(comma)), nil
+			}
+			return strings.Split(string(line), comma), nil
+		}
+		return t.parse(line, []byte(comma))
+	}
+}
+
+// InputOffset returns the input stream byte offset of the end of the last record read.
+func (t *Tokenizer) InputOffset() int64 {
+	return t.offset
+}
+
+// readLine reads a line including its line ending. The line is only valid until the next call.
+func (t *Tokenizer) readLine() ([]byte, error) {
+	line, err := t.reader.ReadSlice('\n')
+	if err == bufio.ErrBufferFull {
+		t.buffer = append(t.buffer[:0], line...)
+		for err == bufio.ErrBufferFull {
+			line, err = t.reader.ReadSlice('\n')
+			t.buffer = append(t.buffer, line...)
+		}
+		line = t.buffer
+	}
+	if len(line) > 0 {
+		t.line++
+		if err == io.EOF {
+			err = nil
+		}
+	}
+	first := t.offset == 0
+	t.offset += int64(len(line))
+	if first {
+		// A byte order mark is skipped before tokenizing, so that the first column can be quoted.
+		line = bytes.TrimPrefix(line, []byte(bom))
+	}
+	return line, err
+}
+
+func trimLineEnding(line []byte) []byte {
+	if n := len(line); n > 0 && line[n-1] == '\n' {
+		line = line[:n-1]
+		if n := len(line); n > 0 && line[n-1] == '\r' {
+			line = line[:n-1]
+		}
+	}
+	return line
+}
+
+// parse parses a record with quotes or escapes, reading the following lines while a quoted field is open.
+func (t *Tokenizer) parse(line []byte, comma []byte) ([]string, error) {
+	quote, escape, hasEscape := byte(t.Quote), byte(t.Escape), t.Escape != 0
+	startLine := t.line
+	var fields []string
+	var field []byte
+	i := 0
+	for {
+		field = field[:0]
+		if i < len(line) && line[i] == quote {
+			// Quoted field
+			i++
+		quoted:
+			for {
+				if i == len(line) {
+					field = append(field, '\n')
+					next, err := t.readLine()
+					if len(next) == 0 && err != nil {
+						return nil, &csv.ParseError{StartLine: startLine, Line: t.line, Column: len(line) + 1, Err: csv.ErrQuote}
+					}
+					line, i = trimLineEnding(next), 0
+					continue
+				}
+				c := line[i]
+				switch {
+				case hasEscape && c == escape:
+					// An escape at the end of the line escapes the line break.
+					if i+1 < len(line) {
+						field = appendUnescaped(field, escape, line[i+1])
+						i++
+					}
+					i++
+				case c == quote && i+1 < len(line) && line[i+1] == quote:
+					field = append(field, quote)
+					i += 2
+				case c == quote:
+					i++
+					break quoted
+				default:
+					field = append(field, c)
+					i++
+				}
+			}
+			fields = append(fields, string(field))
+			if i == len(line) {
+				return fields, nil
+			}
+			if !bytes.HasPrefix(line[i:], comma) {
+				return nil, &csv.ParseError{StartLine: startLine, Line: t.line, Column: i + 1, Err: csv.ErrQuote}
+			}
+			i += len(comma)
+			continue
+		}
+		// Unquoted field
+		for i < len(line) && !bytes.HasPrefix(line[i:], comma) {
+			c := line[i]
+			switch {
+			case hasEscape && c == escape && i+1 < len(line):
+				field = appendUnescaped(field, escape, line[i+1])
+				i += 2
+			case c == quote:
+				return nil, &csv.ParseError{StartLine: startLine, Line: t.line, Column: i + 1, Err: csv.ErrBareQuote}
+			default:
+				field = append(field, c)
+				i++
+			}
+		}
+		fields = append(fields, string(field))
+		if i == len(line) {
+			return fields, nil
+		}
+		i += len(comma)
+	}
+}
+
+// appendUnescaped appends the character c escaped by the escape character.
+func appendUnescaped(field []byte, escape, c byte) []byte {
+	switch c {
+	case 'n':
+		return append(field, '\n')
+	case 'r':
+		return append(field, '\r')
+	case 't':
+		return append(field, '\t')
+	case '0':
+		return append(field, 0)
+	case 'N':
+		return append(field, escape, 'N')
+	}
+	return append(field, c)
+}
@@ -0,0 +1,169 @@
+package typedcsv_test
+
+import (
+	"encoding/csv"
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hoshiumiarata/typedcsv"
+)
+
+func TestTokenizer(t *testing.T) {
+	inputs := []string{
+		"a,b,c\n1,2,3\n",
+		"a,b,c\r\n1,2,3",
+		"a,,\n\n,b,\n",
+		"\"a,b\",\"c\"\"d\"\n\"\",x\n",
+		"\"multi\r\nline\",\"more\nlines\n\",end\nnext,row\n",
+		"a,\"b\"\n\"c\",d\n",
+	}
+	for _, input := range inputs {
+		reader := csv.NewReader(strings.NewReader(input))
+		reader.FieldsPerRecord = -1
+		expected, err := reader.ReadAll()
+		if err != nil {
+			t.Fatal(err)
+		}
+		tokenizer := typedcsv.NewTokenizer(strings.NewReader(input))
+		var records [][]string
+		for {
+			record, err := tokenizer.Read()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				t.Fatalf("%q: %v", input, err)
+			}
+			records = append(records, record)
+		}
+		if !reflect.DeepEqual(records, expected) {
+			t.Fatalf("%q: Expected %q, got %q", input, expected, records)
+		}
+		if tokenizer.InputOffset() != int64(len(input)) {
+			t.Fatalf("%q: Expected %d, got %d", input, len(input), tokenizer.InputOffset())
+		}
+	}
+
+	tokenizer := typedcsv.NewTokenizer(strings.NewReader("a;b|c;\"d;e\""))
+	tokenizer.Comma = ';'
+	record, err := tokenizer.Read()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(record, []string{"a", "b|c", "d;e"}) {
+		t.Fatalf("Expected %q, got %q", []string{"a", "b|c", "d;e"}, record)
+	}
+}
+
+func TestTokenizerErrors(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected error
+	}{
+		{"a,b\"c\n", csv.ErrBareQuote},
+		{"a,\"b\"c\n", csv.ErrQuote},
+		{"a,\"b\nc\n", csv.ErrQuote},
+	}
+	for _, test := range tests {
+		_, err := typedcsv.NewTokenizer(strings.NewReader(test.input)).Read()
+		var parseError *csv.ParseError
+		if !errors.As(err, &parseError) || !errors.Is(err, test.expected) {
+			t.Fatalf("%q: Expected %v, got %v", test.input, test.expected, err)
+		}
+	}
+
+	tokenizer := typedcsv.NewTokenizer(strings.NewReader("«a»,b\n"))
+	tokenizer.Quote = '«'
+	if _, err := tokenizer.Read(); err != typedcsv.ErrNonASCIIQuote {
+		t.Fatalf("Expected %v, got %v", typedcsv.ErrNonASCIIQuote, err)
+	}
+	tokenizer = typedcsv.NewTokenizer(strings.NewReader("a,b\n"))
+	tokenizer.Escape = '¥'
+	if _, err := tokenizer.Read(); err != typedcsv.ErrNonASCIIQuote {
+		t.Fatalf("Expected %v, got %v", typedcsv.ErrNonASCIIQuote, err)
+	}
+}
+
+func TestNewFastReader(t *testing.T) {
+	source := strings.NewReader("person_status;other\nactive;1\n\"inactive\";\"2;3\"\n")
+	typedReader := typedcsv.NewFastReader[MarshalTextTestRecord](source)
+	typedReader.Tokenizer().Comma = ';'
+	err := typedReader.ReadHeader()
+	if err != nil {
+		t.Fatal(err)
+	}
+	first, err := typedReader.ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(first) != 2 || first[1].PersonStatus != PersonStatusInactive {
+		t.Fatalf("Expected 2 records, got %v", first)
+	}
+	err = typedReader.Rewind(source)
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := typedReader.ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(first, second) {
+		t.Fatalf("Expected %v, got %v", first, second)
+	}
+	if typedReader.Reader != nil {
+		t.Fatalf("Expected nil, got %v", typedReader.Reader)
+	}
+}
+
+type TokenizerDialectTestRecord struct {
+	Name string `csv:"name"`
+	Note string `csv:"note"`
+}
+
+func TestNewFastReaderRewindDialect(t *testing.T) {
+	source := strings.NewReader("name,note\n'Smith, John',it\\'s\n")
+	typedReader := typedcsv.NewFastReader[TokenizerDialectTestRecord](source)
+	typedReader.Tokenizer().Quote = '\''
+	typedReader.Tokenizer().Escape = '\\'
+	for i := 0; i < 2; i++ {
+		if i > 0 {
+			if err := typedReader.Rewind(source); err != nil {
+				t.Fatal(err)
+			}
+		} else if err := typedReader.ReadHeader(); err != nil {
+			t.Fatal(err)
+		}
+		records, err := typedReader.ReadAll()
+		if err != nil {
+			t.Fatal(err)
+		}
+		expected := TokenizerDialectTestRecord{Name: "Smith, John", Note: "it's"}
+		if len(records) != 1 || *records[0] != expected {
+			t.Fatalf("Expected %v, got %v", expected, records)
+		}
+	}
+}
+
+func TestTokenizerDialect(t *testing.T) {
+	tokenizer := typedcsv.NewTokenizer(strings.NewReader("'it\\'s',a\\,b,\\N,'line\\nbreak',\\\\\n'multi\\\nline',''''\n"))
+	tokenizer.Quote = '\''
+	tokenizer.Escape = '\\'
+	var records [][]string
+	for {
+		record, err := tokenizer.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		records = append(records, record)
+	}
+	expected := [][]string{{"it's", "a,b", `\N`, "line\nbreak", `\`}, {"multi\nline", "'"}}
+	if !reflect.DeepEqual(records, expected) {
+		t.Fatalf("Expected %q, got %q", expected, records)
+	}
+}
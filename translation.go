@@ -0,0 +1,68 @@
+package typedcsv
+
+// WithTranslation sets a table translating the values of the given column before they are parsed,
+// such as map[string]string{"UK": "GB", "England": "GB"} for a country column, so that value normalizations
+// live in configuration rather than in UnmarshalText methods. Values not in the table are left as is.
+// The column is matched like the header columns, with the name mapper set by WithNameMapper.
+//
+// If the table is one-to-one, TypedCSVWriter applies the inverse table to the formatted values.
+// Otherwise, as with the table above, the values cannot be inverted and are written as formatted, such as "GB".
+// Setting a table for the same column again replaces it.
+func WithTranslation(column string, table map[string]string) Option {
+	return func(o *options) {
+		if o.translations == nil {
+			o.translations = make(map[string]*translation)
+		}
+		o.translations[column] = newTranslation(table)
+	}
+}
+
+// A translation is a table set by WithTranslation and its inverse, which is nil if the table is not one-to-one.
+type translation struct {
+	table   map[string]string
+	inverse map[string]string
+}
+
+func newTranslation(table map[string]string) *translation {
+	t := &translation{
+		table:   make(map[string]string, len(table)),
+		inverse: make(map[string]string, len(table)),
+	}
+	for from, to := range table {
+		t.table[from] = to
+		if _, ok := t.inverse[to]; ok {
+			t.inverse = nil
+		}
+		if t.inverse != nil {
+			t.inverse[to] = from
+		}
+	}
+	return t
+}
+
+// translationOf returns the translation of the column with the canonical name key set by WithTranslation, or nil.
+func (o *options) translationOf(key string) *translation {
+	for column, translation := range o.translations {
+		if o.nameMapper.Canonical(column) == key {
+			return translation
+		}
+	}
+	return nil
+}
+
+// translate returns the translation of the CSV value, or the value if it is not in the table.
+func (t *translation) translate(value string) string {
+	if translated, ok := t.table[value]; ok {
+		return translated
+	}
+	return value
+}
+
+// untranslate returns the CSV value translated to the formatted value by the inverse table,
+// or the value if there is none.
+func (t *translation) untranslate(value string) string {
+	if untranslated, ok := t.inverse[value]; ok {
+		return untranslated
+	}
+	return value
+}
@@ -0,0 +1,118 @@
+package typedcsv_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hoshiumiarata/typedcsv"
+)
+
+type TranslationTestRecord struct {
+	Name    string  `csv:"name"`
+	Country string  `csv:"country" enum:"GB|FR"`
+	Active  *bool   `csv:"active" null:""`
+	Score   float64 `csv:"score" default:"1.5"`
+}
+
+func TestWithTranslation(t *testing.T) {
+	opts := []typedcsv.Option{
+		typedcsv.WithTranslation("country", map[string]string{"UK": "GB", "England": "GB", "GB": "GB", "France": "FR"}),
+		typedcsv.WithTranslation("active", map[string]string{"yes": "true", "no": "false", "n/a": ""}),
+		typedcsv.WithTranslation("score", map[string]string{"-": ""}),
+	}
+	input := "name,country,active,score\n" +
+		"alice,UK,yes,2\n" +
+		"bob,England,n/a,-\n" +
+		"carol,France,no,3\n" +
+		"dave,FR,true,4\n"
+	reader := typedcsv.NewReaderFrom[TranslationTestRecord](strings.NewReader(input), opts...)
+	if err := reader.ReadHeader(); err != nil {
+		t.Fatal(err)
+	}
+	records, err := reader.ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []struct {
+		country string
+		active  string
+		score   float64
+	}{
+		{"GB", "true", 2},
+		{"GB", "nil", 1.5},
+		{"FR", "false", 3},
+		{"FR", "true", 4},
+	}
+	for i, record := range records {
+		active := "nil"
+		if record.Active != nil {
+			active = map[bool]string{true: "true", false: "false"}[*record.Active]
+		}
+		if record.Country != expected[i].country || active != expected[i].active || record.Score != expected[i].score {
+			t.Fatalf("Expected %v, got %+v", expected[i], *record)
+		}
+	}
+
+	var buffer strings.Builder
+	writer := typedcsv.NewWriterTo[TranslationTestRecord](&buffer, opts...)
+	if err := writer.WriteHeader(); err != nil {
+		t.Fatal(err)
+	}
+	for _, record := range records {
+		if err := writer.WriteRecord(*record); err != nil {
+			t.Fatal(err)
+		}
+	}
+	writer.Flush()
+	expectedOutput := "name,country,active,score\n" +
+		"alice,GB,yes,2\n" +
+		"bob,GB,n/a,1.5\n" +
+		"carol,FR,no,3\n" +
+		"dave,FR,yes,4\n"
+	if buffer.String() != expectedOutput {
+		t.Fatalf("Expected %q, got %q", expectedOutput, buffer.String())
+	}
+}
+
+func TestWithTranslationInverse(t *testing.T) {
+	tests := []struct {
+		table    map[string]string
+		expected string
+	}{
+		{map[string]string{"UK": "GB", "England": "GB"}, "country\nGB\n"},
+		{map[string]string{"UK": "GB", "La France": "FR"}, "country\nUK\n"},
+	}
+	for _, test := range tests {
+		var buffer strings.Builder
+		writer := typedcsv.NewWriterTo[TranslationMapperTestRecord](&buffer, typedcsv.WithTranslation("country", test.table))
+		if err := writer.WriteHeader(); err != nil {
+			t.Fatal(err)
+		}
+		if err := writer.WriteRecord(TranslationMapperTestRecord{Country: "GB"}); err != nil {
+			t.Fatal(err)
+		}
+		writer.Flush()
+		if buffer.String() != test.expected {
+			t.Fatalf("%v: Expected %q, got %q", test.table, test.expected, buffer.String())
+		}
+	}
+}
+
+type TranslationMapperTestRecord struct {
+	Country string `csv:"country"`
+}
+
+func TestWithTranslationNameMapper(t *testing.T) {
+	reader := typedcsv.NewReaderFrom[TranslationMapperTestRecord](strings.NewReader("Country\nUK\n"),
+		typedcsv.WithNameMapper(typedcsv.SnakeCaseNameMapper{}), typedcsv.WithTranslation("COUNTRY", map[string]string{"UK": "GB"}))
+	if err := reader.ReadHeader(); err != nil {
+		t.Fatal(err)
+	}
+	record, err := reader.ReadRecord()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if record.Country != "GB" {
+		t.Fatalf("Expected %v, got %v", "GB", record.Country)
+	}
+}
@@ -0,0 +1,63 @@
+package typedcsv_test
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"testing"
+
+	"github.com/hoshiumiarata/typedcsv"
+)
+
+type UnexportedOnlyRecord struct {
+	name string
+}
+
+type UntaggedRecord struct {
+	Name string
+}
+
+func TestTypeError(t *testing.T) {
+	errs := []error{
+		typedcsv.NewCodec[int]().Err(),
+		typedcsv.NewCodec[*Person]().Err(),
+		typedcsv.NewCodec[UnexportedOnlyRecord]().Err(),
+		typedcsv.NewCodec[UntaggedRecord]().Err(),
+	}
+	messages := []string{"not a struct", "not a struct", "no exported fields", "no field is mapped to a column"}
+	notStruct := []bool{true, true, false, false}
+	for i, err := range errs {
+		var typeError typedcsv.TypeError
+		if !errors.As(err, &typeError) || typeError.Message != messages[i] {
+			t.Fatalf("Expected a TypeError %q, got %v", messages[i], err)
+		}
+		if errors.Is(err, typedcsv.ErrNotStruct) != notStruct[i] || errors.Is(err, typedcsv.ErrNoCSVFields) == notStruct[i] {
+			t.Fatalf("Expected %v to wrap ErrNotStruct: %v, got the other sentinel", err, notStruct[i])
+		}
+	}
+	if err := typedcsv.NewCodec[Person]().Err(); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+}
+
+func TestTypeErrorOnFirstUse(t *testing.T) {
+	csvReader := typedcsv.NewReader[string](csv.NewReader(bytes.NewBufferString("a\nb\n")))
+	if err := csvReader.ReadHeader(); !errors.Is(err, typedcsv.ErrNotStruct) {
+		t.Fatalf("Expected %v, got %v", typedcsv.ErrNotStruct, err)
+	}
+	if _, err := csvReader.ReadRecord(); !errors.Is(err, typedcsv.ErrNotStruct) {
+		t.Fatalf("Expected %v, got %v", typedcsv.ErrNotStruct, err)
+	}
+
+	buf := bytes.Buffer{}
+	csvWriter := typedcsv.NewWriter[UntaggedRecord](csv.NewWriter(&buf))
+	if err := csvWriter.WriteHeader(); !errors.Is(err, typedcsv.ErrNoCSVFields) {
+		t.Fatalf("Expected %v, got %v", typedcsv.ErrNoCSVFields, err)
+	}
+	if err := csvWriter.WriteRecord(UntaggedRecord{}); !errors.Is(err, typedcsv.ErrNoCSVFields) {
+		t.Fatalf("Expected %v, got %v", typedcsv.ErrNoCSVFields, err)
+	}
+	if _, err := typedcsv.JSONSchema[int](); !errors.Is(err, typedcsv.ErrNotStruct) {
+		t.Fatalf("Expected %v, got %v", typedcsv.ErrNotStruct, err)
+	}
+}
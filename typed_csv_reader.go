@@ -1,160 +1,373 @@
 package typedcsv
 
 import (
-	"encoding"
+	"context"
 	"encoding/csv"
+	"errors"
 	"fmt"
 	"io"
 	"reflect"
 	"strings"
-	"time"
 )
 
 // A TypedCSVReader reads structs from a CSV file.
 //
 // The struct must have exported fields with a "csv" tag.
+// The fields of embedded structs without a "csv" tag are mapped as if they were fields of the struct.
+// The fields of a struct field with a "prefix" tag are mapped to columns named with the tag value followed by their column names,
+// such as "address.city" for the "city" field of a struct field tagged `prefix:"address."`.
 //
 //   - the "csv" tag value is used as the CSV header.
 //   - the "null" tag value is used to set the field to nil when the CSV value is equal to the tag value.
 //   - the "time_format" tag value is used to parse time.Time fields. The value must be a valid time.Time format.
+//     The special values "excel" and "excel1904" use Excel serial date-times of the 1900 and 1904 date systems.
+//     "epoch_days" uses days since 1970-01-01, "julian_day" and "mjd" use Julian and modified Julian days in UTC.
+//     "iso_week" uses ISO 8601 week dates such as 2024-W05-3.
 //   - the "time_location" tag value is used to set the location of time.Time fields. The value must be a valid time.Location name. Should be used with the "time_format" tag value.
+//   - the "time_zone" tag value is a time.Location name such as "UTC" to which time.Time fields are converted after parsing, overriding WithTimeZone.
+//   - the "prefer" tag value "text" or "time" chooses between UnmarshalText and the "time_format" tag value for fields that support both.
+//     Without it, the "time_format" tag value is used if it is set. With "time", the default time format is time.RFC3339Nano.
+//   - the "duration_format" tag value is used to parse time.Duration fields: "string" for Go duration strings, "seconds" and "milliseconds" for integers, or "hms" for HH:MM:SS.
+//   - the "required", "enum", "pattern", "min" and "max" tag values validate the fields like the same properties of a SchemaColumn.
+//     "required" must be "true", and "enum" lists the allowed values separated by "|". A FieldParseError wrapping ErrValidation is returned if a value is invalid.
+//     A required field, also set with the "required" option of the "csv" tag as in `csv:"name,required"`, must have a column in the header and a non-empty value.
+//   - the "index" tag value is the zero-based position of the column of the field. If a field has an "index" tag or UseStructFieldOrder is set,
+//     the columns are mapped to the fields by position rather than by name, and files without a header are read without calling ReadHeader.
+//   - the "default" tag value is parsed instead of the CSV value when the value is empty or the column is missing, including for required fields.
+//     The "default_func" tag value instead names a function generating the value, registered with RegisterDefaultFunc, such as "now".
+//   - the "format" tag value is used to parse the fields with fmt.Sscanf, without its flags, widths and precisions: "%.2f" parses "%f".
+//     Slice items formatted with a width and joined without a separator, such as "%02x", are split by width,
+//     and strings formatted with a width, such as "%8s", are read without their padding spaces.
+//     The "parse" tag value sets the fmt.Sscanf format explicitly.
+//     Without these tags, and with the decimal verbs of the field kind such as "%d", "%f" and "%s", strings are set to the CSV value verbatim,
+//     and booleans, decimal integers, floats and complex numbers, optionally surrounded by white space, are parsed with strconv.
+//     Integers are in base 10, without prefixes or underscores: "0x1F" and "1_000" are errors, and "010" is 10.
+//   - the "base" tag value, 2 to 36, is the base of integer fields and slice items, such as "16" for hex IDs,
+//     optionally prefixed with "0x", "0o" or "0b" for bases 16, 8 and 2. "0" selects the base from the prefix, as in strconv.ParseInt.
+//     It is used instead of the "format" tag value.
 //   - the "separator" tag value is used to split slice fields.
+//   - the "quoted" tag value "true" allows slice items to be enclosed in double quotes, so that they can contain the separator.
+//   - the "encoding" tag value "csv" makes a struct field read from one cell holding a CSV row, whose values are mapped to the tagged fields of the struct in order. The "separator" tag value can set the delimiter of the row.
+//   - the "encoding" tag value "hex" makes [N]byte fields parsed as hex, "base64" as standard base64, and "uuid" as UUIDs such as
+//     123e4567-e89b-12d3-a456-426614174000 for [16]byte fields, also accepted in upper case, without hyphens, in braces or with the urn:uuid: prefix.
+//   - the "locale" tag value is a BCP 47 language tag such as "fr-FR" selecting the decimal and grouping separators of number fields,
+//     and the month names of time fields whose "time_format" has "January" or "Jan", overriding WithLocale.
+//   - the "parse_method" tag value names a method of the struct with the signature func(string) (V, error), where V is the field type
+//     or the type it points to, which parses the CSV value of that field only, instead of the other tags and converters.
 //
 // If a field implements encoding.TextUnmarshaler, the CSV value is passed to UnmarshalText.
+// Raw fields are set to the CSV value without any parsing.
 type TypedCSVReader[T any] struct {
 	Reader *csv.Reader
 	Header map[string]int
+
+	codec        *Codec[T]
+	tokenizer    *Tokenizer
+	headerFields []string
+	columns      []int
+	warnings     []Warning
+	row          int
+	offset       int64
+	interner     *interner
+	cache        *valueCache
+	closers      []io.Closer
+	seen         func(key string) bool
+	dropped      int
 }
 
 // NewReader returns a new TypedCSVReader that wraps the given csv.Reader.
-func NewReader[T any](reader *csv.Reader) *TypedCSVReader[T] {
-	return &TypedCSVReader[T]{
-		Reader: reader,
-	}
+// To create many readers for the same type and options, use a Codec.
+func NewReader[T any](reader *csv.Reader, opts ...Option) *TypedCSVReader[T] {
+	return NewCodec[T](opts...).NewReader(reader)
+}
+
+// NewFastReader returns a new TypedCSVReader that reads from r with a Tokenizer instead of a csv.Reader.
+// Its Reader field is nil. The Tokenizer can be configured with the Tokenizer method.
+func NewFastReader[T any](r io.Reader, opts ...Option) *TypedCSVReader[T] {
+	return NewCodec[T](opts...).NewFastReader(r)
+}
+
+// Tokenizer returns the Tokenizer of a reader created by NewFastReader, or nil.
+func (r *TypedCSVReader[T]) Tokenizer() *Tokenizer {
+	return r.tokenizer
 }
 
 // ReadHeader reads the CSV header from the underlying reader.
-// It uses the "csv" tag value of the struct fields.
-// It returns io.EOF if there is no header.
+// It matches the header columns with the column names of the struct fields given by the NameMapper.
+// A UTF-8 byte order mark at the start of the header is skipped. Readers created from an io.Reader,
+// such as by NewReaderFrom and NewFastReader, skip it before splitting the header, so that its first column can be quoted.
+// If a column appears more than once, the first one is read and the others are reported as WarningDuplicateColumn.
+// It returns io.EOF if there is no header, and the TypeError of the Codec if T cannot be used as a record.
+// It returns a FieldParseError wrapping ErrValidation if the column of a required field is missing;
+// the header is kept, but ReadRecord returns the same error.
 func (r *TypedCSVReader[T]) ReadHeader() error {
-	header, err := r.Reader.Read()
+	if r.codec.typeErr != nil {
+		return r.codec.typeErr
+	}
+	header, err := r.read()
 	if err != nil {
 		return err
 	}
+	trimBOM(header)
+	if r.codec.options.trimSpace {
+		for i := range header {
+			header[i] = strings.TrimSpace(header[i])
+		}
+	}
+	r.warnings = nil
+	r.headerFields = append([]string(nil), header...)
 	r.Header = make(map[string]int)
 	for i, field := range header {
+		if _, ok := r.Header[field]; ok {
+			r.warn(Warning{Kind: WarningDuplicateColumn, Column: field, Index: i})
+			continue
+		}
 		r.Header[field] = i
 	}
-	return nil
+	r.row = 0
+	r.bindColumns()
+
+	known := make(map[string]bool)
+	var missingErr error
+	for i := range r.codec.fields {
+		f := &r.codec.fields[i]
+		known[f.key] = true
+		if (r.columns[i] < 0 || r.columns[i] >= len(header)) && (r.codec.selected == nil || r.codec.selected[i]) {
+			r.warn(Warning{Kind: WarningMissingColumn, Column: f.name, Index: -1})
+			if f.required() && !f.hasDefaults() && missingErr == nil {
+				missingErr = f.errMissingColumn()
+			}
+		}
+	}
+	if rowHash := r.codec.options.rowHash; rowHash != nil {
+		known[r.codec.options.nameMapper.Canonical(rowHash.column)] = true
+		if _, ok := r.Header[rowHash.column]; !ok {
+			r.warn(Warning{Kind: WarningMissingColumn, Column: rowHash.column, Index: -1})
+		}
+	}
+	for i, field := range header {
+		if r.codec.positions == nil && !known[r.codec.options.nameMapper.Canonical(field)] {
+			r.warn(Warning{Kind: WarningUnknownColumn, Column: field, Index: i})
+		}
+	}
+	if r.codec.options.columnOrderCheck {
+		mapping, _ := r.Mapping()
+		for _, binding := range mapping.ReorderedFields() {
+			r.warn(Warning{Kind: WarningReorderedColumn, Column: binding.Column, Index: binding.Index})
+		}
+	}
+	if r.codec.options.strictHeader {
+		if err := checkStrictHeader(r.warnings); err != nil {
+			return err
+		}
+	}
+	return missingErr
+}
+
+// Warnings returns the warnings reported since the last call to ReadHeader, up to the limit set by WithWarningLimit.
+// They are kept in memory, so WithWarningHandler should be preferred for very large files with many warnings.
+func (r *TypedCSVReader[T]) Warnings() []Warning {
+	return append([]Warning(nil), r.warnings...)
+}
+
+func (r *TypedCSVReader[T]) warn(w Warning) {
+	r.codec.options.warn(w)
+	r.codec.options.keepWarning(&r.warnings, w)
+}
+
+// HeaderFields returns the header read by ReadHeader, with the original column names in their original order,
+// including duplicated and unknown columns. It returns nil if ReadHeader was not called.
+func (r *TypedCSVReader[T]) HeaderFields() []string {
+	return append([]string(nil), r.headerFields...)
+}
+
+// bindColumns computes the column index of each field from the header.
+func (r *TypedCSVReader[T]) bindColumns() {
+	if r.codec.positions != nil {
+		r.columns = r.codec.positions
+	} else {
+		r.columns = r.codec.bind(r.Header)
+	}
+	r.selectColumns()
+	r.interner = newInterner(&r.codec.options, len(r.columns))
+	r.cache = newValueCache(&r.codec.options, len(r.columns))
 }
 
 // ReadRecord reads the CSV record from the underlying reader.
-// It returns ErrHeaderNotRead if ReadHeader was not called.
+// It returns ErrHeaderNotRead if ReadHeader was not called, unless the fields are mapped by position (see UseStructFieldOrder).
 // It returns io.EOF if there are no more records.
 // It returns a FieldParseError if a field cannot be parsed.
+// It returns a RowReadError if the underlying reader returns a *csv.ParseError, such as for a bare quote or a wrong number of fields.
+// It returns an error wrapping ErrRowHashMismatch if WithRowHash is set and the row hash does not match.
+// If a RateLimiter is set, it waits on it before reading and returns any error returned by Wait;
+// use ReadRecordContext to cancel the wait.
+// With WithDedupeWindow or WithBloomDedupe, the duplicate records are skipped.
 // Otherwise, it returns any error returned by the underlying reader.
-func (r *TypedCSVReader[T]) ReadRecord() (record *T, err error) {
-	if r.Header == nil {
-		err = ErrHeaderNotRead
-		return
+func (r *TypedCSVReader[T]) ReadRecord() (*T, error) {
+	return r.ReadRecordContext(context.Background())
+}
+
+// ReadRecordContext reads the CSV record like ReadRecord, passing ctx to the Wait of the RateLimiter set by WithRateLimit,
+// so that a slow limiter does not block forever: it returns the error of Wait, such as ctx.Err(), when ctx is done.
+func (r *TypedCSVReader[T]) ReadRecordContext(ctx context.Context) (*T, error) {
+	record, err := r.readRecord(ctx)
+	r.emitRecord(record, err)
+	return record, err
+}
+
+// emitRecord reports the result of reading a record to the metrics hook and the report.
+func (r *TypedCSVReader[T]) emitRecord(record *T, err error) {
+	if report := r.codec.options.report; report != nil && err != io.EOF {
+		report.add(r.codec.fields, reflect.ValueOf(record).Elem(), err)
 	}
+	switch {
+	case err == nil:
+		r.codec.options.emit(MetricsEvent{Kind: MetricsRecordDecoded})
+	case err != io.EOF:
+		r.codec.options.emit(MetricsEvent{Kind: MetricsRecordFailed, Err: err})
+	}
+}
 
-	values, err := r.Reader.Read()
-	if err != nil {
-		return
+// A rowSource is the tokenizer used by TypedCSVReader, either a csv.Reader or a Tokenizer.
+type rowSource interface {
+	Read() ([]string, error)
+	InputOffset() int64
+}
+
+// source returns the Tokenizer if the reader was created by NewFastReader, or the underlying csv.Reader.
+func (r *TypedCSVReader[T]) source() rowSource {
+	if r.tokenizer != nil {
+		return r.tokenizer
 	}
+	return r.Reader
+}
 
-	record = new(T)
+// read reads the next row from the underlying reader and reports the number of bytes consumed.
+func (r *TypedCSVReader[T]) read() ([]string, error) {
+	source := r.source()
+	values, err := source.Read()
+	if offset := source.InputOffset(); offset > r.offset {
+		r.codec.options.emit(MetricsEvent{Kind: MetricsBytesRead, Bytes: offset - r.offset})
+		r.offset = offset
+	}
+	return values, err
+}
+
+func (r *TypedCSVReader[T]) readRecord(ctx context.Context) (*T, error) {
+	record := new(T)
+	read, err := r.readRecordInto(ctx, record)
+	if !read {
+		return nil, err
+	}
+	return record, err
+}
 
-	recordType := reflect.TypeOf(record).Elem()
-	recordValue := reflect.ValueOf(record).Elem()
+// ReadRecordInto reads the next CSV record into the given record, which is zeroed first.
+// It returns the same errors as ReadRecord. Together with AcquireRecord and ReleaseRecord,
+// it lets consumers reuse records instead of allocating one per row.
+func (r *TypedCSVReader[T]) ReadRecordInto(record *T) error {
+	var zero T
+	*record = zero
+	_, err := r.readRecordInto(context.Background(), record)
+	r.emitRecord(record, err)
+	return err
+}
 
-	for i := 0; i < recordType.NumField(); i++ {
-		field := recordType.Field(i)
-		if !isValidCSVField(field) {
-			continue
-		}
-		csvTagValue := field.Tag.Get(csvTag)
-		index, ok := r.Header[csvTagValue]
-		if !ok {
-			continue
+// AcquireRecord returns a zero record from the pool of the Codec of the reader. See Codec.AcquireRecord.
+func (r *TypedCSVReader[T]) AcquireRecord() *T {
+	return r.codec.AcquireRecord()
+}
+
+// ReleaseRecord puts the record back in the pool of the Codec of the reader. See Codec.ReleaseRecord.
+func (r *TypedCSVReader[T]) ReleaseRecord(record *T) {
+	r.codec.ReleaseRecord(record)
+}
+
+// readRecordInto reads the next row and decodes it into the zero record, skipping the duplicates dropped by WithDedupeWindow.
+// It reports whether a row was read, so that the record holds the partially decoded values on parse errors.
+func (r *TypedCSVReader[T]) readRecordInto(ctx context.Context, record *T) (read bool, err error) {
+	dedupe := r.codec.options.dedupe
+	if dedupe == nil {
+		return r.readRowInto(ctx, record)
+	}
+	if dedupe.typ != reflect.TypeFor[T]() {
+		return false, fmt.Errorf("typedcsv: deduplication key of %v records used with %v records", dedupe.typ, reflect.TypeFor[T]())
+	}
+	if r.seen == nil {
+		r.seen = dedupe.newFilter()
+	}
+	for {
+		read, err = r.readRowInto(ctx, record)
+		if err != nil || !r.seen(dedupe.key(record)) {
+			return read, err
 		}
-		value := values[index]
-		fieldValue := recordValue.Field(i)
-		fieldKind := fieldValue.Kind()
-		// Pointer
-		if fieldKind == reflect.Ptr {
-			if nullTagValue, ok := field.Tag.Lookup(nullTag); ok && value == nullTagValue {
-				fieldValue.Set(reflect.Zero(fieldValue.Type()))
-				continue
-			}
-			fieldValue.Set(reflect.New(fieldValue.Type().Elem()))
-			fieldValue = fieldValue.Elem()
-		}
-		fieldType := fieldValue.Type()
-		fieldAddr := fieldValue.Addr()
-		fieldAddrInterface := fieldAddr.Interface()
-		// Time
-		if fieldType.ConvertibleTo(timeType) {
-			timeFormat := field.Tag.Get(timeFormatTag)
-			var timeValue time.Time
-			if timeFormat != "" {
-				// time location tag
-				timeLocation := field.Tag.Get(timeLocationTag)
-				if timeLocation != "" {
-					location, err := time.LoadLocation(timeLocation)
-					if err != nil {
-						return record, FieldParseError{Field: csvTagValue, NestedError: err}
-					}
-					timeValue, err = time.ParseInLocation(timeFormat, value, location)
-					if err != nil {
-						return record, FieldParseError{Field: csvTagValue, NestedError: err}
-					}
-				} else {
-					timeValue, err = time.Parse(timeFormat, value)
-					if err != nil {
-						return record, FieldParseError{Field: csvTagValue, NestedError: err}
-					}
-				}
-				fieldValue.Set(reflect.ValueOf(timeValue).Convert(fieldType))
-				continue
-			}
+		r.dropped++
+		var zero T
+		*record = zero
+	}
+}
+
+// Dropped returns the number of duplicate records dropped so far with WithDedupeWindow or WithBloomDedupe.
+func (r *TypedCSVReader[T]) Dropped() int {
+	return r.dropped
+}
+
+// readRowInto reads the next row and decodes it into the zero record.
+func (r *TypedCSVReader[T]) readRowInto(ctx context.Context, record *T) (read bool, err error) {
+	if r.codec.typeErr != nil {
+		return false, r.codec.typeErr
+	}
+	if r.Header == nil {
+		if r.codec.positions == nil {
+			return false, ErrHeaderNotRead
 		}
-		// TextUnmarshaler
-		if fieldAddr.Type().Implements(textUnmarshalerType) {
-			err := fieldAddrInterface.(encoding.TextUnmarshaler).UnmarshalText([]byte(value))
-			if err != nil {
-				return record, FieldParseError{Field: csvTagValue, NestedError: err}
-			}
-			continue
+		r.Header = r.codec.positionalHeader()
+	}
+	if r.columns == nil {
+		r.bindColumns()
+	}
+
+	if r.codec.options.rateLimiter != nil {
+		err = r.codec.options.rateLimiter.Wait(ctx)
+		if err != nil {
+			return false, err
 		}
-		// Slice
-		if fieldKind == reflect.Slice {
-			separator := field.Tag.Get(separatorTag)
-			slice := reflect.MakeSlice(fieldType, 0, 0)
-			for itemIndex, item := range strings.Split(value, separator) {
-				itemValue := reflect.New(fieldType.Elem())
-				_, err := fmt.Sscanf(item, "%v", itemValue.Interface())
-				if err != nil {
-					return record, FieldParseError{Field: fmt.Sprintf("%s[%d]", csvTagValue, itemIndex), NestedError: err}
-				}
-				slice = reflect.Append(slice, itemValue.Elem())
-			}
-			fieldValue.Set(slice)
-			continue
+	}
+
+	values, err := r.read()
+	var parseError *csv.ParseError
+	if errors.As(err, &parseError) {
+		r.row++
+		return false, RowReadError{
+			Record:    r.row,
+			StartLine: parseError.StartLine,
+			Line:      parseError.Line,
+			Column:    parseError.Column,
+			Header:    r.Header,
+			Err:       parseError,
 		}
-		// Default
-		_, err := fmt.Sscanf(value, "%v", fieldAddrInterface)
-		if err == io.EOF {
-			fieldValue.Set(reflect.Zero(fieldValue.Type()))
-			err = nil
+	}
+	if err != nil {
+		return false, err
+	}
+	r.row++
+	if rowHash := r.codec.options.rowHash; rowHash != nil {
+		index, ok := r.Header[rowHash.column]
+		if !ok {
+			index = -1
 		}
-		if err != nil {
-			return record, FieldParseError{Field: csvTagValue, NestedError: err}
+		if !rowHash.verify(values, index) {
+			return false, fmt.Errorf("%w in record %d", ErrRowHashMismatch, r.row)
 		}
 	}
 
-	return
+	state := decodeState{options: &r.codec.options, row: r.row, warnings: &r.warnings, interner: r.interner, cache: r.cache}
+	err = r.codec.decodeInto(&state, r.columns, values, record)
+	if fieldParseError, ok := err.(FieldParseError); ok {
+		fieldParseError.Record, fieldParseError.Line = r.row, r.line()
+		err = fieldParseError
+	}
+	return true, err
 }
 
 // ReadAll reads all the remaining records from the underlying reader.
@@ -175,3 +388,100 @@ func (r *TypedCSVReader[T]) ReadAll() (records []*T, err error) {
 	}
 	return
 }
+
+// ReadAllLenient reads all the remaining records like ReadAll, but keeps reading after a record that cannot be parsed,
+// that is malformed (a RowReadError) or whose row hash does not match. It returns the records that were read successfully
+// and the errors of the other records joined with errors.Join, each with its record number and line,
+// so that all the problems of a file are found in one pass.
+// It stops at the first other error of the underlying reader, which is also returned.
+func (r *TypedCSVReader[T]) ReadAllLenient() (records []*T, err error) {
+	var errs []error
+	for {
+		record := new(T)
+		read, err := r.readRecordInto(context.Background(), record)
+		r.emitRecord(record, err)
+		switch {
+		case err == nil:
+			records = append(records, record)
+		case read, errors.As(err, new(RowReadError)):
+			errs = append(errs, err)
+		case errors.Is(err, ErrRowHashMismatch):
+			errs = append(errs, fmt.Errorf("%w on line %d", err, r.line()))
+		case err == io.EOF:
+			return records, errors.Join(errs...)
+		default:
+			return records, errors.Join(append(errs, err)...)
+		}
+	}
+}
+
+// line returns the line of the start of the last row read.
+func (r *TypedCSVReader[T]) line() int {
+	if r.tokenizer != nil {
+		return r.tokenizer.startLine
+	}
+	line, _ := r.Reader.FieldPos(0)
+	return line
+}
+
+// Reset discards the state of the TypedCSVReader and makes it read from the given csv.Reader, even if it was created by NewFastReader.
+// The Codec and its options are kept. ReadHeader must be called again before ReadRecord.
+func (r *TypedCSVReader[T]) Reset(reader *csv.Reader) {
+	r.Reader = reader
+	r.tokenizer = nil
+	r.reset()
+}
+
+func (r *TypedCSVReader[T]) reset() {
+	r.Header = nil
+	r.headerFields = nil
+	r.columns = nil
+	r.warnings = nil
+	r.row = 0
+	r.offset = 0
+	r.interner = nil
+	r.cache = nil
+	r.seen = nil
+	r.dropped = 0
+}
+
+// Rewind seeks the given source, which must be the one the underlying csv.Reader reads from, back to its start
+// and re-reads the header. A reader whose fields are mapped by position and on which ReadHeader was never called
+// reads a file without a header: Rewind does not read a header then, and the next ReadRecord returns the first row again.
+// The underlying csv.Reader is replaced by a new one with the same configuration.
+// For a reader created by NewFastReader, the Tokenizer is replaced instead, with the same Comma, Quote and Escape.
+// Like Reset, it discards the state of the previous pass: the warnings, the record numbers, the interned strings,
+// the cached values and the keys seen by WithDedupeWindow.
+func (r *TypedCSVReader[T]) Rewind(source io.ReadSeeker) error {
+	_, err := source.Seek(0, io.SeekStart)
+	if err != nil {
+		return err
+	}
+	headerless := r.codec.positions != nil && r.headerFields == nil
+	if r.tokenizer != nil {
+		tokenizer := NewTokenizer(source)
+		tokenizer.Comma = r.tokenizer.Comma
+		tokenizer.Quote = r.tokenizer.Quote
+		tokenizer.Escape = r.tokenizer.Escape
+		r.tokenizer = tokenizer
+		r.reset()
+		return r.rewindHeader(headerless)
+	}
+	reader := csv.NewReader(skipBOM(source))
+	reader.Comma = r.Reader.Comma
+	reader.Comment = r.Reader.Comment
+	reader.FieldsPerRecord = r.Reader.FieldsPerRecord
+	reader.LazyQuotes = r.Reader.LazyQuotes
+	reader.TrimLeadingSpace = r.Reader.TrimLeadingSpace
+	reader.ReuseRecord = r.Reader.ReuseRecord
+	r.Reset(reader)
+	return r.rewindHeader(headerless)
+}
+
+// rewindHeader re-reads the header after a rewind, unless the file has none.
+func (r *TypedCSVReader[T]) rewindHeader(headerless bool) error {
+	if headerless {
+		return nil
+	}
+	return r.ReadHeader()
+}
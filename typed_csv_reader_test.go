@@ -6,6 +6,7 @@ import (
 	"errors"
 	"io"
 	"reflect"
+	"strings"
 	"testing"
 	"time"
 
@@ -44,6 +45,25 @@ func TestReadHeaderEmpty(t *testing.T) {
 	}
 }
 
+func TestReadHeaderDuplicateColumns(t *testing.T) {
+	csvReader := typedcsv.NewReaderFrom[Person](strings.NewReader("name,age,name,Name,name\nJohn,30,Jane,Jim,Joe\n"),
+		typedcsv.WithNameMapper(typedcsv.SnakeCaseNameMapper{TagKey: "csv"}))
+	err := csvReader.ReadHeader()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if csvReader.Header["name"] != 0 {
+		t.Fatalf("Expected %v, got %v", 0, csvReader.Header["name"])
+	}
+	record, err := csvReader.ReadRecord()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if record.Name != "John" {
+		t.Fatalf("Expected %v, got %v", "John", record.Name)
+	}
+}
+
 func TestReadRecordMultiple(t *testing.T) {
 	reader := bytes.Buffer{}
 	reader.WriteString("name,birthday,age,pet names,active,status,percentage,optional\n")
@@ -151,7 +171,7 @@ func TestReadRecordTimeWithWrongTimeFormat(t *testing.T) {
 	if fieldParseError.Unwrap().Error() != expected {
 		t.Fatalf("Expected %v, got %v", expected, fieldParseError.Unwrap().Error())
 	}
-	expected = "typedcsv: error parsing field 'time_with_location': parsing time \"abc\" as \"2006-01-02 15:04:05\": cannot parse \"abc\" as \"2006\""
+	expected = "typedcsv: error parsing field 'time_with_location' in record 1 on line 2: parsing time \"abc\" as \"2006-01-02 15:04:05\": cannot parse \"abc\" as \"2006\""
 	if err.Error() != expected {
 		t.Fatalf("Expected %v, got %v", expected, err.Error())
 	}
@@ -178,7 +198,7 @@ func TestReadRecordTimeWithWrongTimeFormat(t *testing.T) {
 	if fieldParseError.Unwrap().Error() != expected {
 		t.Fatalf("Expected %v, got %v", expected, fieldParseError.Unwrap().Error())
 	}
-	expected = "typedcsv: error parsing field 'time_without_location': parsing time \"abc\" as \"2006-01-02 15:04:05\": cannot parse \"abc\" as \"2006\""
+	expected = "typedcsv: error parsing field 'time_without_location' in record 1 on line 2: parsing time \"abc\" as \"2006-01-02 15:04:05\": cannot parse \"abc\" as \"2006\""
 	if err.Error() != expected {
 		t.Fatalf("Expected %v, got %v", expected, err.Error())
 	}
@@ -208,7 +228,7 @@ func TestReadRecordTimeWithWrongTimeLocation(t *testing.T) {
 	if fieldParseError.Unwrap().Error() != expected {
 		t.Fatalf("Expected %v, got %v", expected, fieldParseError.Unwrap().Error())
 	}
-	expected = "typedcsv: error parsing field 'time': unknown time zone abcdef"
+	expected = "typedcsv: error parsing field 'time' in record 1 on line 2: unknown time zone abcdef"
 	if err.Error() != expected {
 		t.Fatalf("Expected %v, got %v", expected, err.Error())
 	}
@@ -303,7 +323,7 @@ func TestReadRecordUnmarshalText(t *testing.T) {
 	if fieldParseError.Unwrap().Error() != "unknown status" {
 		t.Fatalf("Expected %v, got %v", "unknown status", fieldParseError.Unwrap().Error())
 	}
-	expectedErrorMessage := "typedcsv: error parsing field 'person_status': unknown status"
+	expectedErrorMessage := "typedcsv: error parsing field 'person_status' in record 1 on line 2: unknown status"
 	if err.Error() != expectedErrorMessage {
 		t.Fatalf("Expected %v, got %v", expectedErrorMessage, err.Error())
 	}
@@ -373,7 +393,7 @@ func TestReadRecordNotSupportedTypes(t *testing.T) {
 	if fieldParseError.Unwrap().Error() != expected {
 		t.Fatalf("Expected %v, got %v", expected, fieldParseError.Unwrap().Error())
 	}
-	expected = "typedcsv: error parsing field 'map': can't scan type: *map[string]string"
+	expected = "typedcsv: error parsing field 'map' in record 1 on line 2: can't scan type: *map[string]string"
 	if err.Error() != expected {
 		t.Fatalf("Expected %v, got %v", expected, err.Error())
 	}
@@ -400,7 +420,7 @@ func TestReadRecordNotSupportedTypes(t *testing.T) {
 	if fieldParseError.Unwrap().Error() != expected {
 		t.Fatalf("Expected %v, got %v", expected, fieldParseError.Unwrap().Error())
 	}
-	expected = "typedcsv: error parsing field 'slice_of_map[0]': can't scan type: *map[string]string"
+	expected = "typedcsv: error parsing field 'slice_of_map[0]' in record 1 on line 2: can't scan type: *map[string]string"
 	if err.Error() != expected {
 		t.Fatalf("Expected %v, got %v", expected, err.Error())
 	}
@@ -471,8 +491,111 @@ func TestReadAllTimeWithWrongTimeLocation(t *testing.T) {
 	if fieldParseError.Unwrap().Error() != expected {
 		t.Fatalf("Expected %v, got %v", expected, fieldParseError.Unwrap().Error())
 	}
-	expected = "typedcsv: error parsing field 'time': unknown time zone abcdef"
+	expected = "typedcsv: error parsing field 'time' in record 1 on line 2: unknown time zone abcdef"
 	if err.Error() != expected {
 		t.Fatalf("Expected %v, got %v", expected, err.Error())
 	}
 }
+
+func TestReset(t *testing.T) {
+	reader := bytes.Buffer{}
+	reader.WriteString("person_status\n")
+	reader.WriteString("active\n")
+	csvReader := typedcsv.NewReader[MarshalTextTestRecord](csv.NewReader(&reader))
+	err := csvReader.ReadHeader()
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = csvReader.ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	reader.WriteString("person_status\n")
+	reader.WriteString("inactive\n")
+	csvReader.Reset(csv.NewReader(&reader))
+	_, err = csvReader.ReadRecord()
+	if err != typedcsv.ErrHeaderNotRead {
+		t.Fatalf("Expected %v, got %v", typedcsv.ErrHeaderNotRead, err)
+	}
+	err = csvReader.ReadHeader()
+	if err != nil {
+		t.Fatal(err)
+	}
+	record, err := csvReader.ReadRecord()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if record.PersonStatus != PersonStatusInactive {
+		t.Fatalf("Expected %v, got %v", PersonStatusInactive, record.PersonStatus)
+	}
+}
+
+func TestRewind(t *testing.T) {
+	source := strings.NewReader("person_status;other\nactive;1\ninactive;2\n")
+	csvReader := csv.NewReader(source)
+	csvReader.Comma = ';'
+	typedReader := typedcsv.NewReader[MarshalTextTestRecord](csvReader)
+	err := typedReader.ReadHeader()
+	if err != nil {
+		t.Fatal(err)
+	}
+	first, err := typedReader.ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = typedReader.Rewind(source)
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := typedReader.ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(first) != 2 || !reflect.DeepEqual(first, second) {
+		t.Fatalf("Expected %v, got %v", first, second)
+	}
+}
+
+func TestHeaderFields(t *testing.T) {
+	reader := bytes.Buffer{}
+	reader.WriteString("status,name,extra,name\n")
+	csvReader := typedcsv.NewReader[Person](csv.NewReader(&reader))
+	if csvReader.HeaderFields() != nil {
+		t.Fatalf("Expected nil, got %v", csvReader.HeaderFields())
+	}
+	err := csvReader.ReadHeader()
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{"status", "name", "extra", "name"}
+	if !reflect.DeepEqual(csvReader.HeaderFields(), expected) {
+		t.Fatalf("Expected %v, got %v", expected, csvReader.HeaderFields())
+	}
+}
+
+func TestReadAllLenient(t *testing.T) {
+	input := "person_status\nactive\nbad\n\ninactive\n\"multi\nline\"\nunknown\n"
+	readers := []*typedcsv.TypedCSVReader[MarshalTextTestRecord]{
+		typedcsv.NewReader[MarshalTextTestRecord](csv.NewReader(bytes.NewBufferString(input))),
+		typedcsv.NewFastReader[MarshalTextTestRecord](bytes.NewBufferString(input)),
+	}
+	for _, csvReader := range readers {
+		err := csvReader.ReadHeader()
+		if err != nil {
+			t.Fatal(err)
+		}
+		records, err := csvReader.ReadAllLenient()
+		if len(records) != 3 || records[2].PersonStatus != PersonStatusUnknown {
+			t.Fatalf("Expected 3 records, got %v", records)
+		}
+		var fieldParseError typedcsv.FieldParseError
+		if !errors.As(err, &fieldParseError) {
+			t.Fatalf("Expected a FieldParseError, got %v", err)
+		}
+		errs := err.(interface{ Unwrap() []error }).Unwrap()
+		if len(errs) != 2 || !strings.Contains(errs[0].Error(), " in record 2 on line 3: ") || !strings.Contains(errs[1].Error(), " in record 4 on line 6: ") {
+			t.Fatalf("Expected errors for records 2 and 4, got %v", err)
+		}
+	}
+}
@@ -1,142 +1,171 @@
 package typedcsv
 
 import (
-	"encoding"
 	"encoding/csv"
-	"fmt"
-	"reflect"
-	"strings"
+	"io"
 	"time"
 )
 
 // A TypedCSVWriter writes structs to a CSV file.
 //
 // The struct must have exported fields with a "csv" tag.
+// The fields of embedded structs without a "csv" tag are mapped as if they were fields of the struct.
+// The fields of a struct field with a "prefix" tag are mapped to columns named with the tag value followed by their column names,
+// such as "address.city" for the "city" field of a struct field tagged `prefix:"address."`.
 //
 //   - the "csv" tag value is used as the CSV header.
 //   - the "null" tag value is used as the CSV value when the field is nil.
 //   - the "format" tag value is used as the CSV value. The format and the field value are passed to fmt.Sprintf.
 //   - the "time_format" tag value is used to format time.Time fields. The value must be a valid time.Time format.
+//     The special values "excel" and "excel1904" use Excel serial date-times of the 1900 and 1904 date systems.
+//     "epoch_days" uses days since 1970-01-01, "julian_day" and "mjd" use Julian and modified Julian days in UTC.
+//     "iso_week" uses ISO 8601 week dates such as 2024-W05-3.
 //   - the "time_location" tag value is used to set the location of time.Time fields. The value must be a valid time.Location name. Should be used with the "time_format" tag value.
+//   - the "time_truncate" and "time_round" tag values are durations such as "1s" used to truncate and round time.Time fields before formatting.
+//   - the "prefer" tag value "text" or "time" chooses between MarshalText and the "time_format" tag value for fields that support both.
+//     Without it, the "time_format" tag value is used if it is set. With "time", the default time format is time.RFC3339Nano.
+//   - the "duration_format" tag value is used to format time.Duration fields: "string" for Go duration strings, "seconds" and "milliseconds" for integers, or "hms" for HH:MM:SS.
+//   - the "required", "enum", "pattern", "min" and "max" tag values validate the fields like the same properties of a SchemaColumn.
+//     "required" must be "true", and "enum" lists the allowed values separated by "|". A FieldFormatError wrapping ErrValidation is returned if a value is invalid.
+//   - the "base" tag value is the base in which integer fields and slice items are written, without prefix, unless they have a "format" tag value.
 //   - the "separator" tag value is used to join slice fields. Can be used with the "format" tag value.
+//   - the "quoted" tag value "true" encloses slice items containing the separator or double quotes in double quotes.
+//   - the "encoding" tag value "csv" makes a struct field written as a CSV row in one cell, with the tagged fields of the struct in order. The "separator" tag value can set the delimiter of the row.
+//   - the "encoding" tag value "hex" makes [N]byte fields written as hex, "base64" as standard base64, and "uuid" as UUIDs such as
+//     123e4567-e89b-12d3-a456-426614174000 for [16]byte fields.
+//   - the "include_if" tag value names a method of the struct with the signature func() bool. The field is written only if the method returns true,
+//     otherwise the "null" tag value is written.
+//   - the "locale" tag value is a BCP 47 language tag such as "fr-FR" selecting the decimal separator of number fields,
+//     written without grouping, and the month names of time fields, overriding WithLocale.
+//   - the "default_func" tag value names a function registered with RegisterDefaultFunc, such as "now", generating the value written
+//     instead of the zero value of the field. Only TypedCSVWriter generates values: Equal, FieldDiffs and the other comparisons
+//     of records use the zero value.
+//   - the "format_method" tag value names a method of the struct with the signature func(V) (string, error), where V is the field type
+//     or the type it points to, which formats the value of that field only, instead of the other tags and converters.
 //
 // If a field implements encoding.TextMarshaler, the CSV value is the result of calling MarshalText.
+// Raw fields are written without any formatting.
 type TypedCSVWriter[T any] struct {
 	Writer *csv.Writer
+
+	codec       *Codec[T]
+	tokenWriter *TokenWriter
+	records     int
+	unflushed   int
+	lastFlush   time.Time
+	closers     []io.Closer
+	appending   bool
 }
 
-// NewWriter returns a new TypedCSVWriter that wraps the given csv.Writer.
-func NewWriter[T any](writer *csv.Writer) *TypedCSVWriter[T] {
-	return &TypedCSVWriter[T]{
-		Writer: writer,
+// A rowSink is the writer used by TypedCSVWriter, either a csv.Writer or a TokenWriter.
+type rowSink interface {
+	Write(record []string) error
+	Flush()
+	Error() error
+}
+
+// sink returns the TokenWriter if the writer was created by NewFastWriter, or the underlying csv.Writer.
+func (w *TypedCSVWriter[T]) sink() rowSink {
+	if w.tokenWriter != nil {
+		return w.tokenWriter
 	}
+	return w.Writer
+}
+
+// NewWriter returns a new TypedCSVWriter that wraps the given csv.Writer.
+// To create many writers for the same type and options, use a Codec.
+func NewWriter[T any](writer *csv.Writer, opts ...Option) *TypedCSVWriter[T] {
+	return NewCodec[T](opts...).NewWriter(writer)
+}
+
+// NewFastWriter returns a new TypedCSVWriter that writes to w with a TokenWriter instead of a csv.Writer.
+// Its Writer field is nil. The TokenWriter can be configured with the TokenWriter method.
+func NewFastWriter[T any](w io.Writer, opts ...Option) *TypedCSVWriter[T] {
+	return NewCodec[T](opts...).NewFastWriter(w)
+}
+
+// TokenWriter returns the TokenWriter of a writer created by NewFastWriter, or nil.
+func (w *TypedCSVWriter[T]) TokenWriter() *TokenWriter {
+	return w.tokenWriter
 }
 
 // WriteHeader writes the CSV header to the underlying writer.
-// It uses the "csv" tag value of the struct fields.
+// It uses the column names of the struct fields given by the NameMapper, followed by the row hash column if WithRowHash is set.
+// With WithBOM, the header starts with a UTF-8 byte order mark.
+// With WithoutHeader, or if the writer appends to a file that already has a header (see AppendMode), it writes nothing.
+// It returns the TypeError of the Codec if T cannot be used as a record.
 func (w *TypedCSVWriter[T]) WriteHeader() error {
-	var zero [0]T
-	t := reflect.TypeOf(zero).Elem()
-
-	var header []string
-	for i := 0; i < t.NumField(); i++ {
-		field := t.Field(i)
-		if isValidCSVField(field) {
-			header = append(header, field.Tag.Get(csvTag))
-		}
+	if w.codec.typeErr != nil {
+		return w.codec.typeErr
 	}
-
-	return w.Writer.Write(header)
+	if w.codec.options.noHeader || w.appending {
+		return nil
+	}
+	header := w.codec.fileHeader()
+	if w.codec.options.bom {
+		header = withBOM(header)
+	}
+	return w.sink().Write(header)
 }
 
 // WriteRecord writes the CSV record to the underlying writer.
-// It returns a FieldFormatError if a field cannot be formatted.
+// It returns a FieldFormatError with the number of the record if a field cannot be formatted.
+// With WithFlushEvery, it flushes the writer when due and returns the error of the flush.
 // Otherwise, it returns any error returned by the underlying writer.
 func (w *TypedCSVWriter[T]) WriteRecord(record T) error {
-	recordType := reflect.TypeOf(record)
-	recordValue := reflect.ValueOf(record)
-
-	var values []string
-	for i := 0; i < recordType.NumField(); i++ {
-		field := recordType.Field(i)
-		if !isValidCSVField(field) {
-			continue
-		}
-		csvTagValue := field.Tag.Get(csvTag)
-		fieldValue := recordValue.Field(i)
-		fieldKind := fieldValue.Kind()
-		// Pointer
-		if fieldKind == reflect.Ptr {
-			if fieldValue.IsNil() {
-				nullTagValue := field.Tag.Get(nullTag)
-				values = append(values, nullTagValue)
-				continue
-			}
-			fieldValue = fieldValue.Elem()
-		}
-		fieldType := fieldValue.Type()
-		// Time
-		if fieldType.ConvertibleTo(timeType) {
-			if timeFormat, ok := field.Tag.Lookup(timeFormatTag); ok {
-				timeValue := fieldValue.Convert(timeType).Interface().(time.Time)
-				if timeLocation, ok := field.Tag.Lookup(timeLocationTag); ok {
-					location, err := time.LoadLocation(timeLocation)
-					if err != nil {
-						return FieldFormatError{Field: csvTagValue, NestedError: err}
-					}
-
-					timeValue = timeValue.In(location)
-				}
-
-				values = append(values, timeValue.Format(timeFormat))
-				continue
-			}
-		}
-		// TextMarshaler
-		if fieldType.Implements(textMarshalerType) {
-			text, err := fieldValue.Interface().(encoding.TextMarshaler).MarshalText()
-			if err != nil {
-				return FieldFormatError{Field: csvTagValue, NestedError: err}
-			}
-			values = append(values, string(text))
-			continue
-		}
-		// Slice
-		if fieldKind == reflect.Slice {
-			separator := field.Tag.Get(separatorTag)
-			format, ok := field.Tag.Lookup(formatTag)
-			if !ok {
-				format = "%v"
-			}
-			var builder strings.Builder
-			for i := 0; i < fieldValue.Len(); i++ {
-				if i > 0 {
-					builder.WriteString(separator)
-				}
-				builder.WriteString(fmt.Sprintf(format, fieldValue.Index(i).Interface()))
-			}
-			values = append(values, builder.String())
-			continue
-		}
-		// Format
-		if format, ok := field.Tag.Lookup(formatTag); ok {
-			values = append(values, fmt.Sprintf(format, fieldValue.Interface()))
-			continue
-		}
-		// Default
-		values = append(values, fmt.Sprintf("%v", fieldValue.Interface()))
+	err := w.writeRecord(record)
+	if err == nil {
+		err = w.autoFlush()
+	}
+	if err != nil {
+		w.codec.options.emit(MetricsEvent{Kind: MetricsRecordFailed, Err: err})
+	} else {
+		w.codec.options.emit(MetricsEvent{Kind: MetricsRecordEncoded})
 	}
+	return err
+}
 
-	return w.Writer.Write(values)
+func (w *TypedCSVWriter[T]) writeRecord(record T) error {
+	if w.codec.typeErr != nil {
+		return w.codec.typeErr
+	}
+	w.records++
+	values, err := w.codec.fileRow(record)
+	if fieldFormatError, ok := err.(FieldFormatError); ok {
+		fieldFormatError.Record = w.records
+		return fieldFormatError
+	}
+	if err != nil {
+		return err
+	}
+	if w.tokenWriter != nil {
+		return w.tokenWriter.write(values, w.codec.raw)
+	}
+	return w.sink().Write(values)
 }
 
 // Flush writes any buffered data to the underlying csv.Writer.
 // To check if an error occurred during the Flush, call Error.
 func (w *TypedCSVWriter[T]) Flush() {
-	w.Writer.Flush()
+	w.sink().Flush()
+	w.unflushed, w.lastFlush = 0, time.Now()
+	w.codec.options.emit(MetricsEvent{Kind: MetricsFlush, Err: w.sink().Error()})
 }
 
 // Error reports any error that has occurred during a previous WriteHeader, WriteRecord or Flush.
 func (w *TypedCSVWriter[T]) Error() error {
-	return w.Writer.Error()
+	return w.sink().Error()
+}
+
+// Reset makes the TypedCSVWriter write to the given csv.Writer, even if it was created by NewFastWriter. The Codec and its options are kept.
+// Any data buffered in the previous csv.Writer is not flushed. The records are counted again from the first one.
+// The resources owned by the writer, such as the file created by CreateWriter, are released without being closed,
+// so that Close only flushes the new csv.Writer; call Close before Reset to close them.
+func (w *TypedCSVWriter[T]) Reset(writer *csv.Writer) {
+	w.Writer = writer
+	w.tokenWriter = nil
+	w.records = 0
+	w.closers = nil
+	w.unflushed, w.lastFlush = 0, time.Time{}
+	w.appending = false
 }
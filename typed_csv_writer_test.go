@@ -99,7 +99,7 @@ func TestWriteRecordTimeWithWrongTimeLocation(t *testing.T) {
 	if fieldFormatError.Unwrap().Error() != expected {
 		t.Fatalf("Expected %q, got %q", expected, fieldFormatError.NestedError.Error())
 	}
-	expected = "typedcsv: error formatting field 'time': unknown time zone abcdef"
+	expected = "typedcsv: error formatting field 'time' in record 1: unknown time zone abcdef"
 	if err.Error() != expected {
 		t.Fatalf("Expected %q, got %q", expected, err.Error())
 	}
@@ -192,7 +192,7 @@ func TestWriteRecordMarshalText(t *testing.T) {
 	if fieldFormatError.Unwrap().Error() != expected {
 		t.Fatalf("Expected %q, got %q", expected, fieldFormatError.NestedError.Error())
 	}
-	expected = "typedcsv: error formatting field 'person_status': unknown status"
+	expected = "typedcsv: error formatting field 'person_status' in record 2: unknown status"
 	if err.Error() != expected {
 		t.Fatalf("Expected %q, got %q", expected, err.Error())
 	}
@@ -228,3 +228,50 @@ func TestWriterError(t *testing.T) {
 		t.Fatal("Expected error, got nil")
 	}
 }
+
+func TestWriterReset(t *testing.T) {
+	writer1 := bytes.Buffer{}
+	writer2 := bytes.Buffer{}
+	csvWriter := typedcsv.NewWriter[MarshalTextTestRecord](csv.NewWriter(&writer1))
+	for i, writer := range []*bytes.Buffer{&writer1, &writer2} {
+		if i > 0 {
+			csvWriter.Reset(csv.NewWriter(writer))
+		}
+		err := csvWriter.WriteHeader()
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = csvWriter.WriteRecord(MarshalTextTestRecord{PersonStatus: PersonStatus(i + 1)})
+		if err != nil {
+			t.Fatal(err)
+		}
+		csvWriter.Flush()
+	}
+	expected := "person_status\nactive\n"
+	if writer1.String() != expected {
+		t.Fatalf("Expected %q, got %q", expected, writer1.String())
+	}
+	expected = "person_status\ninactive\n"
+	if writer2.String() != expected {
+		t.Fatalf("Expected %q, got %q", expected, writer2.String())
+	}
+}
+
+type WriterResetTestRecord struct {
+	Value int `csv:"value" max:"10"`
+}
+
+func TestWriterResetRecordNumber(t *testing.T) {
+	csvWriter := typedcsv.NewWriter[WriterResetTestRecord](csv.NewWriter(&bytes.Buffer{}))
+	for _, record := range []WriterResetTestRecord{{Value: 1}, {Value: 2}} {
+		if err := csvWriter.WriteRecord(record); err != nil {
+			t.Fatal(err)
+		}
+	}
+	csvWriter.Reset(csv.NewWriter(&bytes.Buffer{}))
+	err := csvWriter.WriteRecord(WriterResetTestRecord{Value: 100})
+	var fieldFormatError typedcsv.FieldFormatError
+	if !errors.As(err, &fieldFormatError) || fieldFormatError.Record != 1 {
+		t.Fatalf("Expected %T for record 1, got %v", fieldFormatError, err)
+	}
+}
@@ -0,0 +1,135 @@
+package typedcsv
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// Upsert merges the records into the CSV file at path by the key column.
+//
+// A record whose key matches an existing row replaces the values of the row, keeping its position and
+// the values of the columns that are not mapped to a field. The other records are appended in order.
+// If several records have the same key, the last one wins. If the file does not exist, it is created with the header.
+//
+// The file is written atomically: the merged file is written to a temporary file in the same directory,
+// which is then renamed to path. It returns an error if the key is not a column of T, or if a column of T
+// is missing in the header of the existing file.
+func Upsert[T any](path, key string, records []T, opts ...Option) error {
+	codec := NewCodec[T](opts...)
+	canonical := codec.options.nameMapper.Canonical
+	keyField := -1
+	for i, f := range codec.fields {
+		if f.key == canonical(key) {
+			keyField = i
+			break
+		}
+	}
+	if keyField < 0 {
+		return fmt.Errorf("typedcsv: key column %q not found", key)
+	}
+
+	header := codec.Header()
+	var rows [][]string
+	mode := fs.FileMode(0o644)
+	file, err := os.Open(path)
+	switch {
+	case err == nil:
+		reader := csv.NewReader(skipBOM(file))
+		reader.FieldsPerRecord = -1
+		rows, err = reader.ReadAll()
+		if stat, statErr := file.Stat(); statErr == nil {
+			mode = stat.Mode().Perm()
+		}
+		file.Close()
+		if err != nil {
+			return err
+		}
+		if len(rows) > 0 {
+			header, rows = rows[0], rows[1:]
+		}
+	case !errors.Is(err, fs.ErrNotExist):
+		return err
+	}
+
+	columns := codec.bind(headerIndex(header))
+	for i, index := range columns {
+		if index < 0 {
+			return fmt.Errorf("typedcsv: column %q not found in %s", codec.fields[i].name, path)
+		}
+	}
+	positions := make(map[string]int, len(rows))
+	for i, row := range rows {
+		if index := columns[keyField]; index < len(row) {
+			if _, ok := positions[row[index]]; !ok {
+				positions[row[index]] = i
+			}
+		}
+	}
+	for _, record := range records {
+		values, err := codec.encode(record)
+		if err != nil {
+			return err
+		}
+		position, ok := positions[values[keyField]]
+		if !ok {
+			position = len(rows)
+			positions[values[keyField]] = position
+			rows = append(rows, nil)
+		}
+		row := make([]string, max(len(header), len(rows[position])))
+		copy(row, rows[position])
+		for i, index := range columns {
+			row[index] = values[i]
+		}
+		rows[position] = row
+	}
+
+	return writeFileAtomically(path, mode, append([][]string{header}, rows...))
+}
+
+// headerIndex maps the columns of the header to their indices, keeping the first of duplicated columns.
+func headerIndex(header []string) map[string]int {
+	index := make(map[string]int, len(header))
+	for i, column := range header {
+		if _, ok := index[column]; !ok {
+			index[column] = i
+		}
+	}
+	return index
+}
+
+// writeFileAtomically writes the rows to a temporary file in the directory of path and renames it to path.
+func writeFileAtomically(path string, mode fs.FileMode, rows [][]string) (err error) {
+	temp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			temp.Close()
+			os.Remove(temp.Name())
+		}
+	}()
+	writer := csv.NewWriter(temp)
+	err = writer.WriteAll(rows)
+	if err != nil {
+		return err
+	}
+	err = temp.Chmod(mode)
+	if err != nil {
+		return err
+	}
+	err = temp.Sync()
+	if err != nil {
+		return err
+	}
+	err = temp.Close()
+	if err != nil {
+		return err
+	}
+	return os.Rename(temp.Name(), path)
+}
@@ -0,0 +1,90 @@
+package typedcsv_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hoshiumiarata/typedcsv"
+)
+
+type UpsertTestRecord struct {
+	ID   string `csv:"id"`
+	Name string `csv:"name"`
+}
+
+func TestUpsert(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "registry.csv")
+	err := typedcsv.Upsert(path, "id", []UpsertTestRecord{{ID: "b", Name: "Bob"}, {ID: "a", Name: "Alice"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := "id,name\nb,Bob\na,Alice\n"
+	if string(data) != expected {
+		t.Fatalf("Expected %q, got %q", expected, string(data))
+	}
+
+	err = os.WriteFile(path, []byte("note,id,name\nfirst,b,Bob\nsecond,a,Alice\n"), 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = os.Chmod(path, 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = typedcsv.Upsert(path, "id", []UpsertTestRecord{{ID: "c", Name: "Carol"}, {ID: "b", Name: "Robert"}, {ID: "c", Name: "Caroline"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err = os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected = "note,id,name\nfirst,b,Robert\nsecond,a,Alice\n,c,Caroline\n"
+	if string(data) != expected {
+		t.Fatalf("Expected %q, got %q", expected, string(data))
+	}
+	stat, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stat.Mode().Perm() != 0o600 {
+		t.Fatalf("Expected %v, got %v", os.FileMode(0o600), stat.Mode().Perm())
+	}
+	entries, err := os.ReadDir(filepath.Dir(path))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		t.Fatalf("Expected %d, got %d", 1, len(entries))
+	}
+}
+
+func TestUpsertErrors(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "registry.csv")
+	err := typedcsv.Upsert(path, "unknown", []UpsertTestRecord{{ID: "a"}})
+	expected := `typedcsv: key column "unknown" not found`
+	if err == nil || err.Error() != expected {
+		t.Fatalf("Expected %q, got %v", expected, err)
+	}
+
+	err = os.WriteFile(path, []byte("id\na\n"), 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = typedcsv.Upsert(path, "id", []UpsertTestRecord{{ID: "a"}})
+	if err == nil {
+		t.Fatal("Expected error, got nil")
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "id\na\n" {
+		t.Fatalf("Expected %q, got %q", "id\na\n", string(data))
+	}
+}
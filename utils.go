@@ -10,17 +10,28 @@ const (
 	csvTag          = "csv"
 	nullTag         = "null"
 	formatTag       = "format"
+	parseTag        = "parse"
 	timeFormatTag   = "time_format"
 	timeLocationTag = "time_location"
+	timeZoneTag     = "time_zone"
+	timeTruncateTag = "time_truncate"
+	timeRoundTag    = "time_round"
+	durationTag     = "duration_format"
+	preferTag       = "prefer"
 	separatorTag    = "separator"
+	quotedTag       = "quoted"
+	encodingTag     = "encoding"
+	prefixTag       = "prefix"
+	includeIfTag    = "include_if"
+	descTag         = "desc"
+	unitTag         = "unit"
+	defaultTag      = "default"
 )
 
 var (
 	timeType            = reflect.TypeOf(time.Time{})
+	durationType        = reflect.TypeOf(time.Duration(0))
+	rawType             = reflect.TypeOf(Raw(""))
 	textMarshalerType   = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
 	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
 )
-
-func isValidCSVField(field reflect.StructField) bool {
-	return field.IsExported() && field.Tag.Get(csvTag) != ""
-}
@@ -0,0 +1,97 @@
+package typedcsv
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const (
+	requiredTag = "required"
+	patternTag  = "pattern"
+	minTag      = "min"
+	maxTag      = "max"
+)
+
+// validationOf returns the validations given by the "required", "enum", "pattern", "min" and "max" tag values,
+// with the same meaning as in a SchemaColumn, or nil if there are none.
+// A field is also required if its "csv" tag has the "required" option, as in `csv:"name,required"`.
+func validationOf(tag reflect.StructTag, enum []string) (*SchemaColumn, error) {
+	c := SchemaColumn{Enum: enum}
+	c.Required = tag.Get(requiredTag) == "true" || hasRequiredOption(tag.Get(csvTag))
+	if pattern, ok := tag.Lookup(patternTag); ok {
+		compiled, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid pattern: %w", err)
+		}
+		c.Pattern, c.pattern = pattern, compiled
+	}
+	for _, bound := range []struct {
+		key   string
+		value **float64
+	}{{minTag, &c.Min}, {maxTag, &c.Max}} {
+		if text, ok := tag.Lookup(bound.key); ok {
+			n, err := strconv.ParseFloat(text, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid %s %q", bound.key, text)
+			}
+			*bound.value = &n
+		}
+	}
+	if !c.Required && c.Enum == nil && c.pattern == nil && c.Min == nil && c.Max == nil {
+		return nil, nil
+	}
+	return &c, nil
+}
+
+// hasRequiredOption reports whether a "csv" tag value has the "required" option.
+func hasRequiredOption(tag string) bool {
+	_, options, _ := strings.Cut(tag, ",")
+	return hasTagOption(strings.Split(options, ","), requiredTag)
+}
+
+// required reports whether the field is required by its tags.
+func (f *field) required() bool {
+	return f.validation != nil && f.validation.Required
+}
+
+// errMissingColumn returns the error of a required field whose column is missing.
+func (f *field) errMissingColumn() error {
+	return FieldParseError{Field: f.name, NestedError: fmt.Errorf("%w: column is missing", ErrValidation)}
+}
+
+// validate checks the CSV value of the field and its Go value against the validation tags.
+// A nil pointer is only checked against the "required" tag value.
+func (f *field) validate(value string, fieldValue reflect.Value) error {
+	if f.validationErr != nil {
+		return f.validationErr
+	}
+	c := f.validation
+	if f.pointer {
+		if fieldValue.IsNil() {
+			if c.Required {
+				return fmt.Errorf("%w: value is required", ErrValidation)
+			}
+			return nil
+		}
+		fieldValue = fieldValue.Elem()
+	}
+	err := c.validateText(value)
+	if err != nil {
+		return err
+	}
+	var parsed any
+	switch fieldValue.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		parsed = fieldValue.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		parsed = fieldValue.Uint()
+	case reflect.Float32, reflect.Float64:
+		parsed = fieldValue.Float()
+	case reflect.String:
+		parsed = fieldValue.String()
+	}
+	return c.validateRange(parsed, value)
+}
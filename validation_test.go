@@ -0,0 +1,123 @@
+package typedcsv_test
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"testing"
+
+	"github.com/hoshiumiarata/typedcsv"
+)
+
+type ValidationTestRecord struct {
+	Code     string  `csv:"code" required:"true" pattern:"^[A-Z]{3}$"`
+	Status   string  `csv:"status" enum:"active|inactive"`
+	Age      int     `csv:"age" min:"0" max:"150"`
+	Name     string  `csv:"name" max:"5"`
+	Optional *string `csv:"optional" null:"" required:"true"`
+}
+
+func TestValidationOnRead(t *testing.T) {
+	tests := []struct {
+		row      string
+		expected string
+	}{
+		{"ABC,active,30,John,x", ""},
+		{",active,30,John,x", "typedcsv: error parsing field 'code' in record 1 on line 2: typedcsv: validation failed: value is required"},
+		{"abc,active,30,John,x", "typedcsv: error parsing field 'code' in record 1 on line 2: typedcsv: validation failed: value 'abc' does not match pattern '^[A-Z]{3}$'"},
+		{"ABC,gone,30,John,x", "typedcsv: error parsing field 'status' in record 1 on line 2: typedcsv: validation failed: value 'gone' is not one of active, inactive"},
+		{"ABC,active,151,John,x", "typedcsv: error parsing field 'age' in record 1 on line 2: typedcsv: validation failed: value '151' is greater than 150"},
+		{"ABC,active,30,Johnny,x", "typedcsv: error parsing field 'name' in record 1 on line 2: typedcsv: validation failed: length of value 'Johnny' is greater than 5"},
+		{"ABC,active,30,John,", "typedcsv: error parsing field 'optional' in record 1 on line 2: typedcsv: validation failed: value is required"},
+	}
+	for _, test := range tests {
+		reader := bytes.Buffer{}
+		reader.WriteString("code,status,age,name,optional\n" + test.row + "\n")
+		csvReader := typedcsv.NewReader[ValidationTestRecord](csv.NewReader(&reader))
+		err := csvReader.ReadHeader()
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = csvReader.ReadRecord()
+		if test.expected == "" {
+			if err != nil {
+				t.Fatalf("%q: %v", test.row, err)
+			}
+			continue
+		}
+		if err == nil || err.Error() != test.expected {
+			t.Fatalf("%q: Expected %q, got %v", test.row, test.expected, err)
+		}
+		var fieldParseError typedcsv.FieldParseError
+		if !errors.As(err, &fieldParseError) || !errors.Is(err, typedcsv.ErrValidation) {
+			t.Fatalf("%q: Expected %T wrapping %v, got %v", test.row, fieldParseError, typedcsv.ErrValidation, err)
+		}
+	}
+}
+
+func TestValidationOnWrite(t *testing.T) {
+	optional := "x"
+	valid := ValidationTestRecord{Code: "ABC", Status: "active", Age: 30, Name: "John", Optional: &optional}
+	writer := bytes.Buffer{}
+	csvWriter := typedcsv.NewWriter[ValidationTestRecord](csv.NewWriter(&writer))
+	err := csvWriter.WriteRecord(valid)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	invalid := valid
+	invalid.Age = -1
+	err = csvWriter.WriteRecord(invalid)
+	expected := "typedcsv: error formatting field 'age' in record 2: typedcsv: validation failed: value '-1' is less than 0"
+	if err == nil || err.Error() != expected {
+		t.Fatalf("Expected %q, got %v", expected, err)
+	}
+	var fieldFormatError typedcsv.FieldFormatError
+	if !errors.As(err, &fieldFormatError) || !errors.Is(err, typedcsv.ErrValidation) {
+		t.Fatalf("Expected %T wrapping %v, got %v", fieldFormatError, typedcsv.ErrValidation, err)
+	}
+
+	invalid = valid
+	invalid.Optional = nil
+	err = csvWriter.WriteRecord(invalid)
+	expected = "typedcsv: error formatting field 'optional' in record 3: typedcsv: validation failed: value is required"
+	if err == nil || err.Error() != expected {
+		t.Fatalf("Expected %q, got %v", expected, err)
+	}
+	csvWriter.Flush()
+	if writer.String() != "ABC,active,30,John,x\n" {
+		t.Fatalf("Expected %q, got %q", "ABC,active,30,John,x\n", writer.String())
+	}
+}
+
+type RequiredTestRecord struct {
+	ID   string `csv:"id,required"`
+	Name string `csv:"name"`
+}
+
+func TestRequiredColumn(t *testing.T) {
+	if header := typedcsv.NewCodec[RequiredTestRecord]().Header(); header[0] != "id" {
+		t.Fatalf("Expected column id, got %v", header)
+	}
+	csvReader := typedcsv.NewReader[RequiredTestRecord](csv.NewReader(bytes.NewBufferString("name\nAlice\n")))
+	err := csvReader.ReadHeader()
+	expected := "typedcsv: error parsing field 'id': typedcsv: validation failed: column is missing"
+	if err == nil || err.Error() != expected || !errors.Is(err, typedcsv.ErrValidation) {
+		t.Fatalf("Expected %v, got %v", expected, err)
+	}
+	_, err = csvReader.ReadRecord()
+	expected = "typedcsv: error parsing field 'id' in record 1 on line 2: typedcsv: validation failed: column is missing"
+	if err == nil || err.Error() != expected {
+		t.Fatalf("Expected %v, got %v", expected, err)
+	}
+
+	csvReader = typedcsv.NewReader[RequiredTestRecord](csv.NewReader(bytes.NewBufferString("id,name\n,Alice\n1,Bob\n")))
+	if err := csvReader.ReadHeader(); err != nil {
+		t.Fatal(err)
+	}
+	records, err := csvReader.ReadAllLenient()
+	expected = "typedcsv: error parsing field 'id' in record 1 on line 2: typedcsv: validation failed: value is required"
+	if len(records) != 1 || records[0].ID != "1" || err == nil || err.Error() != expected {
+		t.Fatalf("Expected %v, got %v and %v", expected, records, err)
+	}
+}
@@ -0,0 +1,97 @@
+package typedcsv
+
+import "reflect"
+
+const cacheTag = "cache"
+
+// WithValueCache makes a TypedCSVReader memoize the parsed values of each column by their CSV value,
+// so that the repeated values of low-cardinality columns, such as enums, times or custom types with an expensive
+// UnmarshalText, are parsed once and copied afterwards. Parsing must only depend on the CSV value, which holds
+// for all the tags of this package; a field with the "cache" tag value "false" is always parsed, for example
+// if its UnmarshalText has side effects or a Resolver returns changing values.
+//
+// Only the values of fields whose types hold no slices, maps, interfaces or pointers, except to the parsed value itself
+// and within time.Time, are cached, and values whose parsing reported a Warning are not.
+// maxValues bounds the number of values kept per column. If maxValues is 0, there is no limit.
+// The caches are kept until the next call to ReadHeader or Reset.
+func WithValueCache(maxValues int) Option {
+	return func(o *options) {
+		o.valueCache = true
+		o.valueCacheLimit = maxValues
+	}
+}
+
+// A valueCache holds the parsed values of each field by CSV value.
+type valueCache struct {
+	limit  int
+	fields []map[string]reflect.Value
+}
+
+// newValueCache returns a new valueCache for the given number of fields, or nil if caching is disabled.
+func newValueCache(o *options, fields int) *valueCache {
+	if !o.valueCache {
+		return nil
+	}
+	return &valueCache{limit: o.valueCacheLimit, fields: make([]map[string]reflect.Value, fields)}
+}
+
+// get sets fieldValue to the cached value of the field for the CSV value and reports whether there was one.
+func (c *valueCache) get(i int, f *field, value string, fieldValue reflect.Value) bool {
+	if !f.cacheable {
+		return false
+	}
+	cached, ok := c.fields[i][value]
+	if !ok {
+		return false
+	}
+	if f.pointer && !cached.IsNil() {
+		pointer := reflect.New(f.typ)
+		pointer.Elem().Set(cached.Elem())
+		cached = pointer
+	}
+	fieldValue.Set(cached)
+	return true
+}
+
+// put caches the parsed value of the field for the CSV value.
+func (c *valueCache) put(i int, f *field, value string, fieldValue reflect.Value) {
+	if !f.cacheable {
+		return
+	}
+	values := c.fields[i]
+	if values == nil {
+		values = make(map[string]reflect.Value)
+		c.fields[i] = values
+	}
+	if c.limit > 0 && len(values) >= c.limit {
+		return
+	}
+	cached := reflect.New(fieldValue.Type()).Elem()
+	cached.Set(fieldValue)
+	if f.pointer && !cached.IsNil() {
+		pointer := reflect.New(f.typ)
+		pointer.Elem().Set(cached.Elem())
+		cached.Set(pointer)
+	}
+	values[value] = cached
+}
+
+// isCacheable reports whether the values of type t can be copied without sharing mutable memory.
+func isCacheable(t reflect.Type) bool {
+	if t == timeType || t.ConvertibleTo(timeType) {
+		return true
+	}
+	switch t.Kind() {
+	case reflect.Slice, reflect.Map, reflect.Chan, reflect.Func, reflect.Interface, reflect.Ptr, reflect.UnsafePointer:
+		return false
+	case reflect.Array:
+		return isCacheable(t.Elem())
+	case reflect.Struct:
+		for i := 0; i < t.NumField(); i++ {
+			if !isCacheable(t.Field(i).Type) {
+				return false
+			}
+		}
+	}
+	return true
+}
@@ -0,0 +1,81 @@
+package typedcsv_test
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hoshiumiarata/typedcsv"
+)
+
+var countingUnmarshals int
+
+type CountingCode string
+
+func (c *CountingCode) UnmarshalText(text []byte) error {
+	countingUnmarshals++
+	*c = CountingCode(text)
+	return nil
+}
+
+type ValueCacheTestRecord struct {
+	Code     CountingCode  `csv:"code"`
+	Uncached CountingCode  `csv:"uncached" cache:"false"`
+	Time     *time.Time    `csv:"time" time_format:"2006-01-02" null:""`
+	Tags     []string      `csv:"tags" separator:";"`
+	Status   PersonStatus  `csv:"status"`
+	Count    int           `csv:"count"`
+	Timeout  time.Duration `csv:"timeout" duration_format:"seconds"`
+}
+
+func TestValueCache(t *testing.T) {
+	input := "code,uncached,time,tags,status,count,timeout\n" +
+		"A,A,2024-01-02,x;y,active,,30\n" +
+		"A,A,2024-01-02,x;y,active,,30\n" +
+		"B,B,,x;y,inactive,1,30\n"
+	countingUnmarshals = 0
+	csvReader := typedcsv.NewReader[ValueCacheTestRecord](csv.NewReader(bytes.NewBufferString(input)), typedcsv.WithValueCache(0))
+	if err := csvReader.ReadHeader(); err != nil {
+		t.Fatal(err)
+	}
+	records, err := csvReader.ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if countingUnmarshals != 5 {
+		t.Fatalf("Expected %v calls to UnmarshalText, got %v", 5, countingUnmarshals)
+	}
+	if records[0].Time == records[1].Time || *records[0].Time != *records[1].Time || records[2].Time != nil {
+		t.Fatalf("Expected equal times with distinct pointers, got %v, %v and %v", records[0].Time, records[1].Time, records[2].Time)
+	}
+	records[0].Tags[0] = "z"
+	if records[1].Tags[0] != "x" || records[1].Status != PersonStatusActive || records[1].Timeout != 30*time.Second {
+		t.Fatalf("Expected independent records, got %v", *records[1])
+	}
+	if warnings := csvReader.Warnings(); len(warnings) != 2 {
+		t.Fatalf("Expected a coerced value warning for each empty count, got %v", warnings)
+	}
+}
+
+func BenchmarkReadRecordValueCache(b *testing.B) {
+	var buffer bytes.Buffer
+	buffer.WriteString("code,uncached,time,tags,status,count,timeout\n")
+	for i := 0; i < benchmarkRows; i++ {
+		fmt.Fprintf(&buffer, "C%d,U,2024-01-%02d,x,active,%d,30\n", i%10, i%28+1, i%5)
+	}
+	data := buffer.Bytes()
+	codec := typedcsv.NewCodec[ValueCacheTestRecord](typedcsv.WithValueCache(0))
+	b.SetBytes(int64(len(data)))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		csvReader := codec.NewReader(csv.NewReader(bytes.NewReader(data)))
+		if err := csvReader.ReadHeader(); err != nil {
+			b.Fatal(err)
+		}
+		if _, err := csvReader.ReadAll(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,142 @@
+package typedcsv
+
+import (
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+)
+
+// ErrManifestMismatch is returned by VerifiedReader when the file does not match its manifest.
+var ErrManifestMismatch = errors.New("typedcsv: file does not match manifest")
+
+// LoadManifest reads a JSON Manifest, as written by ManifestWriter.
+func LoadManifest(r io.Reader) (Manifest, error) {
+	var manifest Manifest
+	err := json.NewDecoder(r).Decode(&manifest)
+	return manifest, err
+}
+
+// A VerifiedReader reads records like a TypedCSVReader and checks the file against a Manifest while reading.
+//
+// It fails as soon as the file has more bytes or records than the manifest,
+// and checks the digest, the size and the number of records when the end of the file is reached,
+// so that truncated or corrupted files are detected before their records are used.
+// It does not expose the underlying TypedCSVReader, whose methods would read records without these checks.
+type VerifiedReader[T any] struct {
+	reader   *TypedCSVReader[T]
+	manifest Manifest
+	digest   hash.Hash
+	counter  countingReader
+}
+
+// NewVerifiedReader returns a new VerifiedReader reading CSV from r and checking it against the manifest.
+// It returns an error if the digest algorithm of the manifest is not supported. Only "sha256" is supported.
+func NewVerifiedReader[T any](r io.Reader, manifest Manifest, opts ...Option) (*VerifiedReader[T], error) {
+	if manifest.Algorithm != manifestAlgorithm {
+		return nil, fmt.Errorf("typedcsv: unsupported manifest algorithm %q", manifest.Algorithm)
+	}
+	vr := &VerifiedReader[T]{
+		manifest: manifest,
+		digest:   sha256.New(),
+	}
+	vr.counter.reader = io.TeeReader(r, vr.digest)
+	vr.reader = NewReader[T](csv.NewReader(skipBOM(&vr.counter)), opts...)
+	return vr, nil
+}
+
+// ReadHeader reads the CSV header, like TypedCSVReader.ReadHeader.
+// It returns an error wrapping ErrManifestMismatch if the file is larger than the manifest size.
+func (r *VerifiedReader[T]) ReadHeader() error {
+	err := r.reader.ReadHeader()
+	if err == nil {
+		err = r.checkSize()
+	}
+	return err
+}
+
+// ReadRecord reads the CSV record, like TypedCSVReader.ReadRecord.
+// It returns an error wrapping ErrManifestMismatch if the file has more bytes or records than the manifest,
+// or if its digest, size or number of records does not match the manifest at the end of the file.
+// It returns io.EOF only if the whole file matches the manifest.
+// Records that cannot be parsed are counted as records of the file, so that reading can go on after them.
+func (r *VerifiedReader[T]) ReadRecord() (*T, error) {
+	record, err := r.reader.ReadRecord()
+	if err == io.EOF {
+		if err := r.check(); err != nil {
+			return nil, err
+		}
+		return nil, io.EOF
+	}
+	if err != nil {
+		return record, err
+	}
+	if r.reader.row > r.manifest.Rows {
+		return nil, fmt.Errorf("%w: more than %d records", ErrManifestMismatch, r.manifest.Rows)
+	}
+	if err := r.checkSize(); err != nil {
+		return nil, err
+	}
+	return record, nil
+}
+
+// ReadAll reads all the remaining records, like TypedCSVReader.ReadAll, and checks the file against the manifest.
+func (r *VerifiedReader[T]) ReadAll() (records []*T, err error) {
+	for {
+		record, err := r.ReadRecord()
+		if err == io.EOF {
+			return records, nil
+		}
+		if err != nil {
+			return records, err
+		}
+		records = append(records, record)
+	}
+}
+
+// Warnings returns the warnings reported since ReadHeader, like TypedCSVReader.Warnings.
+func (r *VerifiedReader[T]) Warnings() []Warning {
+	return r.reader.Warnings()
+}
+
+// HeaderFields returns the header read by ReadHeader, like TypedCSVReader.HeaderFields.
+func (r *VerifiedReader[T]) HeaderFields() []string {
+	return r.reader.HeaderFields()
+}
+
+func (r *VerifiedReader[T]) checkSize() error {
+	if r.counter.n > r.manifest.Bytes {
+		return fmt.Errorf("%w: more than %d bytes", ErrManifestMismatch, r.manifest.Bytes)
+	}
+	return nil
+}
+
+// check checks the whole file against the manifest.
+func (r *VerifiedReader[T]) check() error {
+	if r.counter.n != r.manifest.Bytes {
+		return fmt.Errorf("%w: %d bytes, expected %d", ErrManifestMismatch, r.counter.n, r.manifest.Bytes)
+	}
+	if r.reader.row != r.manifest.Rows {
+		return fmt.Errorf("%w: %d records, expected %d", ErrManifestMismatch, r.reader.row, r.manifest.Rows)
+	}
+	if digest := hex.EncodeToString(r.digest.Sum(nil)); digest != r.manifest.Digest {
+		return fmt.Errorf("%w: digest %s, expected %s", ErrManifestMismatch, digest, r.manifest.Digest)
+	}
+	return nil
+}
+
+// A countingReader counts the bytes read from the underlying reader.
+type countingReader struct {
+	reader io.Reader
+	n      int64
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+	r.n += int64(n)
+	return n, err
+}
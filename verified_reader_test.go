@@ -0,0 +1,130 @@
+package typedcsv_test
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/hoshiumiarata/typedcsv"
+)
+
+func writeWithManifest(t *testing.T) (string, typedcsv.Manifest) {
+	data := bytes.Buffer{}
+	manifest := bytes.Buffer{}
+	csvWriter := typedcsv.NewManifestWriter[MarshalTextTestRecord](&data, &manifest)
+	err := csvWriter.WriteHeader()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, status := range []PersonStatus{PersonStatusActive, PersonStatusInactive, PersonStatusActive} {
+		err = csvWriter.WriteRecord(MarshalTextTestRecord{PersonStatus: status})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	err = csvWriter.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := typedcsv.LoadManifest(&manifest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data.String(), loaded
+}
+
+func TestVerifiedReader(t *testing.T) {
+	data, manifest := writeWithManifest(t)
+	csvReader, err := typedcsv.NewVerifiedReader[MarshalTextTestRecord](strings.NewReader(data), manifest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = csvReader.ReadHeader()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if header := csvReader.HeaderFields(); len(header) != 1 || header[0] != "person_status" {
+		t.Fatalf("Expected %v, got %v", []string{"person_status"}, header)
+	}
+	records, err := csvReader.ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 3 {
+		t.Fatalf("Expected %d, got %d", 3, len(records))
+	}
+}
+
+func TestVerifiedReaderMismatch(t *testing.T) {
+	data, manifest := writeWithManifest(t)
+	tests := []struct {
+		name     string
+		data     string
+		expected string
+	}{
+		{"truncated", strings.TrimSuffix(data, "active\n"), "typedcsv: file does not match manifest: 30 bytes, expected 37"},
+		{"corrupted", strings.Replace(data, "inactive", "active\nactive", 1), "typedcsv: file does not match manifest: more than 37 bytes"},
+		{"modified", strings.Replace(data, "active\ninactive", "inactive\nactive", 1), "typedcsv: file does not match manifest: digest"},
+	}
+	for _, test := range tests {
+		csvReader, err := typedcsv.NewVerifiedReader[MarshalTextTestRecord](strings.NewReader(test.data), manifest)
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = csvReader.ReadHeader()
+		if err == nil {
+			_, err = csvReader.ReadAll()
+		}
+		if !errors.Is(err, typedcsv.ErrManifestMismatch) {
+			t.Fatalf("%s: Expected %v, got %v", test.name, typedcsv.ErrManifestMismatch, err)
+		}
+		if !strings.HasPrefix(err.Error(), test.expected) {
+			t.Fatalf("%s: Expected %q, got %q", test.name, test.expected, err.Error())
+		}
+	}
+
+	manifest.Algorithm = "md5"
+	_, err := typedcsv.NewVerifiedReader[MarshalTextTestRecord](strings.NewReader(data), manifest)
+	if err == nil {
+		t.Fatal("Expected error, got nil")
+	}
+}
+
+type VerifiedReaderTestRecord struct {
+	Count int `csv:"count"`
+}
+
+func TestVerifiedReaderSkipsBadRow(t *testing.T) {
+	data := "count\n1\nx\n3\n"
+	digest := sha256.Sum256([]byte(data))
+	manifest := typedcsv.Manifest{Algorithm: "sha256", Digest: hex.EncodeToString(digest[:]), Bytes: int64(len(data)), Rows: 3}
+	csvReader, err := typedcsv.NewVerifiedReader[VerifiedReaderTestRecord](strings.NewReader(data), manifest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := csvReader.ReadHeader(); err != nil {
+		t.Fatal(err)
+	}
+	var counts []int
+	for {
+		record, err := csvReader.ReadRecord()
+		if err == io.EOF {
+			break
+		}
+		var fieldParseError typedcsv.FieldParseError
+		if errors.As(err, &fieldParseError) {
+			continue
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		counts = append(counts, record.Count)
+	}
+	if len(counts) != 2 || counts[0] != 1 || counts[1] != 3 {
+		t.Fatalf("Expected %v, got %v", []int{1, 3}, counts)
+	}
+}
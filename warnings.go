@@ -0,0 +1,145 @@
+package typedcsv
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+)
+
+// A WarningKind identifies the kind of a Warning.
+type WarningKind int
+
+const (
+	// WarningDuplicateColumn is reported when a header column appears more than once. Only the first one is used.
+	WarningDuplicateColumn WarningKind = iota
+	// WarningMissingColumn is reported when the column of a field is not in the header.
+	WarningMissingColumn
+	// WarningUnknownColumn is reported when a header column is not mapped to any field.
+	WarningUnknownColumn
+	// WarningMissingValue is reported when a record has fewer values than the header and the value of a field is missing.
+	WarningMissingValue
+	// WarningCoercedValue is reported when a value that cannot be scanned, such as an empty value, is coerced to the zero value.
+	WarningCoercedValue
+	// WarningReorderedColumn is reported when the column of a field is not in declaration order in the header.
+	// It is only reported with WithColumnOrderCheck.
+	WarningReorderedColumn
+	// WarningRoundTripMismatch is reported when a value re-encoded after decoding differs from the CSV value.
+	// It is only reported with WithRoundTripAudit.
+	WarningRoundTripMismatch
+)
+
+// String returns a description of the warning kind.
+func (k WarningKind) String() string {
+	switch k {
+	case WarningDuplicateColumn:
+		return "duplicate column"
+	case WarningMissingColumn:
+		return "missing column"
+	case WarningUnknownColumn:
+		return "unknown column"
+	case WarningMissingValue:
+		return "skipped missing value"
+	case WarningCoercedValue:
+		return "coerced value to zero value"
+	case WarningReorderedColumn:
+		return "reordered column"
+	case WarningRoundTripMismatch:
+		return "round-trip mismatch"
+	default:
+		return "unknown warning"
+	}
+}
+
+// A Warning describes an issue found while reading that is not an error.
+type Warning struct {
+	Kind WarningKind
+	// Row is the one-based number of the record, or 0 for warnings about the header.
+	Row int
+	// Column is the name of the column.
+	Column string
+	// Index is the index of the column in the header, or -1 if not applicable.
+	Index int
+	// Value is the CSV value, if applicable.
+	Value string
+	// Encoded is the value re-encoded after decoding, for a WarningRoundTripMismatch.
+	Encoded string
+}
+
+// String returns a human-readable description of the warning.
+func (w Warning) String() string {
+	s := fmt.Sprintf("typedcsv: %s '%s'", w.Kind, w.Column)
+	if w.Row > 0 {
+		s += fmt.Sprintf(" in record %d", w.Row)
+	}
+	switch w.Kind {
+	case WarningCoercedValue:
+		s += fmt.Sprintf(" (value %q)", w.Value)
+	case WarningRoundTripMismatch:
+		s += fmt.Sprintf(" (value %q re-encoded as %q)", w.Value, w.Encoded)
+	}
+	return s
+}
+
+// WithColumnOrderCheck makes ReadHeader report a WarningReorderedColumn for each field whose column is not
+// in the same relative order in the header as the fields in the struct, for example when a vendor silently
+// reorders the columns of an export. Reading still succeeds. See also Mapping.ReorderedFields.
+func WithColumnOrderCheck() Option {
+	return func(o *options) {
+		o.columnOrderCheck = true
+	}
+}
+
+// WithWarningHandler sets a function called synchronously for each Warning.
+// Warnings are also logged with the logger set by WithLogger and returned by TypedCSVReader.Warnings.
+func WithWarningHandler(handler func(Warning)) Option {
+	return func(o *options) {
+		o.warningHandler = handler
+	}
+}
+
+// defaultWarningLimit is the number of warnings kept by TypedCSVReader.Warnings unless WithWarningLimit is set.
+const defaultWarningLimit = 1000
+
+// WithWarningLimit bounds the number of warnings kept by TypedCSVReader.Warnings, 1000 by default,
+// so that files with a warning on every record do not grow them without limit.
+// Warnings beyond the limit are still passed to the handler set by WithWarningHandler and logged.
+// If maxWarnings is 0, there is no limit.
+func WithWarningLimit(maxWarnings int) Option {
+	return func(o *options) {
+		o.warningLimit = maxWarnings
+	}
+}
+
+// keepWarning appends the warning to warnings, unless they already hold the number of warnings set by WithWarningLimit.
+func (o *options) keepWarning(warnings *[]Warning, w Warning) {
+	if o.warningLimit == 0 || len(*warnings) < o.warningLimit {
+		*warnings = append(*warnings, w)
+	}
+}
+
+func (o *options) warn(w Warning) {
+	if o.logger != nil {
+		level := slog.LevelWarn
+		if w.Kind == WarningCoercedValue {
+			level = slog.LevelDebug
+		}
+		args := make([]any, 0, 8)
+		if w.Row > 0 {
+			args = append(args, "row", w.Row)
+		}
+		args = append(args, "column", w.Column)
+		if w.Index >= 0 {
+			args = append(args, "index", w.Index)
+		}
+		switch w.Kind {
+		case WarningCoercedValue:
+			args = append(args, "value", w.Value)
+		case WarningRoundTripMismatch:
+			args = append(args, "value", w.Value, "encoded", w.Encoded)
+		}
+		o.logger.Log(context.Background(), level, "typedcsv: "+w.Kind.String(), args...)
+	}
+	if o.warningHandler != nil {
+		o.warningHandler(w)
+	}
+}
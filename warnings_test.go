@@ -0,0 +1,74 @@
+package typedcsv_test
+
+import (
+	"bytes"
+	"encoding/csv"
+	"reflect"
+	"testing"
+
+	"github.com/hoshiumiarata/typedcsv"
+)
+
+func TestWarnings(t *testing.T) {
+	reader := bytes.Buffer{}
+	reader.WriteString("name,age,extra,name\n")
+	reader.WriteString("John,,x,y\n")
+	var handled []typedcsv.Warning
+	csvReader := typedcsv.NewReader[Person](csv.NewReader(&reader), typedcsv.WithWarningHandler(func(w typedcsv.Warning) {
+		handled = append(handled, w)
+	}))
+	err := csvReader.ReadHeader()
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = csvReader.ReadRecord()
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []typedcsv.Warning{
+		{Kind: typedcsv.WarningDuplicateColumn, Column: "name", Index: 3},
+		{Kind: typedcsv.WarningMissingColumn, Column: "birthday", Index: -1},
+		{Kind: typedcsv.WarningMissingColumn, Column: "pet names", Index: -1},
+		{Kind: typedcsv.WarningMissingColumn, Column: "active", Index: -1},
+		{Kind: typedcsv.WarningMissingColumn, Column: "status", Index: -1},
+		{Kind: typedcsv.WarningMissingColumn, Column: "percentage", Index: -1},
+		{Kind: typedcsv.WarningMissingColumn, Column: "optional", Index: -1},
+		{Kind: typedcsv.WarningUnknownColumn, Column: "extra", Index: 2},
+		{Kind: typedcsv.WarningCoercedValue, Row: 1, Column: "age", Index: -1, Value: ""},
+	}
+	if !reflect.DeepEqual(csvReader.Warnings(), expected) {
+		t.Fatalf("Expected %v, got %v", expected, csvReader.Warnings())
+	}
+	if !reflect.DeepEqual(handled, expected) {
+		t.Fatalf("Expected %v, got %v", expected, handled)
+	}
+	expectedString := `typedcsv: coerced value to zero value 'age' in record 1 (value "")`
+	if expected[8].String() != expectedString {
+		t.Fatalf("Expected %q, got %q", expectedString, expected[8].String())
+	}
+}
+
+func TestWarningLimit(t *testing.T) {
+	reader := bytes.Buffer{}
+	reader.WriteString("name,age\n")
+	for range 5 {
+		reader.WriteString("John,\n")
+	}
+	handled := 0
+	csvReader := typedcsv.NewReader[Person](csv.NewReader(&reader), typedcsv.WithWarningLimit(3), typedcsv.WithWarningHandler(func(typedcsv.Warning) {
+		handled++
+	}))
+	err := csvReader.ReadHeader()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := csvReader.ReadAll(); err != nil {
+		t.Fatal(err)
+	}
+	if len(csvReader.Warnings()) != 3 {
+		t.Fatalf("Expected %v, got %v", 3, len(csvReader.Warnings()))
+	}
+	if handled != 11 {
+		t.Fatalf("Expected %v, got %v", 11, handled)
+	}
+}
@@ -0,0 +1,102 @@
+package typedcsv
+
+import (
+	"archive/zip"
+	"bufio"
+	"encoding/xml"
+	"io"
+	"iter"
+	"strconv"
+)
+
+// xlsxParts are the parts of a workbook with one sheet, apart from the sheet itself.
+var xlsxParts = []struct{ name, content string }{
+	{"[Content_Types].xml", xml.Header + `<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+		`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+		`<Default Extension="xml" ContentType="application/xml"/>` +
+		`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+		`<Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>` +
+		`</Types>`},
+	{"_rels/.rels", xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+		`</Relationships>`},
+	{"xl/workbook.xml", xml.Header + `<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+		`<sheets><sheet name="Sheet1" sheetId="1" r:id="rId1"/></sheets></workbook>`},
+	{"xl/_rels/workbook.xml.rels", xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>` +
+		`</Relationships>`},
+}
+
+// exportXLSX writes the records as a workbook with one sheet, with inline strings so that no shared string table is needed.
+func exportXLSX[T any](w io.Writer, codec *Codec[T], records iter.Seq[T]) error {
+	if codec.typeErr != nil {
+		return codec.typeErr
+	}
+	archive := zip.NewWriter(w)
+	for _, part := range xlsxParts {
+		file, err := archive.Create(part.name)
+		if err != nil {
+			return err
+		}
+		if _, err := io.WriteString(file, part.content); err != nil {
+			return err
+		}
+	}
+	file, err := archive.Create("xl/worksheets/sheet1.xml")
+	if err != nil {
+		return err
+	}
+	sheet := bufio.NewWriter(file)
+	sheet.WriteString(xml.Header + `<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`)
+	header, fields := codec.fileHeader(), codec.fileFields()
+	writeXLSXRow(sheet, 1, header, make([]cellKind, len(header)))
+	kinds := make([]cellKind, len(header))
+	row := 1
+	for record := range records {
+		values, err := codec.fileRow(record)
+		if err != nil {
+			return err
+		}
+		codec.cellKinds(fields, values, kinds)
+		row++
+		writeXLSXRow(sheet, row, values, kinds)
+	}
+	sheet.WriteString(`</sheetData></worksheet>`)
+	if err := sheet.Flush(); err != nil {
+		return err
+	}
+	return archive.Close()
+}
+
+func writeXLSXRow(w *bufio.Writer, row int, values []string, kinds []cellKind) {
+	w.WriteString(`<row r="` + strconv.Itoa(row) + `">`)
+	for i, value := range values {
+		reference := xlsxColumn(i) + strconv.Itoa(row)
+		switch kinds[i] {
+		case cellNull:
+		case cellNumber:
+			w.WriteString(`<c r="` + reference + `"><v>` + value + `</v></c>`)
+		case cellBoolean:
+			b, _ := strconv.ParseBool(value)
+			v := "0"
+			if b {
+				v = "1"
+			}
+			w.WriteString(`<c r="` + reference + `" t="b"><v>` + v + `</v></c>`)
+		default:
+			w.WriteString(`<c r="` + reference + `" t="inlineStr"><is><t xml:space="preserve">`)
+			xml.EscapeText(w, []byte(value))
+			w.WriteString(`</t></is></c>`)
+		}
+	}
+	w.WriteString(`</row>`)
+}
+
+// xlsxColumn returns the letters of the zero-based column index, such as "A", "Z" and "AA".
+func xlsxColumn(index int) string {
+	var letters []byte
+	for index++; index > 0; index = (index - 1) / 26 {
+		letters = append([]byte{byte('A' + (index-1)%26)}, letters...)
+	}
+	return string(letters)
+}